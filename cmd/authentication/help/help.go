@@ -31,6 +31,7 @@ Usage:
 	authentication [[command] [arguments]]
 The commands are:
     config               display the default configuration
+    keys rotate          create a new token signing key
     run                  run node with existing configuration
     version              displays the authentication version
 "run" is the default command.
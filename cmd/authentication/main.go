@@ -114,6 +114,10 @@ func (m *Main) Run(args ...string) error {
 		if err := run.NewPrintConfigCommand().Run(args...); err != nil {
 			return fmt.Errorf("config: %s", err)
 		}
+	case "keys":
+		if err := run.NewKeysCommand().Run(args...); err != nil {
+			return fmt.Errorf("keys: %s", err)
+		}
 	case "version":
 		if err := NewVersionCommand().Run(args...); err != nil {
 			return fmt.Errorf("version: %s", err)
@@ -12,8 +12,11 @@ import (
 	"time"
 
 	"github.com/fkasper/sitrep-authentication/database"
+	"github.com/fkasper/sitrep-authentication/mailer"
 	"github.com/fkasper/sitrep-authentication/meta"
+	"github.com/fkasper/sitrep-authentication/passwords"
 	"github.com/fkasper/sitrep-authentication/services/httpd"
+	"github.com/fkasper/sitrep-authentication/signing"
 	regmeta "github.com/xpandmmi/registrator/meta"
 	"github.com/xpandmmi/registrator/services/registration"
 	"github.com/xpandmmi/registrator/services/selfheal"
@@ -24,6 +27,9 @@ type Config struct {
 	Meta         *meta.Config        `toml:"meta"`
 	HTTPD        httpd.Config        `toml:"http"`
 	Database     *database.Config    `toml:"database"`
+	Signing      *signing.Config     `toml:"signing"`
+	Mail         *mailer.Config      `toml:"mail"`
+	Passwords    *passwords.Config   `toml:"password-policy"`
 	RegMeta      *regmeta.Config     `toml:"service"`
 	Registration registration.Config `toml:"registration"`
 	Selfheal     selfheal.Config     `toml:"self-heal"`
@@ -35,6 +41,9 @@ func NewConfig() *Config {
 	c.Meta = meta.NewConfig()
 	c.HTTPD = httpd.NewConfig()
 	c.Database = database.NewConfig()
+	c.Signing = signing.NewConfig()
+	c.Mail = mailer.NewConfig()
+	c.Passwords = passwords.NewConfig()
 
 	c.RegMeta = regmeta.NewConfig()
 	c.Registration = registration.NewConfig()
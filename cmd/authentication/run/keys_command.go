@@ -0,0 +1,81 @@
+package run
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fkasper/sitrep-authentication/signing"
+)
+
+// KeysCommand represents the command executed by "authentication keys".
+type KeysCommand struct {
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// NewKeysCommand return a new instance of KeysCommand.
+func NewKeysCommand() *KeysCommand {
+	return &KeysCommand{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// Run executes the keys sub command given in args.
+func (cmd *KeysCommand) Run(args ...string) error {
+	if len(args) == 0 || args[0] != "rotate" {
+		fmt.Fprintln(cmd.Stderr, keysUsage)
+		return fmt.Errorf("unknown keys command")
+	}
+
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	configPath := fs.String("config", "", "")
+	algorithm := fs.String("algorithm", "", "")
+	activateIn := fs.Duration("activate-in", 0, "")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, keysUsage) }
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	config := NewConfig()
+	if *configPath != "" {
+		if _, err := toml.DecodeFile(*configPath, &config); err != nil {
+			return fmt.Errorf("parse config: %s", err)
+		}
+	}
+	if err := config.ApplyEnvOverrides(); err != nil {
+		return fmt.Errorf("apply env config: %v", err)
+	}
+	if config.Signing.KeysDir == "" {
+		return fmt.Errorf("signing.keys-dir must be specified to rotate keys")
+	}
+	alg := config.Signing.Algorithm
+	if *algorithm != "" {
+		alg = *algorithm
+	}
+
+	activatesAt := time.Now().Add(*activateIn)
+	key, err := signing.Rotate(config.Signing.KeysDir, alg, activatesAt, time.Duration(config.Signing.RotationOverlap))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "created %s key %s, signing from %s\n", alg, key.ID, activatesAt.Format(time.RFC3339))
+	fmt.Fprintf(cmd.Stdout, "previous keys verify tokens until %s\n", activatesAt.Add(time.Duration(config.Signing.RotationOverlap)).Format(time.RFC3339))
+	return nil
+}
+
+var keysUsage = `usage: keys rotate [flags]
+keys rotate adds a new signing key to the keys directory. The keys in use
+keep verifying tokens for the rotation overlap after the new key activates.
+        -config <path>
+                          Set the path to the configuration file.
+        -algorithm <RS256|ES256>
+                          Override the algorithm of the new key.
+        -activate-in <duration>
+                          Publish the key now, but only start signing with
+                          it after the duration has passed.
+`
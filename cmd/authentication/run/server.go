@@ -10,9 +10,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fkasper/sitrep-authentication/mailer"
 	"github.com/fkasper/sitrep-authentication/meta"
+	"github.com/fkasper/sitrep-authentication/passwords"
 	"github.com/fkasper/sitrep-authentication/services/httpd"
+	"github.com/fkasper/sitrep-authentication/services/keys"
 	"github.com/fkasper/sitrep-authentication/services/metrics"
+	"github.com/fkasper/sitrep-authentication/signing"
 	"github.com/gocql/gocql"
 	elastigo "github.com/mattbaird/elastigo/lib"
 	regmeta "github.com/xpandmmi/registrator/meta"
@@ -57,6 +61,16 @@ type Server struct {
 	// Database
 	elasticsearch *elastigo.Conn
 	cassandra     *gocql.ClusterConfig
+
+	// Token signing
+	keys *signing.KeyRing
+
+	// Outgoing mail
+	mailer mailer.Sender
+
+	// Rules for new passwords and how they are hashed
+	passwordPolicy *passwords.Policy
+	passwordHasher *passwords.Hasher
 }
 
 // NewServer returns a new instance of Server built from a config.
@@ -74,6 +88,20 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 		Sleep:      30 * time.Second,
 	}
 
+	ring, err := signing.LoadKeyRing(c.Signing)
+	if err != nil {
+		return nil, fmt.Errorf("load signing keys: %s", err)
+	}
+
+	policy, err := passwords.NewPolicy(c.Passwords)
+	if err != nil {
+		return nil, fmt.Errorf("load password policy: %s", err)
+	}
+	hasher, err := passwords.NewHasher(c.Passwords)
+	if err != nil {
+		return nil, fmt.Errorf("configure password hashing: %s", err)
+	}
+
 	s := &Server{
 		buildInfo: *buildInfo,
 		err:       make(chan error),
@@ -83,12 +111,20 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 		BindAddress:   c.Meta.BindAddress,
 		elasticsearch: elasticsearch,
 		cassandra:     db,
+		keys:          ring,
+
+		passwordPolicy: policy,
+		passwordHasher: hasher,
+	}
+	if sender := mailer.NewSMTPSender(c.Mail); sender != nil {
+		s.mailer = sender
 	}
 
 	// Append services.
 	//s.appendMongoService(c.Mongo)
 
 	s.appendMetricsReportingService(c.Meta)
+	s.appendKeysService(c.Signing)
 	s.appendHTTPDService(c.HTTPD)
 	s.appendRegistrationService(c.Registration, c.RegMeta)
 	return s, nil
@@ -106,6 +142,14 @@ func (s *Server) appendMetricsReportingService(c *meta.Config) {
 	s.Services = append(s.Services, srv)
 }
 
+func (s *Server) appendKeysService(c *signing.Config) {
+	if s.keys == nil || c.KeysDir == "" || c.ReloadInterval <= 0 {
+		return
+	}
+	srv := keys.NewService(c, s.keys)
+	s.Services = append(s.Services, srv)
+}
+
 func (s *Server) appendHTTPDService(c httpd.Config) {
 	if !c.Enabled {
 		return
@@ -114,6 +158,10 @@ func (s *Server) appendHTTPDService(c httpd.Config) {
 	srv.Handler.Version = s.buildInfo.Version
 	srv.Handler.Elasticsearch = s.elasticsearch
 	srv.Handler.Cassandra = s.cassandra
+	srv.Handler.Options.Keys = s.keys
+	srv.Handler.Options.Mailer = s.mailer
+	srv.Handler.Options.PasswordPolicy = s.passwordPolicy
+	srv.Handler.Options.PasswordHasher = s.passwordHasher
 	s.Services = append(s.Services, srv)
 }
 
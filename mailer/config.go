@@ -0,0 +1,27 @@
+package mailer
+
+const (
+	// DefaultPort is the SMTP submission port
+	DefaultPort = 587
+
+	// DefaultFrom is the sender address of outgoing mail
+	DefaultFrom = "SITREP <noreply@sitrep-vatcinc.com>"
+)
+
+// Config represents the configuration of the SMTP server mail is submitted
+// to. Without a host, no mail is sent.
+type Config struct {
+	Host     string `toml:"smtp-host"`
+	Port     int    `toml:"smtp-port"`
+	Username string `toml:"smtp-username"`
+	Password string `toml:"smtp-password"`
+	From     string `toml:"from"`
+}
+
+// NewConfig builds a new configuration with default values.
+func NewConfig() *Config {
+	return &Config{
+		Port: DefaultPort,
+		From: DefaultFrom,
+	}
+}
@@ -0,0 +1,90 @@
+// Package mailer sends the mail of the service, like password reset links.
+// Senders are pluggable, so tests can replace SMTP with an in-process fake.
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidHeader is returned for messages, whose recipient or subject
+// would inject additional headers
+var ErrInvalidHeader = errors.New("mailer: invalid header value")
+
+// Message is a plain text mail to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(msg *Message) error
+}
+
+// SMTPSender submits messages to an SMTP server
+type SMTPSender struct {
+	Addr string
+	From string
+	Auth smtp.Auth
+}
+
+// NewSMTPSender returns a sender for the server configured in c. It returns
+// nil, if no server is configured.
+func NewSMTPSender(c *Config) *SMTPSender {
+	if c == nil || c.Host == "" {
+		return nil
+	}
+	s := &SMTPSender{
+		Addr: net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		From: c.From,
+	}
+	if c.Username != "" {
+		s.Auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+	return s
+}
+
+// Send submits msg to the SMTP server
+func (s *SMTPSender) Send(msg *Message) error {
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+	data, err := msg.Bytes(s.From, time.Now())
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.Addr, s.Auth, from.Address, []string{to.Address}, data)
+}
+
+// Bytes formats the message for submission, sent by from at date
+func (m *Message) Bytes(from string, date time.Time) ([]byte, error) {
+	for _, v := range []string{from, m.To, m.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.Replace(m.Body, "\n", "\r\n", -1))
+	return b.Bytes(), nil
+}
@@ -0,0 +1,50 @@
+package mailer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := &mailer.Message{
+		To:      "someguy@somedomain.com",
+		Subject: "Reset your password",
+		Body:    "Hello\nWorld",
+	}
+	b, err := msg.Bytes(mailer.DefaultFrom, time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(b)
+	if !strings.HasPrefix(s, "From: "+mailer.DefaultFrom+"\r\nTo: someguy@somedomain.com\r\nSubject: Reset your password\r\n") {
+		t.Fatalf("unexpected headers: %q", s)
+	}
+	if !strings.HasSuffix(s, "\r\n\r\nHello\r\nWorld") {
+		t.Fatalf("unexpected body: %q", s)
+	}
+}
+
+func TestMessage_HeaderInjection(t *testing.T) {
+	msg := &mailer.Message{
+		To:      "someguy@somedomain.com\r\nBcc: other@somedomain.com",
+		Subject: "Reset your password",
+	}
+	if _, err := msg.Bytes(mailer.DefaultFrom, time.Now()); err != mailer.ErrInvalidHeader {
+		t.Fatalf("header injection was not refused: %v", err)
+	}
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	if s := mailer.NewSMTPSender(mailer.NewConfig()); s != nil {
+		t.Fatalf("sender without host was created")
+	}
+	c := mailer.NewConfig()
+	c.Host = "smtp.example.com"
+	s := mailer.NewSMTPSender(c)
+	if s == nil || s.Addr != "smtp.example.com:587" || s.Auth != nil {
+		t.Fatalf("unexpected sender: %+v", s)
+	}
+}
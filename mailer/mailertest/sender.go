@@ -0,0 +1,48 @@
+// Package mailertest provides a sender, which keeps messages in memory
+// instead of delivering them.
+package mailertest
+
+import (
+	"sync"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+)
+
+// Sender records every message sent through it
+type Sender struct {
+	mu       sync.Mutex
+	messages []*mailer.Message
+}
+
+// NewSender returns an empty Sender
+func NewSender() *Sender {
+	return &Sender{}
+}
+
+// Send records msg
+func (s *Sender) Send(msg *mailer.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent to the recipient to
+func (s *Sender) Messages(to string) []*mailer.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var messages []*mailer.Message
+	for _, msg := range s.messages {
+		if msg.To == to {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// Len returns the number of messages sent
+func (s *Sender) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages)
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// SetUserExpiry lets the account of the user with email expire at validTill.
+// Without validTill, the account never expires.
+func SetUserExpiry(cassandra *gocql.ClusterConfig, client *ClientInfo, actor string, email string, validTill *time.Time) (*sitrep.UsersByEmail, error) {
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil || user.Email == "" {
+		return nil, NewUserNotFoundError()
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	// The timestamp is kept, when expiry is cleared. It has no meaning
+	// without is_expiring.
+	update := ctx.Upsert(UsersTable).
+		SetBoolean(UsersTable.IS_EXPIRING, validTill != nil)
+	details := "never"
+	if validTill != nil {
+		update = update.SetTimestamp(UsersTable.ACCESS_VALID_TILL, *validTill)
+		details = validTill.UTC().Format(time.RFC3339)
+	}
+	if err := update.
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+	user.IsExpiring = validTill != nil
+	if validTill != nil {
+		user.AccessValidTill = *validTill
+	}
+	if err := RecordAuditEvent(cassandra, user.Email, AuditAccountExpiryChanged, client, actor+" "+details); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkAccountExpiry refuses users, whose account has expired
+func checkAccountExpiry(user *sitrep.UsersByEmail) error {
+	if user.IsExpiring && !time.Now().Before(user.AccessValidTill) {
+		return NewAccountExpiredError()
+	}
+	return nil
+}
+
+// capTokenLifetime shortens lifetime, so tokens of user do not outlive the
+// account
+func capTokenLifetime(user *sitrep.UsersByEmail, lifetime time.Duration) time.Duration {
+	if !user.IsExpiring {
+		return lifetime
+	}
+	if remaining := user.AccessValidTill.Sub(time.Now()); remaining < lifetime {
+		return remaining
+	}
+	return lifetime
+}
+
+// AccountExpiredError is returned, when the account of a user is past its expiry
+type AccountExpiredError struct {
+	Message string
+}
+
+// Error prints the AccountExpiredError
+func (a *AccountExpiredError) Error() string {
+	return a.Message
+}
+
+// NewAccountExpiredError produces a new AccountExpiredError
+func NewAccountExpiredError() *AccountExpiredError {
+	return &AccountExpiredError{
+		Message: "Your account has expired, please contact an administrator!",
+	}
+}
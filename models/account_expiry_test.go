@@ -0,0 +1,74 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func TestAccountExpiry_CapsTokenLifetime(t *testing.T) {
+	user := mockUser()
+	user.Email = "expiring@somedomain.com"
+	user.IsExpiring = true
+	user.AccessValidTill = time.Now().Add(2 * time.Minute)
+	initUser(user)
+	c := dbConn()
+
+	tokens, err := models.UserSignIn(c, models.NewOptions(), nil, user.Email, "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	if tokens.ExpiresIn <= 0 || tokens.ExpiresIn > 120 {
+		t.Fatalf("Access token outlives the account: %d", tokens.ExpiresIn)
+	}
+	if _, err := models.VerifyUserRequest(c, models.NewOptions(), tokens.AccessToken); err != nil {
+		t.Fatalf("Access token verification failed: %v", err)
+	}
+}
+
+func TestAccountExpiry_Expired(t *testing.T) {
+	user := mockUser()
+	user.Email = "expired@somedomain.com"
+	initUser(user)
+	c := dbConn()
+
+	tokens, err := models.UserSignIn(c, models.NewOptions(), nil, user.Email, "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	past := time.Now().Add(-time.Minute)
+	if _, err := models.SetUserExpiry(c, nil, "admin@somedomain.com", user.Email, &past); err != nil {
+		t.Fatalf("Expiry could not be set: %v", err)
+	}
+
+	if _, err := models.UserSignIn(c, models.NewOptions(), nil, user.Email, "test1234", "password"); err == nil {
+		t.Fatalf("Expired user could sign in")
+	} else if _, ok := err.(*models.AccountExpiredError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, models.NewOptions(), tokens.AccessToken); err == nil {
+		t.Fatalf("Token of an expired user was accepted")
+	} else if _, ok := err.(*models.AccountExpiredError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := models.RefreshSignIn(c, models.NewOptions(), nil, tokens.RefreshToken); err == nil {
+		t.Fatalf("Expired user could refresh the session")
+	}
+
+	if _, err := models.SetUserExpiry(c, nil, "admin@somedomain.com", user.Email, nil); err != nil {
+		t.Fatalf("Expiry could not be cleared: %v", err)
+	}
+	if _, err := models.UserSignIn(c, models.NewOptions(), nil, user.Email, "test1234", "password"); err != nil {
+		t.Fatalf("User could not sign in after the expiry was cleared: %v", err)
+	}
+}
+
+func TestAccountExpiry_UnknownUser(t *testing.T) {
+	c := dbConn()
+	if _, err := models.SetUserExpiry(c, nil, "admin@somedomain.com", "nobody@somedomain.com", nil); err == nil {
+		t.Fatalf("Expiry of an unknown user was changed")
+	} else if _, ok := err.(*models.UserNotFoundError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
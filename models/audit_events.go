@@ -0,0 +1,92 @@
+package models
+
+import (
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// AuditEventsTable is a reference to the security relevant events of users
+var AuditEventsTable = sitrep.AuditEventsTableDef()
+
+const (
+	// AuditTotpEnabled is recorded, when a user enables two factor authentication
+	AuditTotpEnabled = "mfa.totp_enabled"
+
+	// AuditTotpDisabled is recorded, when a user disables two factor authentication
+	AuditTotpDisabled = "mfa.totp_disabled"
+
+	// AuditRecoveryCodeUsed is recorded, when a recovery code replaces the authenticator app
+	AuditRecoveryCodeUsed = "mfa.recovery_code_used"
+
+	// AuditRecoveryCodesRegenerated is recorded, when a user replaces all recovery codes
+	AuditRecoveryCodesRegenerated = "mfa.recovery_codes_regenerated"
+
+	// AuditWebAuthnRegistered is recorded, when a user registers a passkey or security key
+	AuditWebAuthnRegistered = "webauthn.registered"
+
+	// AuditWebAuthnRemoved is recorded, when a user removes a passkey or security key
+	AuditWebAuthnRemoved = "webauthn.removed"
+
+	// AuditPasswordReset is recorded, when a user sets a new password with an emailed token
+	AuditPasswordReset = "password.reset"
+
+	// AuditRegistered is recorded, when a user creates an account
+	AuditRegistered = "account.registered"
+
+	// AuditInvitationSent is recorded, when a user invites somebody into an exercise
+	AuditInvitationSent = "invitation.sent"
+
+	// AuditInvitationRevoked is recorded, when a user withdraws an invitation
+	AuditInvitationRevoked = "invitation.revoked"
+
+	// AuditInvitationAccepted is recorded, when a user joins an exercise by invitation
+	AuditInvitationAccepted = "invitation.accepted"
+
+	// AuditAccountLocked is recorded, when too many failed sign in attempts lock an account
+	AuditAccountLocked = "account.locked"
+
+	// AuditAccountUnlocked is recorded, when an administrator lifts the lockout of an account
+	AuditAccountUnlocked = "account.unlocked"
+
+	// AuditAccountExpiryChanged is recorded, when an administrator extends or clears the expiry of an account
+	AuditAccountExpiryChanged = "account.expiry_changed"
+
+	// AuditImpersonationStarted is recorded for both users, when one starts acting as the other
+	AuditImpersonationStarted = "impersonation.started"
+
+	// AuditImpersonationUsed is recorded, whenever a request is made with an impersonation token
+	AuditImpersonationUsed = "impersonation.used"
+)
+
+// RecordAuditEvent adds an event to the audit log of a user
+func RecordAuditEvent(cassandra *gocql.ClusterConfig, email string, event string, client *ClientInfo, details string) error {
+	if client == nil {
+		client = &ClientInfo{}
+	}
+	auditEvent := &sitrep.AuditEvents{
+		UserEmail:  email,
+		EventId:    gocql.TimeUUID(),
+		Event:      event,
+		Details:    details,
+		RemoteAddr: client.RemoteAddr,
+		UserAgent:  client.UserAgent,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	return ctx.Store(AuditEventsTable.Bind(*auditEvent)).Exec(session)
+}
+
+// FindAuditEvents lists the audit log of a user, newest events first
+func FindAuditEvents(cassandra *gocql.ClusterConfig, email string) ([]sitrep.AuditEvents, error) {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(AuditEventsTable).
+		Where(
+		AuditEventsTable.USER_EMAIL.Eq(email)).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	return sitrep.BindAuditEvents(iter)
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// AuthorizationCodesTable is a reference to the authorization codes table
+var AuthorizationCodesTable = sitrep.AuthorizationCodesTableDef()
+
+// AuthorizationRequest describes what a client asked for at the authorization
+// endpoint
+type AuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+// HasScope reports whether the client asked for scope
+func (r *AuthorizationRequest) HasScope(scope string) bool {
+	return containsScope(parseScopes(r.Scope), scope)
+}
+
+// ValidateAuthorizationRequest checks that the client of req exists, may
+// redirect to the requested URI and protects the code with a PKCE challenge.
+// The scope of req is narrowed down to the scopes the client has been
+// registered for.
+func ValidateAuthorizationRequest(cassandra *gocql.ClusterConfig, req *AuthorizationRequest) (*sitrep.OauthClients, error) {
+	client, err := FindClientByID(cassandra, req.ClientID)
+	if err != nil {
+		return nil, NewClientInvalidError()
+	}
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return nil, NewRedirectURIInvalidError()
+	}
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != sitrep.CodeChallengeMethodS256 {
+		return nil, NewAuthorizationCodeInvalidError()
+	}
+	allowed := parseScopes(client.AllowedScopes)
+	granted := []string{}
+	for _, scope := range parseScopes(req.Scope) {
+		if containsScope(allowed, scope) && !containsScope(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	req.Scope = strings.Join(granted, " ")
+	return client, nil
+}
+
+// CreateAuthorizationCode issues a one-time code for user, which the client
+// of req can exchange for tokens
+func CreateAuthorizationCode(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, req *AuthorizationRequest) (string, error) {
+	code, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	authCode := &sitrep.AuthorizationCodes{
+		CodeHash:            sitrep.HashOpaqueToken(code),
+		ClientId:            req.ClientID,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		ExpiresAt:           time.Now().Add(opts.AuthorizationCodeLifetime),
+		Nonce:               req.Nonce,
+		RedirectUri:         req.RedirectURI,
+		Scope:               req.Scope,
+		UserEmail:           user.Email,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(AuthorizationCodesTable.Bind(*authCode)).Exec(session); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// ExchangeAuthorizationCode redeems an authorization code for tokens. Codes
+// can only be redeemed once, by the client they have been issued to and with
+// the verifier of their PKCE challenge.
+func ExchangeAuthorizationCode(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, clientID string, secret string, code string, redirectURI string, verifier string) (*sitrep.JWTResponse, error) {
+	var authCode sitrep.AuthorizationCodes
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(AuthorizationCodesTable).
+		Where(
+		AuthorizationCodesTable.CODE_HASH.Eq(sitrep.HashOpaqueToken(code))).
+		Into(
+		AuthorizationCodesTable.To(&authCode)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewAuthorizationCodeInvalidError()
+	}
+
+	applied, err := consumeOnce(session, "authorization_codes", map[string]interface{}{
+		"code_hash": authCode.CodeHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied || authCode.IsExpired() {
+		return nil, NewAuthorizationCodeInvalidError()
+	}
+	if authCode.ClientId != clientID || authCode.RedirectUri != redirectURI {
+		return nil, NewAuthorizationCodeInvalidError()
+	}
+	if !authCode.VerifyCodeChallenge(verifier) {
+		return nil, NewAuthorizationCodeInvalidError()
+	}
+
+	oauthClient, err := FindClientByID(cassandra, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !oauthClient.IsPublic {
+		if err := oauthClient.ValidateSecret(secret); err != nil {
+			return nil, NewClientInvalidError()
+		}
+	}
+
+	user, err := FindUserByEmail(cassandra, authCode.UserEmail)
+	if err != nil {
+		return nil, NewAuthorizationCodeInvalidError()
+	}
+	if user.IsBanned {
+		return nil, NewUserInvalidError()
+	}
+	tokens, err := signIn(cassandra, opts, client, user)
+	if err != nil {
+		return nil, err
+	}
+	if containsScope(parseScopes(authCode.Scope), ScopeOpenID) {
+		if tokens.IDToken, err = NewIDToken(opts, user, clientID, authCode.Nonce); err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+// AuthorizationCodeInvalidError is returned, when an authorization code can not be exchanged
+type AuthorizationCodeInvalidError struct {
+	Message string
+}
+
+// Error prints the AuthorizationCodeInvalidError
+func (a *AuthorizationCodeInvalidError) Error() string {
+	return a.Message
+}
+
+// NewAuthorizationCodeInvalidError produces a new AuthorizationCodeInvalidError
+func NewAuthorizationCodeInvalidError() *AuthorizationCodeInvalidError {
+	return &AuthorizationCodeInvalidError{
+		Message: "The authorization code is invalid or has expired!",
+	}
+}
+
+// RedirectURIInvalidError is returned, when a client asks to redirect to an unregistered URI
+type RedirectURIInvalidError struct {
+	Message string
+}
+
+// Error prints the RedirectURIInvalidError
+func (r *RedirectURIInvalidError) Error() string {
+	return r.Message
+}
+
+// NewRedirectURIInvalidError produces a new RedirectURIInvalidError
+func NewRedirectURIInvalidError() *RedirectURIInvalidError {
+	return &RedirectURIInvalidError{
+		Message: "The redirect URI is not registered for this client!",
+	}
+}
@@ -0,0 +1,165 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/fkasper/sitrep-authentication/mailer/mailertest"
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/signing"
+)
+
+// Verifier and challenge of the PKCE example in RFC 7636, appendix B
+const (
+	mockCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r7wW1gFWFOEjXk"
+	mockCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func mockKey(t *testing.T) *signing.Key {
+	pemBytes, err := signing.GenerateKey("ES256")
+	if err != nil {
+		t.Fatalf("Key could not be generated: %v", err)
+	}
+	key, err := signing.NewKey("", "ES256", pemBytes)
+	if err != nil {
+		t.Fatalf("Key could not be loaded: %v", err)
+	}
+	return key
+}
+
+func mockAuthorizationRequest(t *testing.T) *models.AuthorizationRequest {
+	client := &sitrep.OauthClients{
+		Name:         "menu app",
+		IsPublic:      true,
+		RedirectUris:  "https://sitrep-vatcinc.com/callback",
+		AllowedScopes: "openid exercise",
+	}
+	if _, err := models.RegisterClient(dbConn(), client); err != nil {
+		t.Fatalf("Client registration failed unexpectedly: %v", err)
+	}
+	return &models.AuthorizationRequest{
+		ClientID:            client.ClientId,
+		RedirectURI:         "https://sitrep-vatcinc.com/callback",
+		CodeChallenge:       mockCodeChallenge,
+		CodeChallengeMethod: sitrep.CodeChallengeMethodS256,
+	}
+}
+
+func TestAuthorizationCode_Exchange(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req := mockAuthorizationRequest(t)
+	if _, err := models.ValidateAuthorizationRequest(c, req); err != nil {
+		t.Fatalf("Authorization request was rejected: %v", err)
+	}
+	user, err := models.AuthenticateUser(c, "someguy@somedomain.com", "test1234")
+	if err != nil {
+		t.Fatalf("Authentication failed unexpectedly")
+	}
+	code, err := models.CreateAuthorizationCode(c, opts, user, req)
+	if err != nil {
+		t.Fatalf("Code creation failed unexpectedly: %v", err)
+	}
+	tokens, err := models.ExchangeAuthorizationCode(c, opts, nil, req.ClientID, "", code, req.RedirectURI, mockCodeVerifier)
+	if err != nil {
+		t.Fatalf("Code exchange failed unexpectedly: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, tokens.AccessToken); err != nil {
+		t.Fatalf("Access token of the code exchange is invalid")
+	}
+	if _, err := models.ExchangeAuthorizationCode(c, opts, nil, req.ClientID, "", code, req.RedirectURI, mockCodeVerifier); err == nil {
+		t.Fatalf("Authorization code was exchanged twice")
+	}
+}
+
+func TestAuthorizationCode_IDToken(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	key := mockKey(t)
+	opts := models.NewOptions()
+	opts.Issuer = "https://auth.example.com"
+	opts.Keys = signing.NewKeyRing(key)
+	req := mockAuthorizationRequest(t)
+	req.Scope = "openid admin"
+	req.Nonce = "n-0S6_WzA2Mj"
+	if _, err := models.ValidateAuthorizationRequest(c, req); err != nil {
+		t.Fatalf("Authorization request was rejected: %v", err)
+	}
+	if req.Scope != "openid" {
+		t.Fatalf("Scope was not narrowed to the scopes of the client: %s", req.Scope)
+	}
+	code, err := models.CreateAuthorizationCode(c, opts, mockUser(), req)
+	if err != nil {
+		t.Fatalf("Code creation failed unexpectedly: %v", err)
+	}
+	tokens, err := models.ExchangeAuthorizationCode(c, opts, nil, req.ClientID, "", code, req.RedirectURI, mockCodeVerifier)
+	if err != nil {
+		t.Fatalf("Code exchange failed unexpectedly: %v", err)
+	}
+	idToken, err := jwt.Parse(tokens.IDToken, func(token *jwt.Token) (interface{}, error) {
+		return key.VerifyKey(), nil
+	})
+	if err != nil {
+		t.Fatalf("ID token is invalid: %v", err)
+	}
+	if idToken.Claims["iss"] != opts.Issuer || idToken.Claims["aud"] != req.ClientID {
+		t.Fatalf("unexpected issuer or audience: %v", idToken.Claims)
+	}
+	if idToken.Claims["nonce"] != req.Nonce || idToken.Claims["email"] != "someguy@somedomain.com" {
+		t.Fatalf("unexpected nonce or email: %v", idToken.Claims)
+	}
+	if idToken.Claims["token_use"] != models.TokenUseID {
+		t.Fatalf("unexpected token use: %v", idToken.Claims)
+	}
+}
+
+func TestIDToken_RequiresKeyRing(t *testing.T) {
+	opts := models.NewOptions()
+	if opts.OpenIDEnabled() {
+		t.Fatalf("OpenID Connect is enabled without a key ring")
+	}
+	if _, err := models.NewIDToken(opts, mockUser(), "menu-app", ""); err == nil {
+		t.Fatalf("ID token was signed without a key ring")
+	} else if _, ok := err.(*models.OpenIDDisabledError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestSigningKey_NoActiveKey(t *testing.T) {
+	key := mockKey(t)
+	key.RetiresAt = time.Now().Add(-time.Minute)
+	opts := models.NewOptions()
+	opts.Mailer = mailertest.NewSender()
+	opts.Keys = signing.NewKeyRing(key)
+
+	// Verifiers only know the keys of the ring, the secret of the user must
+	// not take the place of a retired key
+	if err := models.SendConfirmationEmail(opts, mockUser()); err != signing.ErrNoActiveKey {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestAuthorizationCode_WrongVerifier(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req := mockAuthorizationRequest(t)
+	code, err := models.CreateAuthorizationCode(c, opts, mockUser(), req)
+	if err != nil {
+		t.Fatalf("Code creation failed unexpectedly: %v", err)
+	}
+	if _, err := models.ExchangeAuthorizationCode(c, opts, nil, req.ClientID, "", code, req.RedirectURI, "wrong"); err == nil {
+		t.Fatalf("Code was exchanged with a wrong verifier")
+	}
+}
+
+func TestAuthorizationCode_UnregisteredRedirect(t *testing.T) {
+	req := mockAuthorizationRequest(t)
+	req.RedirectURI = "https://evil.example.com/callback"
+	if _, err := models.ValidateAuthorizationRequest(dbConn(), req); err == nil {
+		t.Fatalf("Unregistered redirect URI was accepted")
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// cqlc can neither set a TTL nor run lightweight transactions. The helpers
+// below build these statements, everything else goes through cqlc.
+
+// insertWithTTL inserts a row of columns into table, which Cassandra removes
+// after ttl. Rows nobody redeems or looks at again, like challenges and
+// counters, do not pile up this way. A ttl of 0 keeps the row forever.
+func insertWithTTL(session *gocql.Session, table string, columns map[string]interface{}, ttl time.Duration) error {
+	names, values := splitColumns(columns)
+	seconds := 0
+	if ttl > 0 {
+		seconds = ttlSeconds(ttl)
+	}
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) USING TTL ?`,
+		table, strings.Join(names, ", "), placeholders(len(names)))
+	return session.Query(stmt, append(values, seconds)...).Exec()
+}
+
+// insertOnce inserts a row of columns into table, unless a row with the same
+// primary key exists, and reports whether it was inserted. Concurrent
+// inserts of the same key can not overwrite each other.
+func insertOnce(session *gocql.Session, table string, columns map[string]interface{}) (bool, error) {
+	names, values := splitColumns(columns)
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) IF NOT EXISTS`,
+		table, strings.Join(names, ", "), placeholders(len(names)))
+	// A failed insert returns the whole existing row, so it is scanned into a map
+	return session.Query(stmt, values...).MapScanCAS(map[string]interface{}{})
+}
+
+// consumeOnce deletes the row of table with the primary key in keys and
+// reports whether it still existed. Of concurrent requests redeeming the
+// same single use token or code, only one succeeds.
+func consumeOnce(session *gocql.Session, table string, keys map[string]interface{}) (bool, error) {
+	names, values := splitColumns(keys)
+	for i, name := range names {
+		names[i] = name + " = ?"
+	}
+	stmt := fmt.Sprintf(`DELETE FROM %s WHERE %s IF EXISTS`, table, strings.Join(names, " AND "))
+	return session.Query(stmt, values...).MapScanCAS(map[string]interface{}{})
+}
+
+// splitColumns returns the names of columns in a stable order, so the
+// statements can be prepared once, together with their values
+func splitColumns(columns map[string]interface{}) ([]string, []interface{}) {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		values[i] = columns[name]
+	}
+	return names, values
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
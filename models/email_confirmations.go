@@ -0,0 +1,151 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// TokenUseEmailConfirmation marks tokens, which confirm the email address of a user
+const TokenUseEmailConfirmation = "email_confirmation"
+
+// SendConfirmationEmail mails a link to user, which confirms the email
+// address of the account. Confirmation tokens are not stored, confirming an
+// address twice does no harm.
+func SendConfirmationEmail(opts *Options, user *sitrep.UsersByEmail) error {
+	if opts.Mailer == nil {
+		return NewMailerDisabledError()
+	}
+	key, err := opts.signingKey(user.JwtEncryptionKey)
+	if err != nil {
+		return err
+	}
+	token, err := key.Sign(map[string]interface{}{
+		"sub":       user.Email,
+		"token_use": TokenUseEmailConfirmation,
+		"exp":       time.Now().Add(opts.EmailConfirmationLifetime).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	return opts.Mailer.Send(confirmationMessage(opts, user, token))
+}
+
+// ResendConfirmationEmail mails a new confirmation link to the user with
+// email. Unknown, banned and confirmed users are silently ignored, so the
+// caller can not tell whether an account exists.
+func ResendConfirmationEmail(cassandra *gocql.ClusterConfig, opts *Options, email string) error {
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" || user.IsBanned || user.IsConfirmed {
+		return nil
+	}
+	return SendConfirmationEmail(opts, user)
+}
+
+// ConfirmEmail marks the email address of the user a confirmation token has
+// been mailed to as confirmed
+func ConfirmEmail(cassandra *gocql.ClusterConfig, opts *Options, token string) (*sitrep.UsersByEmail, error) {
+	parsed, err := sitrep.ParseJwt(token, opts.Keys, func(claims map[string]interface{}) (string, error) {
+		if use, _ := claims["token_use"].(string); use != TokenUseEmailConfirmation {
+			return "", NewEmailConfirmationInvalidError()
+		}
+		subject, _ := claims["sub"].(string)
+		found, err := FindUserByEmail(cassandra, subject)
+		if err != nil {
+			return "", err
+		}
+		return found.JwtEncryptionKey, nil
+	})
+	if err != nil {
+		return nil, NewEmailConfirmationInvalidError()
+	}
+	if use, _ := parsed.Claims["token_use"].(string); use != TokenUseEmailConfirmation {
+		return nil, NewEmailConfirmationInvalidError()
+	}
+	subject, _ := parsed.Claims["sub"].(string)
+	user, err := FindUserByEmail(cassandra, subject)
+	if err != nil || user.Email == "" {
+		return nil, NewEmailConfirmationInvalidError()
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetBoolean(UsersTable.IS_CONFIRMED, true).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+	user.IsConfirmed = true
+	return user, nil
+}
+
+// CheckEmailConfirmed refuses users, whose email address has not been
+// confirmed, if the service requires confirmed addresses
+func CheckEmailConfirmed(opts *Options, user *sitrep.UsersByEmail) error {
+	if opts.RequireConfirmedEmail && !user.IsConfirmed {
+		return NewEmailNotConfirmedError()
+	}
+	return nil
+}
+
+func confirmationMessage(opts *Options, user *sitrep.UsersByEmail, token string) *mailer.Message {
+	link := token
+	if opts.EmailConfirmationURL != "" {
+		link = opts.EmailConfirmationURL + "?" + url.Values{"token": {token}}.Encode()
+	}
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your SITREP email address",
+		Body: fmt.Sprintf(`Hello %s,
+
+please confirm the email address of your SITREP account by following this
+link within %d hours:
+
+%s
+
+If you did not create an account, you can ignore this mail.
+`, user.RealName, int(opts.EmailConfirmationLifetime/time.Hour), link),
+	}
+}
+
+// EmailConfirmationInvalidError is returned, when an email confirmation token is wrong or expired
+type EmailConfirmationInvalidError struct {
+	Message string
+}
+
+// Error prints the EmailConfirmationInvalidError
+func (e *EmailConfirmationInvalidError) Error() string {
+	return e.Message
+}
+
+// NewEmailConfirmationInvalidError produces a new EmailConfirmationInvalidError
+func NewEmailConfirmationInvalidError() *EmailConfirmationInvalidError {
+	return &EmailConfirmationInvalidError{
+		Message: "This confirmation link is invalid or has expired!",
+	}
+}
+
+// EmailNotConfirmedError is returned, when a user signs in before confirming the email address
+type EmailNotConfirmedError struct {
+	Message string
+}
+
+// Error prints the EmailNotConfirmedError
+func (e *EmailNotConfirmedError) Error() string {
+	return e.Message
+}
+
+// NewEmailNotConfirmedError produces a new EmailNotConfirmedError
+func NewEmailNotConfirmedError() *EmailNotConfirmedError {
+	return &EmailNotConfirmedError{
+		Message: "Please confirm your email address first!",
+	}
+}
@@ -0,0 +1,58 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/mailer/mailertest"
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func TestEmailConfirmation(t *testing.T) {
+	user := mockUser()
+	user.IsConfirmed = false
+	initUser(user)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	opts.RequireConfirmedEmail = true
+	opts.EmailConfirmationURL = "https://sitrep-vatcinc.com/confirm-email"
+
+	if _, err := models.UserSignIn(c, opts, nil, user.Email, "test1234", "password"); err == nil {
+		t.Fatalf("Unconfirmed user was signed in")
+	} else if _, ok := err.(*models.EmailNotConfirmedError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := models.ResendConfirmationEmail(c, opts, user.Email); err != nil {
+		t.Fatalf("Confirmation email could not be sent: %v", err)
+	}
+	token := mailedToken(t, sender, user.Email, opts.EmailConfirmationURL)
+	if _, err := models.ConfirmEmail(c, opts, token); err != nil {
+		t.Fatalf("Confirmation failed unexpectedly: %v", err)
+	}
+	if _, err := models.UserSignIn(c, opts, nil, user.Email, "test1234", "password"); err != nil {
+		t.Fatalf("Confirmed user could not sign in: %v", err)
+	}
+
+	if err := models.ResendConfirmationEmail(c, opts, user.Email); err != nil {
+		t.Fatalf("Resend failed unexpectedly: %v", err)
+	}
+	if sender.Len() != 1 {
+		t.Fatalf("Confirmation email was sent to a confirmed user")
+	}
+}
+
+func TestEmailConfirmation_InvalidToken(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	opts := models.NewOptions()
+	tokens, err := models.UserSignIn(c, opts, nil, user.Email, "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	if _, err := models.ConfirmEmail(c, opts, tokens.AccessToken); err == nil {
+		t.Fatalf("An access token was accepted as confirmation token")
+	}
+}
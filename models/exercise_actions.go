@@ -111,18 +111,20 @@ func FindOrInitSettingsForExercise(cassandra *gocql.ClusterConfig, exerciseID go
 	}
 
 	defaultSettings := map[string]string{
-		"backgroundColorMenuBar": "#ccc",
-		"fontColorMenuBar":       "#555",
-		"newsStationEnabled":     "true",
-		"twitterEnabled":         "true",
-		"facebookEnabled":        "false",
-		"youtubeEnabled":         "false",
-		"usaidEnabled":           "false",
-		"dosEnabled":             "true",
-		"contactEnabled":         "true",
-		"contactDestination":     "sitrep@vatcinc.com",
-		"arcgisMainMapLink":      "",
-		"arcgisEmbed":            "true",
+		"backgroundColorMenuBar":  "#ccc",
+		"fontColorMenuBar":        "#555",
+		"newsStationEnabled":      "true",
+		"twitterEnabled":          "true",
+		"facebookEnabled":         "false",
+		"youtubeEnabled":          "false",
+		"usaidEnabled":            "false",
+		"dosEnabled":              "true",
+		"contactEnabled":          "true",
+		"contactDestination":      "sitrep@vatcinc.com",
+		"arcgisMainMapLink":       "",
+		"arcgisEmbed":             "true",
+		ExerciseSettingRequireMfa: "false",
+		ExerciseSettingMagicLink:  "false",
 	}
 	if err := ctx.Upsert(SettingsByExerciseIdentifierTable).
 		SetStringStringMap(SettingsByExerciseIdentifierTable.SETTINGS, defaultSettings).
@@ -91,6 +91,20 @@ func addUserPermissionToExercise(user *sitrep.UsersByEmail, exercise *sitrep.Exe
 	}
 }
 
+func removeUserPermissionFromExercise(user *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier) {
+	session, ctx := mockDb()
+	defer session.Close()
+	err := ctx.Delete().
+		From(ExercisePermissionsLevelTable).
+		Where(
+		ExercisePermissionsLevelTable.USER_EMAIL.Eq(user.Email),
+		ExercisePermissionsLevelTable.EXERCISE_IDENTIFIER.Eq(exercise.Id)).
+		Exec(session)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // TESTS
 
 func TestExercise_Find(t *testing.T) {
@@ -0,0 +1,317 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// ExerciseInvitationsTable is a reference to the invitations into exercises, that have not been accepted
+var ExerciseInvitationsTable = sitrep.ExerciseInvitationsTableDef()
+
+// InviteToExercise emails an invitation into the exercise with exerciseID
+// to email. Whoever accepts the invitation joins the exercise with role.
+// Members of the exercise can not be invited, their role is changed by an
+// administrator instead.
+func InviteToExercise(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, inviter string, exerciseID gocql.UUID, email string, role string) (*sitrep.ExerciseInvitations, error) {
+	fields := map[string]string{}
+	email, ok := normalizeEmail(email)
+	if !ok {
+		fields["email"] = "is not a valid email address"
+	}
+	if !IsExerciseRole(role) {
+		fields["role"] = "must be one of trainee, oc, admin and invisible"
+	}
+	if len(fields) > 0 {
+		return nil, NewValidationError(fields)
+	}
+	if opts.Mailer == nil {
+		return nil, NewMailerDisabledError()
+	}
+	exercise, err := FindExerciseByID(cassandra, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if exercise.Id != exerciseID {
+		return nil, NewExerciseForbiddenError()
+	}
+	member, err := isExerciseMember(cassandra, email, exercise)
+	if err != nil {
+		return nil, err
+	}
+	if member {
+		return nil, NewValidationError(map[string]string{"email": "is already a member of this exercise"})
+	}
+
+	token, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invitation := &sitrep.ExerciseInvitations{
+		ExerciseId: exerciseID,
+		TokenHash:  sitrep.HashOpaqueToken(token),
+		CreatedAt:  now,
+		Email:      email,
+		ExpiresAt:  now.Add(opts.InvitationLifetime),
+		InvitedBy:  inviter,
+		Role:       role,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(ExerciseInvitationsTable.Bind(*invitation)).Exec(session); err != nil {
+		return nil, err
+	}
+	if err := opts.Mailer.Send(invitationMessage(opts, exercise, invitation, token)); err != nil {
+		// An invitation nobody received must not stay pending
+		ctx.Delete().
+			From(ExerciseInvitationsTable).
+			Where(
+			ExerciseInvitationsTable.EXERCISE_ID.Eq(exerciseID),
+			ExerciseInvitationsTable.TOKEN_HASH.Eq(invitation.TokenHash)).
+			Exec(session)
+		return nil, err
+	}
+	if err := RecordAuditEvent(cassandra, inviter, AuditInvitationSent, client, email); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// FindExerciseInvitations lists the pending invitations into the exercise
+// with exerciseID. Expired invitations are left out.
+func FindExerciseInvitations(cassandra *gocql.ClusterConfig, exerciseID gocql.UUID) ([]sitrep.ExerciseInvitations, error) {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(ExerciseInvitationsTable).
+		Where(
+		ExerciseInvitationsTable.EXERCISE_ID.Eq(exerciseID)).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := sitrep.BindExerciseInvitations(iter)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	pending := invitations[:0]
+	for _, invitation := range invitations {
+		if now.Before(invitation.ExpiresAt) {
+			pending = append(pending, invitation)
+		}
+	}
+	return pending, nil
+}
+
+// RevokeExerciseInvitation withdraws the pending invitation with id, which
+// is the hash of its token, from the exercise with exerciseID
+func RevokeExerciseInvitation(cassandra *gocql.ClusterConfig, client *ClientInfo, actor string, exerciseID gocql.UUID, id string) error {
+	invitation, err := findExerciseInvitationByHash(cassandra, exerciseID, id)
+	if err != nil {
+		return err
+	}
+	if invitation == nil {
+		return NewExerciseInvitationNotFoundError()
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Delete().
+		From(ExerciseInvitationsTable).
+		Where(
+		ExerciseInvitationsTable.EXERCISE_ID.Eq(exerciseID),
+		ExerciseInvitationsTable.TOKEN_HASH.Eq(id)).
+		Exec(session); err != nil {
+		return err
+	}
+	return RecordAuditEvent(cassandra, actor, AuditInvitationRevoked, client, invitation.Email)
+}
+
+// AcceptExerciseInvitation lets the invited user join the exercise of an
+// invitation. Users without an account are registered with the fields of r,
+// their email address is taken from the invitation. Users, whose account
+// address differs from the invitation in case only, name their account in
+// r.Email. Invited users can register, even if registration is disabled.
+func AcceptExerciseInvitation(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, r *Registration) (*sitrep.UsersByEmail, error) {
+	_, invitation, err := findExerciseInvitation(cassandra, r.ExerciseID, r.InvitationToken)
+	if err != nil {
+		return nil, err
+	}
+	email := invitation.Email
+	if account := strings.TrimSpace(r.Email); account != "" && strings.EqualFold(account, email) {
+		email = account
+	}
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil {
+		return nil, err
+	}
+	if user.Email == "" {
+		r.Email = invitation.Email
+		return RegisterUser(cassandra, opts, client, r)
+	}
+	if user.IsBanned {
+		return nil, NewUserInvalidError()
+	}
+	if err := joinExerciseByInvitation(cassandra, client, user, r.ExerciseID, r.InvitationToken); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// joinExerciseByInvitation redeems an invitation for user and writes the
+// memberships it grants. Members of the exercise are refused, accepting an
+// invitation would replace their role and its description.
+func joinExerciseByInvitation(cassandra *gocql.ClusterConfig, client *ClientInfo, user *sitrep.UsersByEmail, exerciseID string, token string) error {
+	exercise, invitation, err := findExerciseInvitation(cassandra, exerciseID, token)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(invitation.Email, user.Email) {
+		return NewExerciseInvitationInvalidError()
+	}
+	member, err := isExerciseMember(cassandra, user.Email, exercise)
+	if err != nil {
+		return err
+	}
+	if member {
+		return NewValidationError(map[string]string{"email": "is already a member of this exercise"})
+	}
+	if _, _, err := consumeExerciseInvitation(cassandra, exerciseID, token); err != nil {
+		return err
+	}
+	if err := AddExerciseMember(cassandra, user, exercise, invitation.Role); err != nil {
+		return err
+	}
+	return RecordAuditEvent(cassandra, user.Email, AuditInvitationAccepted, client, exerciseID)
+}
+
+// findExerciseInvitation returns a pending invitation with token into the
+// exercise with exerciseID and the exercise itself
+func findExerciseInvitation(cassandra *gocql.ClusterConfig, exerciseID string, token string) (*sitrep.ExerciseByIdentifier, *sitrep.ExerciseInvitations, error) {
+	id, err := gocql.ParseUUID(exerciseID)
+	if err != nil || token == "" {
+		return nil, nil, NewExerciseInvitationInvalidError()
+	}
+	invitation, err := findExerciseInvitationByHash(cassandra, id, sitrep.HashOpaqueToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+	if invitation == nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, nil, NewExerciseInvitationInvalidError()
+	}
+	exercise, err := FindExerciseByID(cassandra, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exercise.Id != id {
+		return nil, nil, NewExerciseInvitationInvalidError()
+	}
+	return exercise, invitation, nil
+}
+
+// consumeExerciseInvitation redeems a pending invitation, so it can only be
+// accepted once
+func consumeExerciseInvitation(cassandra *gocql.ClusterConfig, exerciseID string, token string) (*sitrep.ExerciseByIdentifier, *sitrep.ExerciseInvitations, error) {
+	exercise, invitation, err := findExerciseInvitation(cassandra, exerciseID, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	applied, err := consumeOnce(session, "exercise_invitations", map[string]interface{}{
+		"exercise_id": invitation.ExerciseId,
+		"token_hash":  invitation.TokenHash,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if !applied {
+		return nil, nil, NewExerciseInvitationInvalidError()
+	}
+	return exercise, invitation, nil
+}
+
+func findExerciseInvitationByHash(cassandra *gocql.ClusterConfig, exerciseID gocql.UUID, tokenHash string) (*sitrep.ExerciseInvitations, error) {
+	var invitation sitrep.ExerciseInvitations
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(ExerciseInvitationsTable).
+		Where(
+		ExerciseInvitationsTable.EXERCISE_ID.Eq(exerciseID),
+		ExerciseInvitationsTable.TOKEN_HASH.Eq(tokenHash)).
+		Into(
+		ExerciseInvitationsTable.To(&invitation)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return &invitation, nil
+}
+
+func invitationMessage(opts *Options, exercise *sitrep.ExerciseByIdentifier, invitation *sitrep.ExerciseInvitations, token string) *mailer.Message {
+	link := token
+	if opts.InvitationURL != "" {
+		link = opts.InvitationURL + "?" + url.Values{
+			"exercise_id": {invitation.ExerciseId.String()},
+			"token":       {token},
+		}.Encode()
+	}
+	return &mailer.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to %s", exercise.ExerciseName),
+		Body: fmt.Sprintf(`Hello,
+
+%s has invited you to take part in the SITREP exercise %s. To accept
+the invitation, please follow this link within %d days:
+
+%s
+
+If you do not have a SITREP account yet, you can create one on the way.
+`, invitation.InvitedBy, exercise.ExerciseName, int(opts.InvitationLifetime/(24*time.Hour)), link),
+	}
+}
+
+// ExerciseInvitationInvalidError is returned, when an invitation token is wrong, expired or used
+type ExerciseInvitationInvalidError struct {
+	Message string
+}
+
+// Error prints the ExerciseInvitationInvalidError
+func (e *ExerciseInvitationInvalidError) Error() string {
+	return e.Message
+}
+
+// NewExerciseInvitationInvalidError produces a new ExerciseInvitationInvalidError
+func NewExerciseInvitationInvalidError() *ExerciseInvitationInvalidError {
+	return &ExerciseInvitationInvalidError{
+		Message: "This invitation is invalid or has expired!",
+	}
+}
+
+// ExerciseInvitationNotFoundError is returned, when a pending invitation does not exist
+type ExerciseInvitationNotFoundError struct {
+	Message string
+}
+
+// Error prints the ExerciseInvitationNotFoundError
+func (e *ExerciseInvitationNotFoundError) Error() string {
+	return e.Message
+}
+
+// NewExerciseInvitationNotFoundError produces a new ExerciseInvitationNotFoundError
+func NewExerciseInvitationNotFoundError() *ExerciseInvitationNotFoundError {
+	return &ExerciseInvitationNotFoundError{
+		Message: "The invitation could not be found!",
+	}
+}
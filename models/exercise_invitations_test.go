@@ -0,0 +1,200 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/mailer/mailertest"
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func invitationOptions() (*models.Options, *mailertest.Sender) {
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	opts.InvitationURL = "https://sitrep-vatcinc.com/accept-invitation"
+	return opts, sender
+}
+
+func TestExerciseInvitation_NewUser(t *testing.T) {
+	exercise := mockExercise()
+	initExercise(exercise)
+	c := dbConn()
+	opts, sender := invitationOptions()
+	r := mockRegistration()
+
+	if _, err := models.InviteToExercise(c, opts, nil, "someguy@somedomain.com", exercise.Id, r.Email, "general"); err == nil {
+		t.Fatalf("Invitation with an unknown role was sent")
+	}
+	invitation, err := models.InviteToExercise(c, opts, nil, "someguy@somedomain.com", exercise.Id, r.Email, models.RoleOC)
+	if err != nil {
+		t.Fatalf("Invitation could not be sent: %v", err)
+	}
+	pending, err := models.FindExerciseInvitations(c, exercise.Id)
+	if err != nil {
+		t.Fatalf("Invitations could not be listed: %v", err)
+	}
+	listed := false
+	for _, p := range pending {
+		listed = listed || p.TokenHash == invitation.TokenHash
+	}
+	if !listed {
+		t.Fatalf("Pending invitation is not listed")
+	}
+
+	r.ExerciseID = exercise.Id.String()
+	r.InvitationToken = mailedToken(t, sender, invitation.Email, opts.InvitationURL)
+	email := r.Email
+	r.Email = ""
+	user, err := models.AcceptExerciseInvitation(c, opts, nil, r)
+	if err != nil {
+		t.Fatalf("Invitation could not be accepted: %v", err)
+	}
+	if user.Email != invitation.Email || !user.IsConfirmed {
+		t.Fatalf("Invited user %s was not registered as %s", user.Email, email)
+	}
+	permissions, err := models.FindExercisePermissionsForUser(c, user, exercise)
+	if err != nil {
+		t.Fatalf("Permissions could not be read: %v", err)
+	}
+	if !permissions.IsAuthorized || !permissions.IsOc {
+		t.Fatalf("Invited user did not join the exercise as OC")
+	}
+	if _, err := models.AcceptExerciseInvitation(c, opts, nil, r); err == nil {
+		t.Fatalf("Invitation was accepted twice")
+	}
+}
+
+func TestExerciseInvitation_ExistingUser(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	removeUserPermissionFromExercise(user, exercise)
+	c := dbConn()
+	opts, sender := invitationOptions()
+
+	if _, err := models.InviteToExercise(c, opts, nil, "admin@somedomain.com", exercise.Id, user.Email, models.RoleAdmin); err != nil {
+		t.Fatalf("Invitation could not be sent: %v", err)
+	}
+	user, err := models.AcceptExerciseInvitation(c, opts, nil, &models.Registration{
+		ExerciseID:      exercise.Id.String(),
+		InvitationToken: mailedToken(t, sender, user.Email, opts.InvitationURL),
+	})
+	if err != nil {
+		t.Fatalf("Invitation could not be accepted: %v", err)
+	}
+	permissions, err := models.FindExercisePermissionsForUser(c, user, exercise)
+	if err != nil {
+		t.Fatalf("Permissions could not be read: %v", err)
+	}
+	if !permissions.IsAdmin {
+		t.Fatalf("Invited user did not become exercise admin")
+	}
+}
+
+func TestExerciseInvitation_Revoke(t *testing.T) {
+	exercise := mockExercise()
+	initExercise(exercise)
+	c := dbConn()
+	opts, sender := invitationOptions()
+	r := mockRegistration()
+
+	invitation, err := models.InviteToExercise(c, opts, nil, "someguy@somedomain.com", exercise.Id, r.Email, models.RoleTrainee)
+	if err != nil {
+		t.Fatalf("Invitation could not be sent: %v", err)
+	}
+	if err := models.RevokeExerciseInvitation(c, nil, "someguy@somedomain.com", exercise.Id, invitation.TokenHash); err != nil {
+		t.Fatalf("Invitation could not be revoked: %v", err)
+	}
+	if err := models.RevokeExerciseInvitation(c, nil, "someguy@somedomain.com", exercise.Id, invitation.TokenHash); err == nil {
+		t.Fatalf("Invitation was revoked twice")
+	}
+	r.ExerciseID = exercise.Id.String()
+	r.InvitationToken = mailedToken(t, sender, invitation.Email, opts.InvitationURL)
+	if _, err := models.AcceptExerciseInvitation(c, opts, nil, r); err == nil {
+		t.Fatalf("Revoked invitation was accepted")
+	}
+}
+
+func TestExerciseInvitation_RegistrationDisabled(t *testing.T) {
+	exercise := mockExercise()
+	initExercise(exercise)
+	c := dbConn()
+	opts, sender := invitationOptions()
+	opts.Registration = models.RegistrationDisabled
+	r := mockRegistration()
+
+	invitation, err := models.InviteToExercise(c, opts, nil, "someguy@somedomain.com", exercise.Id, r.Email, models.RoleTrainee)
+	if err != nil {
+		t.Fatalf("Invitation could not be sent: %v", err)
+	}
+	r.ExerciseID = exercise.Id.String()
+	r.InvitationToken = mailedToken(t, sender, invitation.Email, opts.InvitationURL)
+	if _, err := models.AcceptExerciseInvitation(c, opts, nil, r); err != nil {
+		t.Fatalf("Invitation could not be accepted with registration disabled: %v", err)
+	}
+}
+
+func TestExerciseInvitation_AccountInOtherCase(t *testing.T) {
+	user := mockUser()
+	user.Email = "Mixed.Case@somedomain.com"
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	removeUserPermissionFromExercise(user, exercise)
+	c := dbConn()
+	opts, sender := invitationOptions()
+
+	invitation, err := models.InviteToExercise(c, opts, nil, "admin@somedomain.com", exercise.Id, "mixed.case@somedomain.com", models.RoleTrainee)
+	if err != nil {
+		t.Fatalf("Invitation could not be sent: %v", err)
+	}
+	accepted, err := models.AcceptExerciseInvitation(c, opts, nil, &models.Registration{
+		Email:           user.Email,
+		ExerciseID:      exercise.Id.String(),
+		InvitationToken: mailedToken(t, sender, invitation.Email, opts.InvitationURL),
+	})
+	if err != nil {
+		t.Fatalf("Invitation could not be accepted: %v", err)
+	}
+	if accepted.Email != user.Email {
+		t.Fatalf("A second account %s was created", accepted.Email)
+	}
+}
+
+func TestExerciseInvitation_ExistingMember(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	removeUserPermissionFromExercise(user, exercise)
+	c := dbConn()
+	opts, sender := invitationOptions()
+
+	invitation, err := models.InviteToExercise(c, opts, nil, "admin@somedomain.com", exercise.Id, user.Email, models.RoleTrainee)
+	if err != nil {
+		t.Fatalf("Invitation could not be sent: %v", err)
+	}
+	addUserPermissionToExercise(user, exercise, true, false, false)
+	if _, err := models.InviteToExercise(c, opts, nil, "admin@somedomain.com", exercise.Id, user.Email, models.RoleTrainee); err == nil {
+		t.Fatalf("Member of the exercise was invited")
+	} else if _, ok := err.(*models.ValidationError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The invitation was sent, before the user joined the exercise
+	_, err = models.AcceptExerciseInvitation(c, opts, nil, &models.Registration{
+		ExerciseID:      exercise.Id.String(),
+		InvitationToken: mailedToken(t, sender, invitation.Email, opts.InvitationURL),
+	})
+	if _, ok := err.(*models.ValidationError); !ok {
+		t.Fatalf("Member of the exercise accepted an invitation: %v", err)
+	}
+	permissions, err := models.FindExercisePermissionsForUser(c, user, exercise)
+	if err != nil {
+		t.Fatalf("Permissions could not be read: %v", err)
+	}
+	if !permissions.IsAdmin || permissions.IsTrainee || permissions.RoleDescription != "Demo Role" {
+		t.Fatalf("Role of the member was replaced: %+v", permissions)
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+const (
+	// RoleTrainee takes part in an exercise
+	RoleTrainee = "trainee"
+
+	// RoleOC observes and controls an exercise
+	RoleOC = "oc"
+
+	// RoleAdmin manages an exercise
+	RoleAdmin = "admin"
+
+	// RoleInvisible takes part in an exercise without showing up in user lists
+	RoleInvisible = "invisible"
+)
+
+// IsExerciseRole reports whether role is one of the roles above
+func IsExerciseRole(role string) bool {
+	switch role {
+	case RoleTrainee, RoleOC, RoleAdmin, RoleInvisible:
+		return true
+	}
+	return false
+}
+
+// isExerciseMember reports whether the user with email has a role in exercise
+func isExerciseMember(cassandra *gocql.ClusterConfig, email string, exercise *sitrep.ExerciseByIdentifier) (bool, error) {
+	permissions, err := FindExercisePermissionsForUser(cassandra, &sitrep.UsersByEmail{Email: email}, exercise)
+	if err != nil {
+		return false, err
+	}
+	return permissions.UserEmail != "", nil
+}
+
+// AddExerciseMember lets user into exercise with role. Both the exercises
+// of the user and the permissions within the exercise are written.
+func AddExerciseMember(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier, role string) error {
+	if !IsExerciseRole(role) {
+		return NewExerciseForbiddenError()
+	}
+	permissions := &sitrep.ExercisePermissionsLevel{
+		UserEmail:          user.Email,
+		ExerciseIdentifier: exercise.Id,
+		IsAuthorized:       true,
+		IsTrainee:          role == RoleTrainee,
+		IsOc:               role == RoleOC,
+		IsAdmin:            role == RoleAdmin,
+		IsInvisible:        role == RoleInvisible,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(ExercisePermissionsLevelTable.Bind(*permissions)).Exec(session); err != nil {
+		return err
+	}
+	// cqlc can only replace whole maps, which would drop the other exercises
+	// of the user
+	return session.Query(`UPDATE create_users_in_exercise SET exercises = exercises + ? WHERE email = ?`,
+		map[string]string{exercise.Id.String(): exercise.ExerciseName}, user.Email).Exec()
+}
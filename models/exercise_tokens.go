@@ -0,0 +1,280 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// TokenUseExercise marks access tokens that are bound to a single exercise
+const TokenUseExercise = "exercise"
+
+// errNotExerciseToken is returned while parsing tokens of any other use
+var errNotExerciseToken = errors.New("not an exercise token")
+
+// ExerciseClaims are the roles of a user within one exercise. Exercise tokens
+// carry them as claims, so requests can be authorized without looking up the
+// permissions of the user.
+type ExerciseClaims struct {
+	Subject         string
+	ExerciseID      gocql.UUID
+	IsOC            bool
+	IsAdmin         bool
+	IsTrainee       bool
+	IsInvisible     bool
+	IsAuthorized    bool
+	RoleDescription string
+
+	// IsSiteAdmin is set for administrators of the whole service, in
+	// contrast to IsAdmin, which only applies to the exercise
+	IsSiteAdmin bool
+
+	// Actor is the email of the user, who is acting as Subject, if the
+	// session is impersonated
+	Actor string
+}
+
+// NewExerciseClaims maps the permissions of user in an exercise to claims
+func NewExerciseClaims(user *sitrep.UsersByEmail, permissions *sitrep.ExercisePermissionsLevel) *ExerciseClaims {
+	return &ExerciseClaims{
+		Subject:         user.Email,
+		ExerciseID:      permissions.ExerciseIdentifier,
+		IsOC:            permissions.IsOc,
+		IsAdmin:         permissions.IsAdmin,
+		IsTrainee:       permissions.IsTrainee,
+		IsInvisible:     permissions.IsInvisible,
+		IsAuthorized:    permissions.IsAuthorized,
+		RoleDescription: permissions.RoleDescription,
+		IsSiteAdmin:     user.IsAdmin,
+	}
+}
+
+// Permissions maps the claims back to the permissions of the user in the
+// exercise
+func (c *ExerciseClaims) Permissions() *sitrep.ExercisePermissionsLevel {
+	return &sitrep.ExercisePermissionsLevel{
+		ExerciseIdentifier: c.ExerciseID,
+		IsAdmin:            c.IsAdmin,
+		IsAuthorized:       c.IsAuthorized,
+		IsInvisible:        c.IsInvisible,
+		IsOc:               c.IsOC,
+		IsTrainee:          c.IsTrainee,
+		RoleDescription:    c.RoleDescription,
+		UserEmail:          c.Subject,
+	}
+}
+
+// CanUpdateSettings reports whether the caller may change the exercise
+// settings in values. Changing settings takes an administrator of the
+// exercise, who also administers the service. Administrators of the
+// exercise alone can only switch sign in with emailed links on and off.
+func (c *ExerciseClaims) CanUpdateSettings(values map[string]string) bool {
+	if c.IsAdmin && c.IsSiteAdmin {
+		return true
+	}
+	if !c.IsAdmin || len(values) == 0 {
+		return false
+	}
+	for key := range values {
+		if key != ExerciseSettingMagicLink {
+			return false
+		}
+	}
+	return true
+}
+
+// IssueExerciseToken exchanges the session of a user for a token bound to
+// exercise, which carries the roles of the user in the exercise.
+//
+// Exercise tokens are not stored, so signing out of a single session does
+// not revoke them. They expire after ExerciseTokenLifetime, which should
+// therefore be kept short, or when the account of the user expires. Tokens
+// signed with the secret of the user are also refused once the user is
+// banned or the encryption key is rotated, tokens signed by the key ring only
+// with ExerciseTokenUserCheck. Tokens of impersonated sessions name the
+// actor in an act claim.
+func IssueExerciseToken(cassandra *gocql.ClusterConfig, opts *Options, principal *Principal, exercise *sitrep.ExerciseByIdentifier) (*sitrep.JWTResponse, error) {
+	user := principal.User
+	if err := checkAccountExpiry(user); err != nil {
+		return nil, err
+	}
+	if err := principal.CheckExercise(exercise.Id); err != nil {
+		return nil, err
+	}
+	permissions, err := FindExercisePermissionsForUser(cassandra, user, exercise)
+	if err != nil || permissions.UserEmail == "" {
+		return nil, NewExerciseForbiddenError()
+	}
+	if err := checkExerciseMfa(cassandra, user, permissions); err != nil {
+		return nil, err
+	}
+	claims := NewExerciseClaims(user, permissions)
+	tokenClaims := map[string]interface{}{
+		"sub":               claims.Subject,
+		"principal_type":    PrincipalUser,
+		"token_use":         TokenUseExercise,
+		"exercise_id":       claims.ExerciseID.String(),
+		"is_oc":             claims.IsOC,
+		"is_exercise_admin": claims.IsAdmin,
+		"is_trainee":        claims.IsTrainee,
+		"is_invisible":      claims.IsInvisible,
+		"is_authorized":     claims.IsAuthorized,
+		"role_description":  claims.RoleDescription,
+		"is_site_admin":     claims.IsSiteAdmin,
+		"key_hash":          encryptionKeyHash(user),
+	}
+	if principal.IsImpersonated() {
+		tokenClaims["act"] = map[string]interface{}{"sub": principal.Actor.Email}
+	}
+	key, err := opts.signingKey(user.JwtEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return sitrep.NewJwtResponse(key, tokenClaims, capTokenLifetime(user, opts.ExerciseTokenLifetime))
+}
+
+// VerifyExerciseToken validates an exercise token and returns its claims.
+// Tokens signed by the key ring are verified from their signature and claims
+// alone, unless ExerciseTokenUserCheck is set. Tokens signed with the secret
+// of the user need the user to be loaded for the secret, so the user is
+// checked as well.
+func VerifyExerciseToken(cassandra *gocql.ClusterConfig, opts *Options, accessToken string) (*ExerciseClaims, error) {
+	var user *sitrep.UsersByEmail
+	token, err := sitrep.ParseJwt(accessToken, opts.Keys, func(claims map[string]interface{}) (string, error) {
+		if use, _ := claims["token_use"].(string); use != TokenUseExercise {
+			return "", errNotExerciseToken
+		}
+		var err error
+		subject, _ := claims["sub"].(string)
+		if user, err = FindUserByEmail(cassandra, subject); err != nil {
+			return "", err
+		}
+		return user.JwtEncryptionKey, nil
+	})
+	if err != nil {
+		if ve, ok := err.(*jwt.ValidationError); ok && ve.Inner == errNotExerciseToken {
+			return nil, errNotExerciseToken
+		}
+		return nil, NewExerciseForbiddenError()
+	}
+	if use, _ := token.Claims["token_use"].(string); use != TokenUseExercise {
+		return nil, errNotExerciseToken
+	}
+
+	// Tokens signed by the key ring did not need the user to be verified
+	if user == nil && opts.ExerciseTokenUserCheck {
+		subject, _ := token.Claims["sub"].(string)
+		if user, err = FindUserByEmail(cassandra, subject); err != nil {
+			return nil, err
+		}
+	}
+	if user != nil {
+		if err := checkExerciseTokenUser(user, token.Claims); err != nil {
+			return nil, err
+		}
+	}
+
+	exerciseID, _ := token.Claims["exercise_id"].(string)
+	id, err := gocql.ParseUUID(exerciseID)
+	if err != nil {
+		return nil, NewExerciseForbiddenError()
+	}
+	claims := &ExerciseClaims{ExerciseID: id}
+	claims.Subject, _ = token.Claims["sub"].(string)
+	claims.IsOC, _ = token.Claims["is_oc"].(bool)
+	claims.IsAdmin, _ = token.Claims["is_exercise_admin"].(bool)
+	claims.IsTrainee, _ = token.Claims["is_trainee"].(bool)
+	claims.IsInvisible, _ = token.Claims["is_invisible"].(bool)
+	claims.IsAuthorized, _ = token.Claims["is_authorized"].(bool)
+	claims.RoleDescription, _ = token.Claims["role_description"].(string)
+	claims.IsSiteAdmin, _ = token.Claims["is_site_admin"].(bool)
+	if act, ok := token.Claims["act"].(map[string]interface{}); ok {
+		claims.Actor, _ = act["sub"].(string)
+		if err := RecordAuditEvent(cassandra, claims.Subject, AuditImpersonationUsed, nil, claims.Actor); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// AuthorizeExerciseRequest returns the roles of the caller in the exercise
+// with exerciseID. Exercise tokens are authorized from their claims, for any
+// other access token of a user the permissions are read from the database.
+func AuthorizeExerciseRequest(cassandra *gocql.ClusterConfig, opts *Options, accessToken string, exerciseID string) (*ExerciseClaims, error) {
+	claims, err := VerifyExerciseToken(cassandra, opts, accessToken)
+	if err == nil {
+		if exerciseID != "" && exerciseID != claims.ExerciseID.String() {
+			return nil, NewExerciseForbiddenError()
+		}
+		return claims, nil
+	}
+	if err != errNotExerciseToken {
+		return nil, err
+	}
+
+	id, err := gocql.ParseUUID(exerciseID)
+	if err != nil {
+		return nil, NewExerciseForbiddenError()
+	}
+	principal, err := VerifyRequest(cassandra, opts, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	user := principal.User
+	if user == nil {
+		return nil, NewUserInvalidError()
+	}
+	if err := principal.CheckExercise(id); err != nil {
+		return nil, err
+	}
+	permissions, err := FindExercisePermissionsForUser(cassandra, user, &sitrep.ExerciseByIdentifier{Id: id})
+	if err != nil || permissions.UserEmail == "" {
+		return nil, NewExerciseForbiddenError()
+	}
+	if err := checkExerciseMfa(cassandra, user, permissions); err != nil {
+		return nil, err
+	}
+	claims = NewExerciseClaims(user, permissions)
+	if principal.IsImpersonated() {
+		claims.Actor = principal.Actor.Email
+	}
+	return claims, nil
+}
+
+// checkExerciseTokenUser refuses exercise tokens of banned or expired
+// accounts and tokens issued before the encryption key of the user was
+// rotated
+func checkExerciseTokenUser(user *sitrep.UsersByEmail, claims map[string]interface{}) error {
+	if user.Email == "" || user.IsBanned {
+		return NewUserInvalidError()
+	}
+	if keyHash, _ := claims["key_hash"].(string); keyHash != encryptionKeyHash(user) {
+		return NewUserInvalidError()
+	}
+	return checkAccountExpiry(user)
+}
+
+// encryptionKeyHash identifies the current encryption key of user, without
+// revealing it in the claims of a token
+func encryptionKeyHash(user *sitrep.UsersByEmail) string {
+	return sitrep.HashOpaqueToken(user.JwtEncryptionKey)
+}
+
+// ExerciseForbiddenError is returned, when a user has no role in an exercise
+type ExerciseForbiddenError struct {
+	Message string
+}
+
+// Error prints the ExerciseForbiddenError
+func (e *ExerciseForbiddenError) Error() string {
+	return e.Message
+}
+
+// NewExerciseForbiddenError produces a new ExerciseForbiddenError
+func NewExerciseForbiddenError() *ExerciseForbiddenError {
+	return &ExerciseForbiddenError{
+		Message: "You are not a member of this exercise!",
+	}
+}
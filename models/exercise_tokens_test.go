@@ -0,0 +1,158 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/signing"
+)
+
+func TestExerciseToken_Claims(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, true, false, true)
+	c := dbConn()
+	opts := models.NewOptions()
+
+	token, err := models.IssueExerciseToken(c, opts, &models.Principal{User: user}, exercise)
+	if err != nil {
+		t.Fatalf("Exercise token was not issued: %v", err)
+	}
+	claims, err := models.AuthorizeExerciseRequest(c, opts, token.AccessToken, "")
+	if err != nil {
+		t.Fatalf("Exercise token was not accepted: %v", err)
+	}
+	if claims.ExerciseID != exercise.Id || claims.Subject != user.Email {
+		t.Fatalf("unexpected exercise or subject: %v", claims)
+	}
+	if !claims.IsAdmin || claims.IsOC || !claims.IsTrainee || !claims.IsSiteAdmin {
+		t.Fatalf("unexpected roles: %v", claims)
+	}
+	permissions, err := models.FindExercisePermissionsForUser(c, user, exercise)
+	if err != nil {
+		t.Fatalf("Permissions could not be fetched: %v", err)
+	}
+	if *claims.Permissions() != *permissions {
+		t.Fatalf("Claims do not match the permissions: %v != %v", claims.Permissions(), permissions)
+	}
+	if _, err := models.AuthorizeExerciseRequest(c, opts, token.AccessToken, "00000000-0000-0000-0000-000000000000"); err == nil {
+		t.Fatalf("Exercise token was accepted for another exercise")
+	}
+	if _, err := models.VerifyUserRequest(c, opts, token.AccessToken); err == nil {
+		t.Fatalf("Exercise token was accepted as an access token")
+	}
+}
+
+func TestExerciseToken_RevokedWithKeyRing(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, false, true, false)
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.Keys = signing.NewKeyRing(mockKey(t))
+	opts.ExerciseTokenUserCheck = true
+
+	token, err := models.IssueExerciseToken(c, opts, &models.Principal{User: user}, exercise)
+	if err != nil {
+		t.Fatalf("Exercise token was not issued: %v", err)
+	}
+	if _, err := models.VerifyExerciseToken(c, opts, token.AccessToken); err != nil {
+		t.Fatalf("Exercise token was not accepted: %v", err)
+	}
+	if err := models.RotateUserEncryptionKey(c, user); err != nil {
+		t.Fatalf("Key could not be rotated: %v", err)
+	}
+	defer initUser(mockUser())
+	if _, err := models.VerifyExerciseToken(c, opts, token.AccessToken); err == nil {
+		t.Fatalf("Exercise token was accepted after the key of the user was rotated")
+	}
+
+	token, err = models.IssueExerciseToken(c, opts, &models.Principal{User: user}, exercise)
+	if err != nil {
+		t.Fatalf("Exercise token was not issued: %v", err)
+	}
+	user.IsBanned = true
+	initUser(user)
+	if _, err := models.VerifyExerciseToken(c, opts, token.AccessToken); err == nil {
+		t.Fatalf("Exercise token of a banned user was accepted")
+	}
+}
+
+func TestExerciseToken_AccessTokenFallback(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, false, true, false)
+	c := dbConn()
+	opts := models.NewOptions()
+	req, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+
+	claims, err := models.AuthorizeExerciseRequest(c, opts, req.AccessToken, exercise.Id.String())
+	if err != nil {
+		t.Fatalf("Access token was not accepted: %v", err)
+	}
+	if claims.IsAdmin || !claims.IsOC {
+		t.Fatalf("unexpected roles: %v", claims)
+	}
+	if _, err := models.AuthorizeExerciseRequest(c, opts, req.AccessToken, ""); err == nil {
+		t.Fatalf("Access token was accepted without an exercise")
+	}
+}
+
+func TestExerciseClaims_CanUpdateSettings(t *testing.T) {
+	magicLink := map[string]string{models.ExerciseSettingMagicLink: "true"}
+	requireMfa := map[string]string{models.ExerciseSettingRequireMfa: "true"}
+	joinCode := map[string]string{models.ExerciseSettingJoinCode: "secret"}
+
+	full := &models.ExerciseClaims{IsAdmin: true, IsSiteAdmin: true}
+	if !full.CanUpdateSettings(requireMfa) || !full.CanUpdateSettings(magicLink) {
+		t.Fatalf("Administrator of exercise and service could not update settings")
+	}
+	exerciseAdmin := &models.ExerciseClaims{IsAdmin: true}
+	if !exerciseAdmin.CanUpdateSettings(magicLink) {
+		t.Fatalf("Exercise administrator could not switch emailed sign in links")
+	}
+	if exerciseAdmin.CanUpdateSettings(requireMfa) || exerciseAdmin.CanUpdateSettings(joinCode) {
+		t.Fatalf("Exercise administrator could update any setting")
+	}
+	siteAdmin := &models.ExerciseClaims{IsSiteAdmin: true, IsTrainee: true}
+	if siteAdmin.CanUpdateSettings(magicLink) {
+		t.Fatalf("Service administrator without a role in the exercise could update settings")
+	}
+}
+
+func TestExerciseToken_KeyRingWithoutUserCheck(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, false, true, false)
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.Keys = signing.NewKeyRing(mockKey(t))
+
+	token, err := models.IssueExerciseToken(c, opts, &models.Principal{User: user}, exercise)
+	if err != nil {
+		t.Fatalf("Exercise token was not issued: %v", err)
+	}
+	user.IsBanned = true
+	initUser(user)
+	defer initUser(mockUser())
+	// Only the signature and the claims are verified, the token stays valid
+	// until it expires
+	if _, err := models.VerifyExerciseToken(c, opts, token.AccessToken); err != nil {
+		t.Fatalf("Exercise token was not verified from its claims: %v", err)
+	}
+	opts.ExerciseTokenUserCheck = true
+	if _, err := models.VerifyExerciseToken(c, opts, token.AccessToken); err == nil {
+		t.Fatalf("Exercise token of a banned user was accepted")
+	}
+}
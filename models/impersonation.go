@@ -0,0 +1,142 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// ImpersonateUser lets actor act as the user with email, e.g. to see an
+// exercise exactly like a trainee does. Administrators of the whole service
+// can act as any user but other administrators, controllers (OCs) only as
+// trainees and invisible members of exerciseID.
+//
+// The access token names the target as sub and actor in an act claim. It
+// can not be refreshed and each of its uses is recorded in the audit log of
+// the target. Tokens of controllers are limited to exerciseID, the target
+// may have more rights in other exercises.
+func ImpersonateUser(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, actor *sitrep.UsersByEmail, email string, exerciseID string) (*sitrep.JWTResponse, error) {
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil || user.Email == "" || user.IsBanned {
+		return nil, NewUserNotFoundError()
+	}
+	if err := checkImpersonation(cassandra, actor, user, exerciseID); err != nil {
+		return nil, err
+	}
+	if err := checkAccountExpiry(user); err != nil {
+		return nil, err
+	}
+
+	claims := map[string]interface{}{
+		"sub":            user.Email,
+		"principal_type": PrincipalUser,
+		"act":            map[string]interface{}{"sub": actor.Email},
+	}
+	if !actor.IsAdmin {
+		// checkImpersonation has already parsed the id
+		id, _ := gocql.ParseUUID(exerciseID)
+		claims["exercise_id"] = id.String()
+	}
+	key, err := opts.signingKey(user.JwtEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	token, err := sitrep.NewJwtResponse(key, claims, capTokenLifetime(user, opts.AccessTokenLifetime))
+	if err != nil {
+		return nil, err
+	}
+	jwtUser := &sitrep.UsersByJwt{
+		EncryptionKey: user.JwtEncryptionKey,
+		Jwt:           token.AccessToken,
+		UserEmail:     user.Email,
+		UserName:      user.RealName,
+	}
+	// Indexed with the other tokens of the user, so signing the user out
+	// everywhere ends the impersonation as well
+	jwtByEmail := &sitrep.JwtByUserEmail{
+		UserEmail: user.Email,
+		Jwt:       token.AccessToken,
+		IssuedAt:  time.Now(),
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(UsersJwtTable.Bind(*jwtUser)).Exec(session); err != nil {
+		return nil, err
+	}
+	if err := ctx.Store(JwtByUserEmailTable.Bind(*jwtByEmail)).Exec(session); err != nil {
+		return nil, err
+	}
+	if err := RecordAuditEvent(cassandra, user.Email, AuditImpersonationStarted, client, actor.Email); err != nil {
+		return nil, err
+	}
+	if err := RecordAuditEvent(cassandra, actor.Email, AuditImpersonationStarted, client, user.Email); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// checkImpersonation decides whether actor may act as user
+func checkImpersonation(cassandra *gocql.ClusterConfig, actor *sitrep.UsersByEmail, user *sitrep.UsersByEmail, exerciseID string) error {
+	if actor.Email == user.Email || user.IsAdmin {
+		return NewImpersonationForbiddenError()
+	}
+	if actor.IsAdmin {
+		return nil
+	}
+	id, err := gocql.ParseUUID(exerciseID)
+	if err != nil {
+		return NewImpersonationForbiddenError()
+	}
+	exercise := &sitrep.ExerciseByIdentifier{Id: id}
+	permissions, err := FindExercisePermissionsForUser(cassandra, actor, exercise)
+	if err != nil || permissions.UserEmail == "" || !permissions.IsOc {
+		return NewImpersonationForbiddenError()
+	}
+	if err := checkExerciseMfa(cassandra, actor, permissions); err != nil {
+		return err
+	}
+	permissions, err = FindExercisePermissionsForUser(cassandra, user, exercise)
+	if err != nil || permissions.UserEmail == "" || permissions.IsOc || permissions.IsAdmin {
+		return NewImpersonationForbiddenError()
+	}
+	return nil
+}
+
+// findActor loads the user named by the act claim of an impersonation
+// token. Tokens without one have no actor.
+func findActor(cassandra *gocql.ClusterConfig, claims map[string]interface{}) (*sitrep.UsersByEmail, error) {
+	act, ok := claims["act"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	email, _ := act["sub"].(string)
+	actor, err := FindUserByEmail(cassandra, email)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Email == "" || actor.IsBanned {
+		return nil, NewUserInvalidError()
+	}
+	if err := checkAccountExpiry(actor); err != nil {
+		return nil, err
+	}
+	return actor, nil
+}
+
+// ImpersonationForbiddenError is returned, when a user may not act as another one
+type ImpersonationForbiddenError struct {
+	Message string
+}
+
+// Error prints the ImpersonationForbiddenError
+func (i *ImpersonationForbiddenError) Error() string {
+	return i.Message
+}
+
+// NewImpersonationForbiddenError produces a new ImpersonationForbiddenError
+func NewImpersonationForbiddenError() *ImpersonationForbiddenError {
+	return &ImpersonationForbiddenError{
+		Message: "You are not allowed to act as this user!",
+	}
+}
@@ -0,0 +1,130 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/gocql/gocql"
+)
+
+func TestImpersonation_Controller(t *testing.T) {
+	controller := mockUser()
+	controller.Email = "controller@somedomain.com"
+	controller.IsAdmin = false
+	initUser(controller)
+	trainee := mockUser()
+	trainee.Email = "trainee@somedomain.com"
+	trainee.IsAdmin = false
+	initUser(trainee)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(controller, exercise, false, true, false)
+	addUserPermissionToExercise(trainee, exercise, false, false, true)
+	c := dbConn()
+	opts := models.NewOptions()
+
+	token, err := models.ImpersonateUser(c, opts, nil, controller, trainee.Email, exercise.Id.String())
+	if err != nil {
+		t.Fatalf("Controller could not impersonate a trainee: %v", err)
+	}
+	if token.RefreshToken != "" {
+		t.Fatalf("Impersonation token can be refreshed")
+	}
+	principal, err := models.VerifyRequest(c, opts, token.AccessToken)
+	if err != nil {
+		t.Fatalf("Impersonation token was not accepted: %v", err)
+	}
+	if principal.User.Email != trainee.Email || !principal.IsImpersonated() || principal.Actor.Email != controller.Email {
+		t.Fatalf("Unexpected identities: %s acting as %s", principal.Actor.Email, principal.User.Email)
+	}
+
+	claims, err := models.AuthorizeExerciseRequest(c, opts, token.AccessToken, exercise.Id.String())
+	if err != nil {
+		t.Fatalf("Impersonation token was not authorized in the exercise: %v", err)
+	}
+	if claims.Subject != trainee.Email || claims.Actor != controller.Email || claims.IsOC {
+		t.Fatalf("Unexpected claims: %+v", claims)
+	}
+
+	events, err := models.FindAuditEvents(c, trainee.Email)
+	if err != nil {
+		t.Fatalf("Audit log could not be fetched: %v", err)
+	}
+	if len(events) == 0 || events[0].Event != models.AuditImpersonationUsed || events[0].Details != controller.Email {
+		t.Fatalf("Use of the impersonation token was not audited")
+	}
+}
+
+func TestImpersonation_Forbidden(t *testing.T) {
+	admin := mockUser()
+	initUser(admin)
+	controller := mockUser()
+	controller.Email = "controller@somedomain.com"
+	controller.IsAdmin = false
+	initUser(controller)
+	trainee := mockUser()
+	trainee.Email = "trainee@somedomain.com"
+	trainee.IsAdmin = false
+	initUser(trainee)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(controller, exercise, false, true, false)
+	addUserPermissionToExercise(trainee, exercise, false, false, true)
+	c := dbConn()
+	opts := models.NewOptions()
+
+	if _, err := models.ImpersonateUser(c, opts, nil, trainee, controller.Email, exercise.Id.String()); err == nil {
+		t.Fatalf("Trainee could impersonate a controller")
+	}
+	if _, err := models.ImpersonateUser(c, opts, nil, controller, trainee.Email, ""); err == nil {
+		t.Fatalf("Controller could impersonate without an exercise")
+	}
+	if _, err := models.ImpersonateUser(c, opts, nil, controller, admin.Email, exercise.Id.String()); err == nil {
+		t.Fatalf("Controller could impersonate an administrator")
+	} else if _, ok := err.(*models.ImpersonationForbiddenError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := models.ImpersonateUser(c, opts, nil, admin, trainee.Email, ""); err != nil {
+		t.Fatalf("Administrator could not impersonate a user: %v", err)
+	}
+}
+
+func TestImpersonation_LimitedToExercise(t *testing.T) {
+	controller := mockUser()
+	controller.Email = "controller@somedomain.com"
+	controller.IsAdmin = false
+	initUser(controller)
+	trainee := mockUser()
+	trainee.Email = "trainee@somedomain.com"
+	trainee.IsAdmin = false
+	initUser(trainee)
+	exercise := mockExercise()
+	initExercise(exercise)
+	other := mockExercise()
+	other.Id = gocql.TimeUUID()
+	initExercise(other)
+	addUserPermissionToExercise(controller, exercise, false, true, false)
+	addUserPermissionToExercise(trainee, exercise, false, false, true)
+	// The trainee controls another exercise, the controller does not
+	addUserPermissionToExercise(trainee, other, true, true, false)
+	c := dbConn()
+	opts := models.NewOptions()
+
+	token, err := models.ImpersonateUser(c, opts, nil, controller, trainee.Email, exercise.Id.String())
+	if err != nil {
+		t.Fatalf("Controller could not impersonate a trainee: %v", err)
+	}
+	if _, err := models.AuthorizeExerciseRequest(c, opts, token.AccessToken, other.Id.String()); err == nil {
+		t.Fatalf("Impersonation token was authorized in another exercise")
+	}
+	principal, err := models.VerifyRequest(c, opts, token.AccessToken)
+	if err != nil {
+		t.Fatalf("Impersonation token was not accepted: %v", err)
+	}
+	if _, err := models.IssueExerciseToken(c, opts, principal, other); err == nil {
+		t.Fatalf("Impersonation token was exchanged for another exercise")
+	}
+	if _, err := models.IssueExerciseToken(c, opts, principal, exercise); err != nil {
+		t.Fatalf("Impersonation token was not exchanged for its exercise: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// ScopeIntrospect lets a service client introspect tokens
+const ScopeIntrospect = "introspect"
+
+// IntrospectToken reports the state of an access token to a service client,
+// which has been registered with ScopeIntrospect. Tokens that are invalid
+// for any reason are reported as inactive, banned users are flagged as well,
+// tokens of expired accounts are inactive. Impersonation tokens name their
+// actor.
+func IntrospectToken(cassandra *gocql.ClusterConfig, opts *Options, client *sitrep.OauthClients, accessToken string) (*sitrep.TokenIntrospection, error) {
+	if !containsScope(parseScopes(client.AllowedScopes), ScopeIntrospect) {
+		return nil, NewInvalidScopeError()
+	}
+	_, claims, principal, err := verifyAccessToken(cassandra, opts, accessToken)
+	if err != nil {
+		return &sitrep.TokenIntrospection{Active: false}, nil
+	}
+	result := &sitrep.TokenIntrospection{
+		Active:        true,
+		Subject:       principal.Subject,
+		Scope:         sitrep.DefaultScope,
+		TokenType:     "bearer",
+		PrincipalType: principal.Type,
+	}
+	if user := principal.User; user != nil {
+		if user.IsBanned {
+			return &sitrep.TokenIntrospection{Active: false, IsBanned: true}, nil
+		}
+		if checkAccountExpiry(user) != nil {
+			return &sitrep.TokenIntrospection{Active: false}, nil
+		}
+		roles, err := FindExerciseRolesForUser(cassandra, user)
+		if err != nil {
+			return nil, err
+		}
+		result.ExerciseRoles = roles
+	}
+	if principal.IsImpersonated() {
+		if err := RecordAuditEvent(cassandra, principal.Subject, AuditImpersonationUsed, nil, principal.Actor.Email); err != nil {
+			return nil, err
+		}
+		result.Act = map[string]string{"sub": principal.Actor.Email}
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		result.ExpiresAt = int64(exp)
+	}
+	if scope, ok := claims["scope"].(string); ok {
+		result.Scope = scope
+	}
+	return result, nil
+}
+
+// FindExerciseRolesForUser lists the permissions of a user in every exercise
+func FindExerciseRolesForUser(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) ([]*sitrep.ExerciseRole, error) {
+	roles := make([]*sitrep.ExerciseRole, 0)
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(ExercisePermissionsLevelTable).
+		Where(
+		ExercisePermissionsLevelTable.USER_EMAIL.Eq(user.Email)).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	err = sitrep.MapExercisePermissionsLevel(iter, func(p sitrep.ExercisePermissionsLevel) (bool, error) {
+		roles = append(roles, &sitrep.ExerciseRole{
+			ExerciseID:   p.ExerciseIdentifier.String(),
+			IsOc:         p.IsOc,
+			IsAdmin:      p.IsAdmin,
+			IsTrainee:    p.IsTrainee,
+			IsAuthorized: p.IsAuthorized,
+			IsInvisible:  p.IsInvisible,
+			Description:  p.RoleDescription,
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
@@ -0,0 +1,291 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// LoginFailuresTable is a reference to the failed sign in attempts within the failure window
+var LoginFailuresTable = sitrep.LoginFailuresTableDef()
+
+// LoginLockoutsTable is a reference to the accounts and clients, which are temporarily locked out
+var LoginLockoutsTable = sitrep.LoginLockoutsTableDef()
+
+// AuthenticateLogin checks the credentials of a sign in attempt like
+// AuthenticateUser. Wrong passwords are counted per email address, per client
+// address and per email and client address. From the second failure of an
+// account on a client, the next attempt has to wait longer every time, and
+// too many failures within LoginFailureWindow lock the client out of the
+// account, the whole account or the client out for LoginLockout.
+// Refused attempts of known users are added to their login history.
+func AuthenticateLogin(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, email string, password string) (*sitrep.UsersByEmail, error) {
+	if client == nil {
+		client = &ClientInfo{}
+	}
+	emailSubject := loginSubjectEmail(email)
+	pairSubject := loginSubjectPair(email, client.RemoteAddr)
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil {
+		return nil, NewUserInvalidError()
+	}
+	if err := checkLoginAllowed(cassandra, opts, emailSubject, pairSubject, loginSubjectAddr(client.RemoteAddr)); err != nil {
+		if _, ok := err.(*AccountLockedError); ok && user.Email != "" {
+			if err := recordLogin(cassandra, opts, client, user.Email, LoginFailureLocked); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	if err := user.ValidatePassword(password); err != nil {
+		if err := recordLoginFailure(cassandra, opts, client, email); err != nil {
+			return nil, err
+		}
+		if user.Email != "" {
+			if err := recordLogin(cassandra, opts, client, user.Email, LoginFailureInvalidPassword); err != nil {
+				return nil, err
+			}
+		}
+		return nil, NewUserInvalidError()
+	}
+	if user.IsBanned {
+		if err := recordLogin(cassandra, opts, client, user.Email, LoginFailureBanned); err != nil {
+			return nil, err
+		}
+		return nil, NewUserInvalidError()
+	}
+	if err := checkAccountExpiry(user); err != nil {
+		if err := recordLogin(cassandra, opts, client, user.Email, LoginFailureExpired); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	if err := clearLoginFailures(cassandra, emailSubject); err != nil {
+		return nil, err
+	}
+	if pairSubject != "" {
+		if err := clearLoginFailures(cassandra, pairSubject); err != nil {
+			return nil, err
+		}
+	}
+	upgradePasswordHash(cassandra, opts, user, password)
+	return user, nil
+}
+
+// UnlockUser lifts the lockout of the user with email and forgets the
+// failed sign in attempts of the account. Lockouts of single client
+// addresses from the account end with LoginLockout.
+func UnlockUser(cassandra *gocql.ClusterConfig, client *ClientInfo, actor string, email string) error {
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil || user.Email == "" {
+		return NewUserNotFoundError()
+	}
+	if err := clearLoginFailures(cassandra, loginSubjectEmail(user.Email)); err != nil {
+		return err
+	}
+	return RecordAuditEvent(cassandra, user.Email, AuditAccountUnlocked, client, actor)
+}
+
+// checkLoginAllowed refuses sign in attempts for locked out subjects and
+// attempts of an account, which come before its progressive delay is over.
+// The delay only grows with the failures from the same client address, if it
+// is known. A single typo costs no delay, it starts with the second failure.
+func checkLoginAllowed(cassandra *gocql.ClusterConfig, opts *Options, emailSubject string, pairSubject string, addrSubject string) error {
+	now := time.Now()
+	for _, subject := range []string{emailSubject, pairSubject, addrSubject} {
+		if subject == "" {
+			continue
+		}
+		lockedUntil, err := findLoginLockout(cassandra, subject)
+		if err != nil {
+			return err
+		}
+		if now.Before(lockedUntil) {
+			return NewAccountLockedError(lockedUntil.Sub(now))
+		}
+	}
+	if opts.LoginDelay <= 0 {
+		return nil
+	}
+	delaySubject := pairSubject
+	if delaySubject == "" {
+		delaySubject = emailSubject
+	}
+	failures, err := findLoginFailures(cassandra, opts, delaySubject)
+	if err != nil || len(failures) < 2 {
+		return err
+	}
+	delay := opts.LoginDelay
+	for i := 2; i < len(failures) && delay < opts.LoginLockout; i++ {
+		delay *= 2
+	}
+	if opts.LoginLockout > 0 && delay > opts.LoginLockout {
+		delay = opts.LoginLockout
+	}
+	if retryAt := failures[0].Add(delay); now.Before(retryAt) {
+		return NewAccountLockedError(retryAt.Sub(now))
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed sign in attempt for the account, for
+// the client and for the client at the account. Subjects reaching their
+// limit are locked out.
+func recordLoginFailure(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, email string) error {
+	limits := map[string]int{
+		loginSubjectEmail(email):                   opts.LoginMaxFailures,
+		loginSubjectAddr(client.RemoteAddr):        opts.LoginMaxFailuresPerAddr,
+		loginSubjectPair(email, client.RemoteAddr): opts.LoginMaxFailuresPerPair,
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	for subject, limit := range limits {
+		if subject == "" {
+			continue
+		}
+		// Failures outside of the window are no longer counted
+		if err := insertWithTTL(session, "login_failures", map[string]interface{}{
+			"subject":    subject,
+			"failure_id": gocql.TimeUUID(),
+		}, opts.LoginFailureWindow); err != nil {
+			return err
+		}
+		if limit <= 0 || opts.LoginLockout <= 0 {
+			continue
+		}
+		failures, err := findLoginFailures(cassandra, opts, subject)
+		if err != nil {
+			return err
+		}
+		if len(failures) < limit {
+			continue
+		}
+		if err := insertWithTTL(session, "login_lockouts", map[string]interface{}{
+			"subject":      subject,
+			"locked_until": time.Now().Add(opts.LoginLockout),
+		}, opts.LoginLockout); err != nil {
+			return err
+		}
+		// Only the lockout of the whole account is audited
+		if subject != loginSubjectEmail(email) {
+			continue
+		}
+		// Guessing at unknown addresses must not fill the audit log
+		if user, err := FindUserByEmail(cassandra, email); err == nil && user.Email != "" {
+			if err := RecordAuditEvent(cassandra, user.Email, AuditAccountLocked, client, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findLoginFailures returns when the failed sign in attempts of subject
+// within LoginFailureWindow happened, the latest first
+func findLoginFailures(cassandra *gocql.ClusterConfig, opts *Options, subject string) ([]time.Time, error) {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(LoginFailuresTable).
+		Where(
+		LoginFailuresTable.SUBJECT.Eq(subject)).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := sitrep.BindLoginFailures(iter)
+	if err != nil {
+		return nil, err
+	}
+	since := time.Now().Add(-opts.LoginFailureWindow)
+	times := make([]time.Time, 0, len(failures))
+	for i := len(failures) - 1; i >= 0; i-- {
+		if failedAt := failures[i].FailureId.Time(); failedAt.After(since) {
+			times = append(times, failedAt)
+		}
+	}
+	return times, nil
+}
+
+func findLoginLockout(cassandra *gocql.ClusterConfig, subject string) (time.Time, error) {
+	var lockout sitrep.LoginLockouts
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	_, err := ctx.Select().
+		From(LoginLockoutsTable).
+		Where(
+		LoginLockoutsTable.SUBJECT.Eq(subject)).
+		Into(
+		LoginLockoutsTable.To(&lockout)).
+		FetchOne(session)
+
+	return lockout.LockedUntil, err
+}
+
+func clearLoginFailures(cassandra *gocql.ClusterConfig, subject string) error {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Delete().
+		From(LoginFailuresTable).
+		Where(
+		LoginFailuresTable.SUBJECT.Eq(subject)).
+		Exec(session); err != nil {
+		return err
+	}
+	return ctx.Delete().
+		From(LoginLockoutsTable).
+		Where(
+		LoginLockoutsTable.SUBJECT.Eq(subject)).
+		Exec(session)
+}
+
+func loginSubjectEmail(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginSubjectAddr(addr string) string {
+	if addr == "" {
+		return ""
+	}
+	return "addr:" + addr
+}
+
+func loginSubjectPair(email string, addr string) string {
+	if addr == "" {
+		return ""
+	}
+	return loginSubjectEmail(email) + " " + loginSubjectAddr(addr)
+}
+
+// ttlSeconds rounds d up to whole seconds, Cassandra needs at least one
+func ttlSeconds(d time.Duration) int {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// AccountLockedError is returned, when an account or client has to wait
+// before trying to sign in again
+type AccountLockedError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+// Error prints the AccountLockedError
+func (a *AccountLockedError) Error() string {
+	return a.Message
+}
+
+// NewAccountLockedError produces a new AccountLockedError
+func NewAccountLockedError(retryAfter time.Duration) *AccountLockedError {
+	seconds := ttlSeconds(retryAfter)
+	return &AccountLockedError{
+		Message:    fmt.Sprintf("Too many failed sign in attempts, please try again in %d seconds!", seconds),
+		RetryAfter: time.Duration(seconds) * time.Second,
+	}
+}
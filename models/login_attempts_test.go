@@ -0,0 +1,100 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func TestLogin_Lockout(t *testing.T) {
+	user := mockUser()
+	user.Email = "locked-out@somedomain.com"
+	initUser(user)
+	c := dbConn()
+	client := &models.ClientInfo{RemoteAddr: "192.0.2.19"}
+	opts := models.NewOptions()
+	opts.LoginDelay = 0
+	opts.LoginMaxFailures = 3
+	if err := models.UnlockUser(c, nil, "admin@somedomain.com", user.Email); err != nil {
+		t.Fatalf("User could not be unlocked: %v", err)
+	}
+
+	for i := 0; i < opts.LoginMaxFailures; i++ {
+		if _, err := models.UserSignIn(c, opts, client, user.Email, "wrong", "password"); err == nil {
+			t.Fatalf("Incorrect password was accepted")
+		} else if _, ok := err.(*models.UserInvalidError); !ok {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	_, err := models.UserSignIn(c, opts, client, user.Email, "test1234", "password")
+	if locked, ok := err.(*models.AccountLockedError); !ok {
+		t.Fatalf("Locked user was not refused: %v", err)
+	} else if locked.RetryAfter <= 0 || locked.RetryAfter > opts.LoginLockout {
+		t.Fatalf("Unexpected retry after: %s", locked.RetryAfter)
+	}
+
+	if err := models.UnlockUser(c, nil, "admin@somedomain.com", user.Email); err != nil {
+		t.Fatalf("User could not be unlocked: %v", err)
+	}
+	if _, err := models.UserSignIn(c, opts, client, user.Email, "test1234", "password"); err != nil {
+		t.Fatalf("Unlocked user could not sign in: %v", err)
+	}
+}
+
+func TestLogin_ProgressiveDelay(t *testing.T) {
+	user := mockUser()
+	user.Email = "delayed@somedomain.com"
+	initUser(user)
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.LoginDelay = time.Hour
+	if err := models.UnlockUser(c, nil, "admin@somedomain.com", user.Email); err != nil {
+		t.Fatalf("User could not be unlocked: %v", err)
+	}
+
+	if _, err := models.UserSignIn(c, opts, nil, user.Email, "wrong", "password"); err == nil {
+		t.Fatalf("Incorrect password was accepted")
+	}
+	if _, err := models.UserSignIn(c, opts, nil, user.Email, "wrong", "password"); err == nil {
+		t.Fatalf("Incorrect password was accepted")
+	} else if _, ok := err.(*models.UserInvalidError); !ok {
+		t.Fatalf("A single failure delayed the next attempt: %v", err)
+	}
+	if _, err := models.UserSignIn(c, opts, nil, user.Email, "test1234", "password"); err == nil {
+		t.Fatalf("Attempt within the delay was accepted")
+	} else if _, ok := err.(*models.AccountLockedError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	models.UnlockUser(c, nil, "admin@somedomain.com", user.Email)
+}
+
+func TestLogin_LockoutPerAddress(t *testing.T) {
+	user := mockUser()
+	user.Email = "locked-out-client@somedomain.com"
+	initUser(user)
+	c := dbConn()
+	attacker := &models.ClientInfo{RemoteAddr: "192.0.2.23"}
+	owner := &models.ClientInfo{RemoteAddr: "198.51.100.7"}
+	opts := models.NewOptions()
+	opts.LoginDelay = 0
+	opts.LoginMaxFailuresPerPair = 2
+	opts.LoginLockout = 2 * time.Second
+	if err := models.UnlockUser(c, nil, "admin@somedomain.com", user.Email); err != nil {
+		t.Fatalf("User could not be unlocked: %v", err)
+	}
+
+	for i := 0; i < opts.LoginMaxFailuresPerPair; i++ {
+		if _, err := models.UserSignIn(c, opts, attacker, user.Email, "wrong", "password"); err == nil {
+			t.Fatalf("Incorrect password was accepted")
+		}
+	}
+	if _, err := models.UserSignIn(c, opts, attacker, user.Email, "test1234", "password"); err == nil {
+		t.Fatalf("Locked out client was not refused")
+	} else if _, ok := err.(*models.AccountLockedError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := models.UserSignIn(c, opts, owner, user.Email, "test1234", "password"); err != nil {
+		t.Fatalf("Another client was locked out of the account: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+	"github.com/relops/cqlc/cqlc"
+)
+
+// LoginHistoryTable is a reference to the sign in attempts of users
+var LoginHistoryTable = sitrep.LoginHistoryTableDef()
+
+const (
+	// LoginSucceeded is the outcome of a sign in, which started a session
+	LoginSucceeded = "success"
+
+	// LoginFailed is the outcome of a refused sign in
+	LoginFailed = "failure"
+)
+
+// Reasons, why a sign in of a known user has been refused
+const (
+	LoginFailureInvalidPassword     = "invalid_password"
+	LoginFailureInvalidSecondFactor = "invalid_second_factor"
+	LoginFailureInvalidPasskey      = "invalid_passkey"
+	LoginFailureLocked              = "locked"
+	LoginFailureBanned              = "banned"
+	LoginFailureExpired             = "expired"
+)
+
+const (
+	// DefaultLoginHistoryPageSize is the number of sign in attempts returned
+	// at once, if no limit is asked for
+	DefaultLoginHistoryPageSize = 50
+
+	// MaxLoginHistoryPageSize is the largest number of sign in attempts
+	// returned at once
+	MaxLoginHistoryPageSize = 500
+)
+
+// FindLoginHistory lists up to limit sign in attempts of a user within
+// LoginHistoryRetention, latest first. Pages after the first one start
+// before the login id of the last attempt of the previous page, the first
+// page is read with an empty before.
+func FindLoginHistory(cassandra *gocql.ClusterConfig, email string, before gocql.UUID, limit int) ([]sitrep.LoginHistory, error) {
+	if limit <= 0 {
+		limit = DefaultLoginHistoryPageSize
+	}
+	if limit > MaxLoginHistoryPageSize {
+		limit = MaxLoginHistoryPageSize
+	}
+	conditions := []cqlc.Condition{LoginHistoryTable.USER_EMAIL.Eq(email)}
+	if before != (gocql.UUID{}) {
+		conditions = append(conditions, LoginHistoryTable.LOGIN_ID.Lt(before))
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(LoginHistoryTable).
+		Where(conditions...).
+		Limit(limit).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	return sitrep.BindLoginHistory(iter)
+}
+
+// signIn starts a new session of user, whose identity has been proven, and
+// records the successful sign in
+func signIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, user *sitrep.UsersByEmail) (*sitrep.JWTResponse, error) {
+	familyID, err := startTokenFamily(cassandra, user, client)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := issueTokens(cassandra, opts, user, familyID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetTimestamp(UsersTable.LAST_LOGGED_IN, now).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+	user.LastLoggedIn = now
+	if err := recordLogin(cassandra, opts, client, user.Email, ""); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// recordLogin adds a sign in attempt to the history of the user with email.
+// Attempts without a failure reason succeeded.
+func recordLogin(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, email string, failureReason string) error {
+	if client == nil {
+		client = &ClientInfo{}
+	}
+	outcome := LoginSucceeded
+	if failureReason != "" {
+		outcome = LoginFailed
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	// The history expires after LoginHistoryRetention, 0 keeps it forever
+	return insertWithTTL(session, "login_history", map[string]interface{}{
+		"user_email":     email,
+		"login_id":       gocql.TimeUUID(),
+		"outcome":        outcome,
+		"failure_reason": failureReason,
+		"remote_addr":    client.RemoteAddr,
+		"user_agent":     client.UserAgent,
+	}, opts.LoginHistoryRetention)
+}
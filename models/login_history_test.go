@@ -0,0 +1,76 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/gocql/gocql"
+)
+
+func TestLoginHistory_SignIn(t *testing.T) {
+	user := mockUser()
+	user.Email = "history@somedomain.com"
+	user.LastLoggedIn = time.Time{}
+	initUser(user)
+	c := dbConn()
+	client := &models.ClientInfo{RemoteAddr: "192.0.2.23", UserAgent: "history-test"}
+	opts := models.NewOptions()
+	opts.LoginDelay = 0
+	if err := models.UnlockUser(c, nil, "admin@somedomain.com", user.Email); err != nil {
+		t.Fatalf("User could not be unlocked: %v", err)
+	}
+
+	if _, err := models.UserSignIn(c, opts, client, user.Email, "wrong", "password"); err == nil {
+		t.Fatalf("Incorrect password was accepted")
+	}
+	if _, err := models.UserSignIn(c, opts, client, user.Email, "test1234", "password"); err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+
+	logins, err := models.FindLoginHistory(c, user.Email, gocql.UUID{}, 2)
+	if err != nil {
+		t.Fatalf("Login history could not be fetched: %v", err)
+	}
+	if len(logins) != 2 {
+		t.Fatalf("Unexpected number of logins: %d", len(logins))
+	}
+	if logins[0].Outcome != models.LoginSucceeded || logins[0].FailureReason != "" {
+		t.Fatalf("Unexpected latest login: %+v", logins[0])
+	}
+	if logins[1].Outcome != models.LoginFailed || logins[1].FailureReason != models.LoginFailureInvalidPassword {
+		t.Fatalf("Unexpected failed login: %+v", logins[1])
+	}
+	if logins[0].RemoteAddr != client.RemoteAddr || logins[0].UserAgent != client.UserAgent {
+		t.Fatalf("Client was not recorded: %+v", logins[0])
+	}
+	next, err := models.FindLoginHistory(c, user.Email, logins[0].LoginId, 1)
+	if err != nil {
+		t.Fatalf("Login history could not be fetched: %v", err)
+	}
+	if len(next) != 1 || next[0].LoginId != logins[1].LoginId {
+		t.Fatalf("Next page does not start after the cursor: %+v", next)
+	}
+
+	signedIn, err := models.FindUserByEmail(c, user.Email)
+	if err != nil {
+		t.Fatalf("User could not be fetched: %v", err)
+	}
+	if time.Since(signedIn.LastLoggedIn) > time.Minute {
+		t.Fatalf("Last login was not updated: %s", signedIn.LastLoggedIn)
+	}
+}
+
+func TestLoginHistory_UnknownUser(t *testing.T) {
+	c := dbConn()
+	if _, err := models.UserSignIn(c, models.NewOptions(), nil, "nobody-history@somedomain.com", "wrong", "password"); err == nil {
+		t.Fatalf("Unknown user could sign in")
+	}
+	logins, err := models.FindLoginHistory(c, "nobody-history@somedomain.com", gocql.UUID{}, 0)
+	if err != nil {
+		t.Fatalf("Login history could not be fetched: %v", err)
+	}
+	if len(logins) != 0 {
+		t.Fatalf("Attempts of unknown users were recorded")
+	}
+}
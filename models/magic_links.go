@@ -0,0 +1,245 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// MagicLinksTable is a reference to the emailed sign in links, that have not been used
+var MagicLinksTable = sitrep.MagicLinksTableDef()
+
+// ExerciseSettingMagicLink is the exercise setting, which lets the members
+// of an exercise sign in with an emailed link instead of their password
+const ExerciseSettingMagicLink = "magicLinkEnabled"
+
+// CheckMagicLinkEnabled refuses exercises, which do not allow to sign in
+// with emailed links
+func CheckMagicLinkEnabled(cassandra *gocql.ClusterConfig, exerciseID string) error {
+	_, err := findMagicLinkExercise(cassandra, exerciseID)
+	return err
+}
+
+// RequestMagicLink emails a single use sign in link to the user with email.
+// Unknown and banned users, users outside of the exercise and privileged
+// users are silently ignored, so the caller can not tell whether an account
+// exists.
+func RequestMagicLink(cassandra *gocql.ClusterConfig, opts *Options, email string, exerciseID string) error {
+	exercise, err := findMagicLinkExercise(cassandra, exerciseID)
+	if err != nil {
+		return err
+	}
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" || user.IsBanned {
+		return nil
+	}
+	permissions, err := FindExercisePermissionsForUser(cassandra, user, exercise)
+	if err != nil {
+		return err
+	}
+	if permissions.UserEmail == "" {
+		return nil
+	}
+	if privileged, err := hasPrivilegedRole(cassandra, user); err != nil || privileged {
+		return err
+	}
+	if opts.Mailer == nil {
+		return NewMailerDisabledError()
+	}
+
+	token, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	link := &sitrep.MagicLinks{
+		TokenHash:  sitrep.HashOpaqueToken(token),
+		ExerciseId: exercise.Id,
+		ExpiresAt:  time.Now().Add(opts.MagicLinkLifetime),
+		UserEmail:  user.Email,
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := insertWithTTL(session, "magic_links", map[string]interface{}{
+		"token_hash":  link.TokenHash,
+		"exercise_id": link.ExerciseId,
+		"expires_at":  link.ExpiresAt,
+		"user_email":  link.UserEmail,
+	}, opts.MagicLinkLifetime); err != nil {
+		return err
+	}
+	return opts.Mailer.Send(magicLinkMessage(opts, user, exercise, token))
+}
+
+// MagicLinkSignIn signs a user in with the token of an emailed link. It
+// takes the place of the password in UserSignIn, users with two factor
+// authentication still need their second factor. Site administrators and
+// exercise controllers always have to sign in with their password.
+func MagicLinkSignIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, token string) (*sitrep.JWTResponse, error) {
+	link, err := consumeMagicLink(cassandra, token)
+	if err != nil {
+		return nil, err
+	}
+	// The link may have been sent, before the exercise switched them off
+	if err := CheckMagicLinkEnabled(cassandra, link.ExerciseId.String()); err != nil {
+		return nil, err
+	}
+	user, err := FindUserByEmail(cassandra, link.UserEmail)
+	if err != nil || user.Email == "" || user.IsBanned {
+		return nil, NewUserInvalidError()
+	}
+	// The user may have been promoted, after the link was sent
+	if privileged, err := hasPrivilegedRole(cassandra, user); err != nil {
+		return nil, err
+	} else if privileged {
+		return nil, NewMagicLinkInvalidError()
+	}
+	return completeSignIn(cassandra, opts, client, user)
+}
+
+// findMagicLinkExercise returns the exercise with exerciseID, if it allows
+// to sign in with emailed links
+func findMagicLinkExercise(cassandra *gocql.ClusterConfig, exerciseID string) (*sitrep.ExerciseByIdentifier, error) {
+	id, err := gocql.ParseUUID(exerciseID)
+	if err != nil {
+		return nil, NewMagicLinkDisabledError()
+	}
+	exercise, err := FindExerciseByID(cassandra, id)
+	if err != nil {
+		return nil, err
+	}
+	if exercise.Id != id {
+		return nil, NewMagicLinkDisabledError()
+	}
+	settings, err := FindOrInitSettingsForExercise(cassandra, id)
+	if err != nil {
+		return nil, err
+	}
+	if settings[ExerciseSettingMagicLink] != "true" {
+		return nil, NewMagicLinkDisabledError()
+	}
+	return exercise, nil
+}
+
+// hasPrivilegedRole reports whether user is a site administrator or an
+// administrator or controller of any exercise. A sign in link grants a
+// session valid in every exercise, so it must not be the weaker way into
+// these accounts.
+func hasPrivilegedRole(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) (bool, error) {
+	if user.IsAdmin {
+		return true, nil
+	}
+	exercises, err := FindExercisesForUser(cassandra, user)
+	if err != nil {
+		return false, err
+	}
+	for id := range exercises.Exercises {
+		exerciseID, err := gocql.ParseUUID(id)
+		if err != nil {
+			continue
+		}
+		permissions, err := FindExercisePermissionsForUser(cassandra, user, &sitrep.ExerciseByIdentifier{Id: exerciseID})
+		if err != nil {
+			return false, err
+		}
+		if permissions.IsAdmin || permissions.IsOc {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// consumeMagicLink redeems the link with token, so it can only be used once
+func consumeMagicLink(cassandra *gocql.ClusterConfig, token string) (*sitrep.MagicLinks, error) {
+	if token == "" {
+		return nil, NewMagicLinkInvalidError()
+	}
+	var link sitrep.MagicLinks
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(MagicLinksTable).
+		Where(
+		MagicLinksTable.TOKEN_HASH.Eq(sitrep.HashOpaqueToken(token))).
+		Into(
+		MagicLinksTable.To(&link)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewMagicLinkInvalidError()
+	}
+
+	applied, err := consumeOnce(session, "magic_links", map[string]interface{}{
+		"token_hash": link.TokenHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied || time.Now().After(link.ExpiresAt) {
+		return nil, NewMagicLinkInvalidError()
+	}
+	return &link, nil
+}
+
+func magicLinkMessage(opts *Options, user *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier, token string) *mailer.Message {
+	link := token
+	if opts.MagicLinkURL != "" {
+		link = opts.MagicLinkURL + "?" + url.Values{"token": {token}}.Encode()
+	}
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Sign in to %s", exercise.ExerciseName),
+		Body: fmt.Sprintf(`Hello %s,
+
+to sign in to the SITREP exercise %s without your password, please
+follow this link within %d minutes. The link can only be used once:
+
+%s
+
+If you did not ask for this, you can ignore this mail.
+`, user.RealName, exercise.ExerciseName, int(opts.MagicLinkLifetime/time.Minute), link),
+	}
+}
+
+// MagicLinkInvalidError is returned, when a sign in link is wrong, expired or used
+type MagicLinkInvalidError struct {
+	Message string
+}
+
+// Error prints the MagicLinkInvalidError
+func (m *MagicLinkInvalidError) Error() string {
+	return m.Message
+}
+
+// NewMagicLinkInvalidError produces a new MagicLinkInvalidError
+func NewMagicLinkInvalidError() *MagicLinkInvalidError {
+	return &MagicLinkInvalidError{
+		Message: "This sign in link is invalid or has expired!",
+	}
+}
+
+// MagicLinkDisabledError is returned, when an exercise does not allow to sign in with emailed links
+type MagicLinkDisabledError struct {
+	Message string
+}
+
+// Error prints the MagicLinkDisabledError
+func (m *MagicLinkDisabledError) Error() string {
+	return m.Message
+}
+
+// NewMagicLinkDisabledError produces a new MagicLinkDisabledError
+func NewMagicLinkDisabledError() *MagicLinkDisabledError {
+	return &MagicLinkDisabledError{
+		Message: "Signing in with an emailed link is disabled for this exercise!",
+	}
+}
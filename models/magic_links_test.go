@@ -0,0 +1,119 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/mailer/mailertest"
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func TestMagicLink_SignIn(t *testing.T) {
+	user := mockUser()
+	user.Email = "roleplayer@somedomain.com"
+	user.IsAdmin = false
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, false, false, true)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	opts.MagicLinkURL = "https://sitrep-vatcinc.com/magic-link"
+	if _, err := models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "true"}); err != nil {
+		t.Fatalf("Setting could not be updated: %v", err)
+	}
+	defer models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "false"})
+
+	if err := models.RequestMagicLink(c, opts, user.Email, exercise.Id.String()); err != nil {
+		t.Fatalf("Sign in link could not be requested: %v", err)
+	}
+	token := mailedToken(t, sender, user.Email, opts.MagicLinkURL)
+
+	tokens, err := models.MagicLinkSignIn(c, opts, nil, token)
+	if err != nil {
+		t.Fatalf("Sign in link was not accepted: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, tokens.AccessToken); err != nil {
+		t.Fatalf("Access token verification failed: %v", err)
+	}
+	if tokens.RefreshToken == "" {
+		t.Fatalf("No refresh token was issued")
+	}
+	if _, err := models.MagicLinkSignIn(c, opts, nil, token); err == nil {
+		t.Fatalf("Sign in link was accepted twice")
+	} else if _, ok := err.(*models.MagicLinkInvalidError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestMagicLink_Disabled(t *testing.T) {
+	user := mockUser()
+	user.Email = "roleplayer@somedomain.com"
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, false, false, true)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	if _, err := models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "false"}); err != nil {
+		t.Fatalf("Setting could not be updated: %v", err)
+	}
+
+	if err := models.RequestMagicLink(c, opts, user.Email, exercise.Id.String()); err == nil {
+		t.Fatalf("Sign in link was sent for a disabled exercise")
+	} else if _, ok := err.(*models.MagicLinkDisabledError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sender.Messages(user.Email)) != 0 {
+		t.Fatalf("Mail was sent for a disabled exercise")
+	}
+}
+
+func TestMagicLink_UnknownUser(t *testing.T) {
+	exercise := mockExercise()
+	initExercise(exercise)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	if _, err := models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "true"}); err != nil {
+		t.Fatalf("Setting could not be updated: %v", err)
+	}
+	defer models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "false"})
+
+	if err := models.RequestMagicLink(c, opts, "nobody@somedomain.com", exercise.Id.String()); err != nil {
+		t.Fatalf("Unknown user was reported: %v", err)
+	}
+	if len(sender.Messages("nobody@somedomain.com")) != 0 {
+		t.Fatalf("Mail was sent to an unknown user")
+	}
+}
+
+func TestMagicLink_Controller(t *testing.T) {
+	user := mockUser()
+	user.Email = "controller@somedomain.com"
+	user.IsAdmin = false
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserToExercise(user, exercise)
+	addUserPermissionToExercise(user, exercise, false, true, false)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	if _, err := models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "true"}); err != nil {
+		t.Fatalf("Setting could not be updated: %v", err)
+	}
+	defer models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingMagicLink: "false"})
+
+	if err := models.RequestMagicLink(c, opts, user.Email, exercise.Id.String()); err != nil {
+		t.Fatalf("Controller was reported: %v", err)
+	}
+	if len(sender.Messages(user.Email)) != 0 {
+		t.Fatalf("Sign in link was sent to a controller")
+	}
+}
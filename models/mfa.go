@@ -0,0 +1,329 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/totp"
+	"github.com/gocql/gocql"
+)
+
+// MfaChallengesTable is a reference to the pending second sign in steps
+var MfaChallengesTable = sitrep.MfaChallengesTableDef()
+
+const (
+	// TotpIssuer names this service in authenticator apps
+	TotpIssuer = "SITREP"
+
+	// ExerciseSettingRequireMfa is the exercise setting, which makes two
+	// factor authentication mandatory for admins and controllers
+	ExerciseSettingRequireMfa = "twoFactorRequired"
+)
+
+// TotpEnrollment holds what a user needs to set up an authenticator app
+type TotpEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// EnrollTotp generates a new TOTP secret for user. Two factor authentication
+// is only enabled, once a code of the secret has been confirmed.
+func EnrollTotp(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) (*TotpEnrollment, error) {
+	if user.TotpEnabled {
+		return nil, NewMfaAlreadyEnabledError()
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetString(UsersTable.TOTP_SECRET, secret).
+		SetBoolean(UsersTable.TOTP_ENABLED, false).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+	user.TotpSecret = secret
+	return &TotpEnrollment{
+		Secret: secret,
+		URI:    totp.URI(TotpIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTotp enables two factor authentication, if code matches the
+// enrolled secret. It returns the recovery codes of the user, which replace
+// the authenticator app, if it gets lost.
+func ConfirmTotp(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, code string, client *ClientInfo) ([]string, error) {
+	if user.TotpEnabled {
+		return nil, NewMfaAlreadyEnabledError()
+	}
+	if user.TotpSecret == "" {
+		return nil, NewMfaInvalidError()
+	}
+	if err := validateTotp(cassandra, user, code); err != nil {
+		return nil, err
+	}
+	codes, err := generateRecoveryCodes(cassandra, user)
+	if err != nil {
+		return nil, err
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetBoolean(UsersTable.TOTP_ENABLED, true).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+	user.TotpEnabled = true
+	if err := RecordAuditEvent(cassandra, user.Email, AuditTotpEnabled, client, ""); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTotp turns two factor authentication off. A current code or a
+// recovery code is required, so a stolen session alone can not remove the
+// second factor.
+func DisableTotp(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, code string, client *ClientInfo) error {
+	if !user.TotpEnabled {
+		return NewMfaInvalidError()
+	}
+	if err := validateSecondFactor(cassandra, user, code, client); err != nil {
+		return err
+	}
+	if err := deleteRecoveryCodes(cassandra, user); err != nil {
+		return err
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetBoolean(UsersTable.TOTP_ENABLED, false).
+		SetString(UsersTable.TOTP_SECRET, "").
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return err
+	}
+	user.TotpEnabled = false
+	user.TotpSecret = ""
+	return RecordAuditEvent(cassandra, user.Email, AuditTotpDisabled, client, "")
+}
+
+// validateTotp checks a code of user. Every code is accepted only once, so
+// an observed code can not be replayed within its period.
+func validateTotp(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, code string) error {
+	step, ok := totp.Validate(user.TotpSecret, code, time.Now())
+	if !ok || step <= user.TotpLastStep {
+		return NewMfaInvalidError()
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetInt64(UsersTable.TOTP_LAST_STEP, step).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return err
+	}
+	user.TotpLastStep = step
+	return nil
+}
+
+// CreateMfaChallenge starts the second sign in step of a user, whose
+// password has been verified. The returned token identifies the sign in,
+// until it is completed with a code.
+func CreateMfaChallenge(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail) (string, error) {
+	token, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	challenge := &sitrep.MfaChallenges{
+		ChallengeHash: sitrep.HashOpaqueToken(token),
+		ExpiresAt:     time.Now().Add(opts.MfaChallengeLifetime),
+		UserEmail:     user.Email,
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := insertWithTTL(session, "mfa_challenges", map[string]interface{}{
+		"challenge_hash": challenge.ChallengeHash,
+		"expires_at":     challenge.ExpiresAt,
+		"user_email":     challenge.UserEmail,
+	}, opts.MfaChallengeLifetime); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// VerifyMfaChallenge completes the second sign in step and returns the user
+// who signed in. Both codes of the authenticator app and recovery codes are
+// accepted. A challenge allows a single attempt, after a wrong code the
+// user has to sign in with the password again.
+func VerifyMfaChallenge(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, mfaToken string, code string) (*sitrep.UsersByEmail, error) {
+	var challenge sitrep.MfaChallenges
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(MfaChallengesTable).
+		Where(
+		MfaChallengesTable.CHALLENGE_HASH.Eq(sitrep.HashOpaqueToken(mfaToken))).
+		Into(
+		MfaChallengesTable.To(&challenge)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewMfaInvalidError()
+	}
+
+	// Concurrent requests must not get more than one guess at the code
+	applied, err := consumeOnce(session, "mfa_challenges", map[string]interface{}{
+		"challenge_hash": challenge.ChallengeHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied || time.Now().After(challenge.ExpiresAt) {
+		return nil, NewMfaInvalidError()
+	}
+
+	user, err := FindUserByEmail(cassandra, challenge.UserEmail)
+	if err != nil {
+		return nil, NewUserInvalidError()
+	}
+	if user.IsBanned || !user.TotpEnabled {
+		return nil, NewUserInvalidError()
+	}
+	if err := validateSecondFactor(cassandra, user, code, client); err != nil {
+		if err := recordLogin(cassandra, opts, client, user.Email, LoginFailureInvalidSecondFactor); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// MfaSignIn completes a sign in, that has been answered with a
+// MfaRequiredError, and issues the tokens
+func MfaSignIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, mfaToken string, code string) (*sitrep.JWTResponse, error) {
+	user, err := VerifyMfaChallenge(cassandra, opts, client, mfaToken, code)
+	if err != nil {
+		return nil, err
+	}
+	return signIn(cassandra, opts, client, user)
+}
+
+// CheckExerciseMfa refuses admins and controllers of exercise without two
+// factor authentication, if the exercise requires it. Users without a role
+// in the exercise are not refused.
+func CheckExerciseMfa(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier) error {
+	permissions, err := FindExercisePermissionsForUser(cassandra, user, exercise)
+	if err != nil {
+		return err
+	}
+	if permissions.UserEmail == "" {
+		return nil
+	}
+	return checkExerciseMfa(cassandra, user, permissions)
+}
+
+// checkExerciseMfa refuses admins and controllers without two factor
+// authentication in exercises that require it. A passkey or security key
+// counts as second factor.
+func checkExerciseMfa(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, permissions *sitrep.ExercisePermissionsLevel) error {
+	if user.TotpEnabled || !(permissions.IsAdmin || permissions.IsOc) {
+		return nil
+	}
+	settings, err := FindOrInitSettingsForExercise(cassandra, permissions.ExerciseIdentifier)
+	if err != nil {
+		return err
+	}
+	if settings[ExerciseSettingRequireMfa] != "true" {
+		return nil
+	}
+	hasKeys, err := hasWebAuthnCredentials(cassandra, user.Email)
+	if err != nil {
+		return err
+	}
+	if !hasKeys {
+		return NewMfaEnrollmentRequiredError()
+	}
+	return nil
+}
+
+// MfaRequiredError is returned, when a sign in needs a second step. The
+// MfaToken has to be sent back together with a code.
+type MfaRequiredError struct {
+	Message  string
+	MfaToken string
+}
+
+// Error prints the MfaRequiredError
+func (m *MfaRequiredError) Error() string {
+	return m.Message
+}
+
+// NewMfaRequiredError produces a new MfaRequiredError
+func NewMfaRequiredError(mfaToken string) *MfaRequiredError {
+	return &MfaRequiredError{
+		Message:  "Please enter the code of your authenticator app!",
+		MfaToken: mfaToken,
+	}
+}
+
+// MfaInvalidError is returned, when a code of the second factor is wrong
+type MfaInvalidError struct {
+	Message string
+}
+
+// Error prints the MfaInvalidError
+func (m *MfaInvalidError) Error() string {
+	return m.Message
+}
+
+// NewMfaInvalidError produces a new MfaInvalidError
+func NewMfaInvalidError() *MfaInvalidError {
+	return &MfaInvalidError{
+		Message: "The code is invalid or has expired!",
+	}
+}
+
+// MfaAlreadyEnabledError is returned, when two factor authentication is enrolled twice
+type MfaAlreadyEnabledError struct {
+	Message string
+}
+
+// Error prints the MfaAlreadyEnabledError
+func (m *MfaAlreadyEnabledError) Error() string {
+	return m.Message
+}
+
+// NewMfaAlreadyEnabledError produces a new MfaAlreadyEnabledError
+func NewMfaAlreadyEnabledError() *MfaAlreadyEnabledError {
+	return &MfaAlreadyEnabledError{
+		Message: "Two factor authentication is already enabled!",
+	}
+}
+
+// MfaEnrollmentRequiredError is returned, when an exercise requires two factor authentication
+type MfaEnrollmentRequiredError struct {
+	Message string
+}
+
+// Error prints the MfaEnrollmentRequiredError
+func (m *MfaEnrollmentRequiredError) Error() string {
+	return m.Message
+}
+
+// NewMfaEnrollmentRequiredError produces a new MfaEnrollmentRequiredError
+func NewMfaEnrollmentRequiredError() *MfaEnrollmentRequiredError {
+	return &MfaEnrollmentRequiredError{
+		Message: "This exercise requires two factor authentication, please enable it first!",
+	}
+}
@@ -0,0 +1,117 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/totp"
+)
+
+func TestMfa_SignIn(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	opts := models.NewOptions()
+
+	enrollment, err := models.EnrollTotp(c, user)
+	if err != nil {
+		t.Fatalf("Enrollment failed unexpectedly: %v", err)
+	}
+	// Codes can not be used twice, so enrollment and sign in use different periods
+	now := time.Now()
+	code, _ := totp.Code(enrollment.Secret, totp.Step(now)-1)
+	if _, err := models.ConfirmTotp(c, user, code, nil); err != nil {
+		t.Fatalf("Confirmation failed unexpectedly: %v", err)
+	}
+
+	_, err = models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	mfa, ok := err.(*models.MfaRequiredError)
+	if !ok {
+		t.Fatalf("Sign in did not ask for a second factor: %v", err)
+	}
+	if _, err := models.MfaSignIn(c, opts, nil, mfa.MfaToken, code); err == nil {
+		t.Fatalf("A used code was accepted again")
+	}
+
+	_, err = models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	mfa = err.(*models.MfaRequiredError)
+	code, _ = totp.Code(enrollment.Secret, totp.Step(now))
+	tokens, err := models.MfaSignIn(c, opts, nil, mfa.MfaToken, code)
+	if err != nil {
+		t.Fatalf("Second step failed unexpectedly: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, tokens.AccessToken); err != nil {
+		t.Fatalf("Access token of the second step is invalid")
+	}
+	if _, err := models.MfaSignIn(c, opts, nil, mfa.MfaToken, code); err == nil {
+		t.Fatalf("A challenge was completed twice")
+	}
+}
+
+func TestMfa_RequiredByExercise(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	exercise := mockExercise()
+	initExercise(exercise)
+	addUserPermissionToExercise(user, exercise, false, true, false)
+	c := dbConn()
+	if _, err := models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingRequireMfa: "true"}); err != nil {
+		t.Fatalf("Settings update failed")
+	}
+	defer models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingRequireMfa: "false"})
+
+	if _, err := models.IssueExerciseToken(c, models.NewOptions(), &models.Principal{User: user}, exercise); err == nil {
+		t.Fatalf("Controller without two factor authentication was let into the exercise")
+	}
+	if err := models.CheckExerciseMfa(c, user, exercise); err == nil {
+		t.Fatalf("Controller without two factor authentication passed the check")
+	} else if _, ok := err.(*models.MfaEnrollmentRequiredError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	req, err := models.UserSignIn(c, models.NewOptions(), nil, user.Email, "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	if _, err := models.AuthorizeExerciseRequest(c, models.NewOptions(), req.AccessToken, exercise.Id.String()); err == nil {
+		t.Fatalf("Controller without two factor authentication could change exercise settings")
+	}
+}
+
+func TestMfa_RecoveryCode(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	opts := models.NewOptions()
+	enrollment, err := models.EnrollTotp(c, user)
+	if err != nil {
+		t.Fatalf("Enrollment failed unexpectedly: %v", err)
+	}
+	code, _ := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+	codes, err := models.ConfirmTotp(c, user, code, nil)
+	if err != nil {
+		t.Fatalf("Confirmation failed unexpectedly: %v", err)
+	}
+	if len(codes) != models.RecoveryCodeCount {
+		t.Fatalf("unexpected number of recovery codes: %d", len(codes))
+	}
+
+	_, err = models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	mfa := err.(*models.MfaRequiredError)
+	if _, err := models.MfaSignIn(c, opts, nil, mfa.MfaToken, codes[0]); err != nil {
+		t.Fatalf("Recovery code was refused: %v", err)
+	}
+	_, err = models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	mfa = err.(*models.MfaRequiredError)
+	if _, err := models.MfaSignIn(c, opts, nil, mfa.MfaToken, codes[0]); err == nil {
+		t.Fatalf("Recovery code was accepted twice")
+	}
+
+	events, err := models.FindAuditEvents(c, user.Email)
+	if err != nil {
+		t.Fatalf("Audit log could not be read: %v", err)
+	}
+	if len(events) == 0 || events[0].Event != models.AuditRecoveryCodeUsed {
+		t.Fatalf("Use of the recovery code was not logged")
+	}
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// OauthClientsTable is a reference to the registered service clients table
+var OauthClientsTable = sitrep.OauthClientsTableDef()
+
+// RegisterClient registers a new service client. Name, scopes, redirect URIs
+// and whether the client is public have to be set by the caller. The
+// returned secret is only known to the caller, the database keeps a hash of it.
+func RegisterClient(cassandra *gocql.ClusterConfig, client *sitrep.OauthClients) (string, error) {
+	id, err := gocql.RandomUUID()
+	if err != nil {
+		return "", err
+	}
+	secret, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	client.ClientId = id.String()
+	client.CreatedAt = time.Now()
+	if err := client.HashSecret(secret); err != nil {
+		return "", err
+	}
+	if client.JwtEncryptionKey, err = sitrep.NewOpaqueToken(); err != nil {
+		return "", err
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(OauthClientsTable.Bind(*client)).Exec(session); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// ClientSignIn issues an access token to a service client for the requested
+// scopes. Without requested scopes, the token carries every allowed scope.
+func ClientSignIn(cassandra *gocql.ClusterConfig, opts *Options, clientID string, secret string, scope string) (*sitrep.JWTResponse, error) {
+	client, err := AuthenticateClient(cassandra, clientID, secret)
+	if err != nil {
+		return nil, err
+	}
+	allowed := parseScopes(client.AllowedScopes)
+	requested := parseScopes(scope)
+	if len(requested) == 0 {
+		requested = allowed
+	}
+	if len(requested) == 0 {
+		return nil, NewInvalidScopeError()
+	}
+	for _, s := range requested {
+		if !containsScope(allowed, s) {
+			return nil, NewInvalidScopeError()
+		}
+	}
+
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	// Clients registered before tokens were bound to a client key get one now
+	if client.JwtEncryptionKey == "" {
+		if client.JwtEncryptionKey, err = sitrep.NewOpaqueToken(); err != nil {
+			return nil, err
+		}
+		if err := ctx.Upsert(OauthClientsTable).
+			SetString(OauthClientsTable.JWT_ENCRYPTION_KEY, client.JwtEncryptionKey).
+			Where(
+			OauthClientsTable.CLIENT_ID.Eq(client.ClientId)).
+			Exec(session); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := opts.signingKey(client.JwtEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	jwtToken, err := sitrep.NewJwtResponse(key, map[string]interface{}{
+		"sub":            client.ClientId,
+		"scope":          strings.Join(requested, " "),
+		"principal_type": PrincipalService,
+	}, opts.AccessTokenLifetime)
+	if err != nil {
+		return nil, err
+	}
+	// Service tokens share the token table with users, so they can be
+	// verified and revoked the same way. The client takes the place of the user.
+	jwtClient := &sitrep.UsersByJwt{
+		EncryptionKey: client.JwtEncryptionKey,
+		Jwt:           jwtToken.AccessToken,
+		UserEmail:     client.ClientId,
+		UserName:      client.Name,
+	}
+	if err := ctx.Store(UsersJwtTable.Bind(*jwtClient)).Exec(session); err != nil {
+		return nil, err
+	}
+	return jwtToken, nil
+}
+
+// FindClientByID receives a service client from the database
+func FindClientByID(cassandra *gocql.ClusterConfig, clientID string) (*sitrep.OauthClients, error) {
+	var client sitrep.OauthClients
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(OauthClientsTable).
+		Where(
+		OauthClientsTable.CLIENT_ID.Eq(clientID)).
+		Into(
+		OauthClientsTable.To(&client)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewClientInvalidError()
+	}
+	return &client, nil
+}
+
+// AuthenticateClient verifies the credentials of a service client
+func AuthenticateClient(cassandra *gocql.ClusterConfig, clientID string, secret string) (*sitrep.OauthClients, error) {
+	client, err := FindClientByID(cassandra, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ValidateSecret(secret); err != nil {
+		return nil, NewClientInvalidError()
+	}
+	return client, nil
+}
+
+// ClientInvalidError is returned, when a service client could not be authenticated
+type ClientInvalidError struct {
+	Message string
+}
+
+// Error prints the ClientInvalidError
+func (c *ClientInvalidError) Error() string {
+	return c.Message
+}
+
+// NewClientInvalidError produces a new ClientInvalidError
+func NewClientInvalidError() *ClientInvalidError {
+	return &ClientInvalidError{
+		Message: "Client authentication failed!",
+	}
+}
+
+// InvalidScopeError is returned, when a client requests a scope it is not allowed to
+type InvalidScopeError struct {
+	Message string
+}
+
+// Error prints the InvalidScopeError
+func (i *InvalidScopeError) Error() string {
+	return i.Message
+}
+
+// NewInvalidScopeError produces a new InvalidScopeError
+func NewInvalidScopeError() *InvalidScopeError {
+	return &InvalidScopeError{
+		Message: "The requested scope is not allowed for this client!",
+	}
+}
@@ -0,0 +1,121 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+func TestClient_Authenticate(t *testing.T) {
+	c := dbConn()
+	client := &sitrep.OauthClients{Name: "news station", AllowedScopes: "introspect"}
+	secret, err := models.RegisterClient(c, client)
+	if err != nil {
+		t.Fatalf("Client registration failed unexpectedly: %v", err)
+	}
+	if _, err := models.AuthenticateClient(c, client.ClientId, secret); err != nil {
+		t.Fatalf("Client authentication failed unexpectedly: %v", err)
+	}
+	if _, err := models.AuthenticateClient(c, client.ClientId, "wrong"); err == nil {
+		t.Fatalf("Client was authenticated with a wrong secret")
+	}
+	if _, err := models.AuthenticateClient(c, "unknown", secret); err == nil {
+		t.Fatalf("Unknown client was authenticated")
+	}
+}
+
+var introspector = &sitrep.OauthClients{Name: "news station", AllowedScopes: models.ScopeIntrospect}
+
+func TestClient_IntrospectToken(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	result, err := models.IntrospectToken(c, opts, introspector, req.AccessToken)
+	if err != nil {
+		t.Fatalf("Introspection failed unexpectedly: %v", err)
+	}
+	if !result.Active || result.Subject != "someguy@somedomain.com" || result.ExpiresAt == 0 {
+		t.Fatalf("Unexpected introspection result: %+v", result)
+	}
+
+	result, err = models.IntrospectToken(c, opts, introspector, "1234")
+	if err != nil {
+		t.Fatalf("Introspection failed unexpectedly: %v", err)
+	}
+	if result.Active {
+		t.Fatalf("Unknown token was reported as active")
+	}
+
+	other := &sitrep.OauthClients{Name: "twitter simulator", AllowedScopes: "users:read"}
+	if _, err := models.IntrospectToken(c, opts, other, req.AccessToken); err == nil {
+		t.Fatalf("Client without the introspect scope could introspect tokens")
+	} else if _, ok := err.(*models.InvalidScopeError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestClient_IntrospectToken_Banned(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	user := mockUser()
+	user.IsBanned = true
+	initUser(user)
+
+	result, err := models.IntrospectToken(c, opts, introspector, req.AccessToken)
+	if err != nil {
+		t.Fatalf("Introspection failed unexpectedly: %v", err)
+	}
+	if result.Active || !result.IsBanned {
+		t.Fatalf("Banned user was not reported: %+v", result)
+	}
+}
+
+func TestClient_SignIn(t *testing.T) {
+	c := dbConn()
+	opts := models.NewOptions()
+	client := &sitrep.OauthClients{Name: "twitter simulator", AllowedScopes: "introspect users:read"}
+	secret, err := models.RegisterClient(c, client)
+	if err != nil {
+		t.Fatalf("Client registration failed unexpectedly: %v", err)
+	}
+	req, err := models.ClientSignIn(c, opts, client.ClientId, secret, "users:read")
+	if err != nil {
+		t.Fatalf("Client sign in failed unexpectedly: %v", err)
+	}
+	if req.Scope != "users:read" || req.RefreshToken != "" {
+		t.Fatalf("Unexpected token response: %+v", req)
+	}
+
+	principal, err := models.VerifyRequest(c, opts, req.AccessToken)
+	if err != nil {
+		t.Fatalf("Client token verification failed: %v", err)
+	}
+	if !principal.IsService() || principal.Subject != client.ClientId || !principal.HasScope("users:read") || principal.HasScope("introspect") {
+		t.Fatalf("Unexpected principal: %+v", principal)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, req.AccessToken); err == nil {
+		t.Fatalf("Client token was accepted as a user token")
+	}
+}
+
+func TestClient_SignIn_ScopeNotAllowed(t *testing.T) {
+	c := dbConn()
+	client := &sitrep.OauthClients{Name: "arcgis proxy", AllowedScopes: "introspect"}
+	secret, err := models.RegisterClient(c, client)
+	if err != nil {
+		t.Fatalf("Client registration failed unexpectedly: %v", err)
+	}
+	if _, err := models.ClientSignIn(c, models.NewOptions(), client.ClientId, secret, "users:write"); err == nil {
+		t.Fatalf("Client was granted a scope it is not allowed to")
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/utils"
+)
+
+// ScopeOpenID asks the authorization code flow to issue an ID token as well
+const ScopeOpenID = "openid"
+
+// TokenUseID marks ID tokens, so they are never accepted as access tokens
+const TokenUseID = "id"
+
+// NewIDToken signs an OpenID Connect ID token, which tells the client with
+// clientID who signed in. The nonce of the authorization request is echoed
+// back, so the client can bind the token to its request.
+//
+// Clients can only verify ID tokens that are signed by an asymmetric key of
+// the key ring, so without one no ID token is issued.
+func NewIDToken(opts *Options, user *sitrep.UsersByEmail, clientID string, nonce string) (string, error) {
+	if !opts.OpenIDEnabled() {
+		return "", NewOpenIDDisabledError()
+	}
+	now := time.Now()
+	claims := utils.MapUserClaims(user)
+	claims["token_use"] = TokenUseID
+	claims["iss"] = opts.Issuer
+	claims["aud"] = clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(capTokenLifetime(user, opts.AccessTokenLifetime)).Unix()
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	// The key ring is configured, so no secret is needed
+	key, err := opts.signingKey("")
+	if err != nil {
+		return "", err
+	}
+	return key.Sign(claims)
+}
+
+// OpenIDEnabled reports whether ID tokens can be issued, which takes an
+// active key in the key ring
+func (o *Options) OpenIDEnabled() bool {
+	return o.Keys != nil && o.Keys.Active() != nil
+}
+
+// IDTokenSigningAlgorithm returns the algorithm ID tokens are currently signed with
+func (o *Options) IDTokenSigningAlgorithm() string {
+	if !o.OpenIDEnabled() {
+		return ""
+	}
+	return o.Keys.Active().Method.Alg()
+}
+
+// OpenIDDisabledError is returned, when an ID token is requested without a
+// key ring to sign it
+type OpenIDDisabledError struct {
+	Message string
+}
+
+// Error prints the OpenIDDisabledError
+func (o *OpenIDDisabledError) Error() string {
+	return o.Message
+}
+
+// NewOpenIDDisabledError produces a new OpenIDDisabledError
+func NewOpenIDDisabledError() *OpenIDDisabledError {
+	return &OpenIDDisabledError{
+		Message: "OpenID Connect is disabled, no signing keys are configured!",
+	}
+}
@@ -0,0 +1,259 @@
+package models
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+	"github.com/fkasper/sitrep-authentication/passwords"
+	"github.com/fkasper/sitrep-authentication/signing"
+	"github.com/fkasper/sitrep-authentication/webauthn"
+)
+
+const (
+	// DefaultAccessTokenLifetime defines how long an access token is valid
+	DefaultAccessTokenLifetime = 15 * time.Minute
+
+	// DefaultRefreshTokenLifetime defines how long a refresh token can be
+	// exchanged for a new access token
+	DefaultRefreshTokenLifetime = 14 * 24 * time.Hour
+
+	// DefaultExerciseTokenLifetime defines how long an exercise token is
+	// valid. Exercise tokens outlive signing out of the session they were
+	// issued from, so it is kept short.
+	DefaultExerciseTokenLifetime = 5 * time.Minute
+
+	// DefaultMfaChallengeLifetime defines how long the second step of a sign
+	// in can be completed
+	DefaultMfaChallengeLifetime = 5 * time.Minute
+
+	// DefaultAuthorizationCodeLifetime defines how long an authorization code
+	// can be exchanged for tokens
+	DefaultAuthorizationCodeLifetime = time.Minute
+
+	// DefaultPasswordResetLifetime defines how long an emailed password reset
+	// token can be redeemed
+	DefaultPasswordResetLifetime = time.Hour
+
+	// DefaultEmailConfirmationLifetime defines how long an emailed
+	// confirmation link is valid
+	DefaultEmailConfirmationLifetime = 48 * time.Hour
+
+	// DefaultInvitationLifetime defines how long an invitation into an
+	// exercise can be accepted
+	DefaultInvitationLifetime = 7 * 24 * time.Hour
+
+	// DefaultMagicLinkLifetime defines how long an emailed sign in link is
+	// valid
+	DefaultMagicLinkLifetime = 10 * time.Minute
+
+	// DefaultLoginFailureWindow defines how long failed sign in attempts are
+	// counted
+	DefaultLoginFailureWindow = 15 * time.Minute
+
+	// DefaultLoginMaxFailures defines how many failed sign in attempts within
+	// the failure window lock an account on every client. It is well above
+	// the limit per account and address, so a single client can not lock
+	// somebody else out.
+	DefaultLoginMaxFailures = 20
+
+	// DefaultLoginMaxFailuresPerPair defines how many failed sign in attempts
+	// at one account from one client address within the failure window lock
+	// that address out of the account
+	DefaultLoginMaxFailuresPerPair = 5
+
+	// DefaultLoginMaxFailuresPerAddr defines how many failed sign in attempts
+	// within the failure window lock out a client address. Clients behind a
+	// shared proxy count as one.
+	DefaultLoginMaxFailuresPerAddr = 50
+
+	// DefaultLoginLockout defines how long locked accounts and clients have
+	// to wait
+	DefaultLoginLockout = 15 * time.Minute
+
+	// DefaultLoginDelay defines how long an account has to wait after its
+	// second failed sign in attempt. The delay doubles with every further
+	// failure.
+	DefaultLoginDelay = time.Second
+
+	// DefaultLoginHistoryRetention defines how long the sign in attempts of
+	// a user are kept
+	DefaultLoginHistoryRetention = 90 * 24 * time.Hour
+
+	// DefaultRateLimitWindow defines how long unauthenticated requests, which
+	// create accounts or send mail, are counted
+	DefaultRateLimitWindow = time.Hour
+
+	// DefaultRateLimitPerAddr defines how many of these requests a client
+	// address can make within the window
+	DefaultRateLimitPerAddr = 20
+
+	// DefaultRateLimitPerEmail defines how many of these requests can name
+	// the same email address within the window
+	DefaultRateLimitPerEmail = 5
+)
+
+// Options holds the service wide settings used while signing users in and
+// issuing tokens
+type Options struct {
+	AccessTokenLifetime       time.Duration
+	RefreshTokenLifetime      time.Duration
+	ExerciseTokenLifetime     time.Duration
+	AuthorizationCodeLifetime time.Duration
+	MfaChallengeLifetime      time.Duration
+	PasswordResetLifetime     time.Duration
+	EmailConfirmationLifetime time.Duration
+	InvitationLifetime        time.Duration
+	MagicLinkLifetime         time.Duration
+
+	// ExerciseTokenUserCheck loads the user of every exercise token signed
+	// by the key ring, so tokens of banned users and tokens issued before
+	// the encryption key was rotated are refused before they expire
+	ExerciseTokenUserCheck bool
+
+	// Failed sign in attempts are limited per account, per client address
+	// and per account and client address, a limit of 0 disables the lockout
+	LoginFailureWindow      time.Duration
+	LoginMaxFailures        int
+	LoginMaxFailuresPerAddr int
+	LoginMaxFailuresPerPair int
+	LoginLockout            time.Duration
+	LoginDelay              time.Duration
+
+	// LoginHistoryRetention is how long sign in attempts are kept in the
+	// login history of a user, 0 keeps them forever
+	LoginHistoryRetention time.Duration
+
+	// Unauthenticated requests, which create accounts or send mail, are
+	// limited per client address and per email address, a limit of 0
+	// disables it
+	RateLimitWindow   time.Duration
+	RateLimitPerAddr  int
+	RateLimitPerEmail int
+
+	// Issuer is the public base URL of this service. It is the iss claim of
+	// ID tokens and the prefix of the endpoints published in the OpenID
+	// Connect discovery document.
+	Issuer string
+
+	// RelyingParty identifies this service towards WebAuthn authenticators.
+	// Without one, it is derived from the Issuer.
+	RelyingParty *webauthn.RelyingParty
+
+	// PasswordResetURL is the page of the web app, which lets users choose a
+	// new password. Password reset tokens are appended as token parameter.
+	PasswordResetURL string
+
+	// EmailConfirmationURL is the page of the web app, which confirms email
+	// addresses. Confirmation tokens are appended as token parameter.
+	EmailConfirmationURL string
+
+	// InvitationURL is the page of the web app, which accepts invitations
+	// into exercises. The exercise and the invitation token are appended as
+	// exercise_id and token parameters.
+	InvitationURL string
+
+	// MagicLinkURL is the page of the web app, which signs users in with an
+	// emailed link. Sign in tokens are appended as token parameter.
+	MagicLinkURL string
+
+	// Registration is one of RegistrationOpen, RegistrationRestricted and
+	// RegistrationDisabled
+	Registration string
+
+	// RequireConfirmedEmail refuses to sign in users, whose email address
+	// has not been confirmed
+	RequireConfirmedEmail bool
+
+	// Mailer delivers password reset, confirmation and sign in links and
+	// invitations into exercises. Without one, no mail is sent.
+	Mailer mailer.Sender
+
+	// PasswordPolicy decides which passwords users can choose. Without one,
+	// the default policy applies.
+	PasswordPolicy *passwords.Policy
+
+	// PasswordHasher hashes new passwords. Without one, they are hashed with
+	// bcrypt at its default cost.
+	PasswordHasher *passwords.Hasher
+
+	// Keys signs the issued tokens. Without keys, tokens are signed with the
+	// secret of each user.
+	Keys *signing.KeyRing
+}
+
+// NewOptions returns Options with default settings.
+func NewOptions() *Options {
+	return &Options{
+		AccessTokenLifetime:       DefaultAccessTokenLifetime,
+		RefreshTokenLifetime:      DefaultRefreshTokenLifetime,
+		ExerciseTokenLifetime:     DefaultExerciseTokenLifetime,
+		AuthorizationCodeLifetime: DefaultAuthorizationCodeLifetime,
+		MfaChallengeLifetime:      DefaultMfaChallengeLifetime,
+		PasswordResetLifetime:     DefaultPasswordResetLifetime,
+		EmailConfirmationLifetime: DefaultEmailConfirmationLifetime,
+		InvitationLifetime:        DefaultInvitationLifetime,
+		MagicLinkLifetime:         DefaultMagicLinkLifetime,
+		LoginFailureWindow:        DefaultLoginFailureWindow,
+		LoginMaxFailures:          DefaultLoginMaxFailures,
+		LoginMaxFailuresPerAddr:   DefaultLoginMaxFailuresPerAddr,
+		LoginMaxFailuresPerPair:   DefaultLoginMaxFailuresPerPair,
+		LoginLockout:              DefaultLoginLockout,
+		LoginDelay:                DefaultLoginDelay,
+		LoginHistoryRetention:     DefaultLoginHistoryRetention,
+		RateLimitWindow:           DefaultRateLimitWindow,
+		RateLimitPerAddr:          DefaultRateLimitPerAddr,
+		RateLimitPerEmail:         DefaultRateLimitPerEmail,
+		Registration:              RegistrationRestricted,
+	}
+}
+
+// passwordPolicy returns the policy new passwords are checked against
+func (o *Options) passwordPolicy() *passwords.Policy {
+	if o.PasswordPolicy != nil {
+		return o.PasswordPolicy
+	}
+	// Without a breached hashes file, the default policy can not fail to load
+	policy, _ := passwords.NewPolicy(nil)
+	return policy
+}
+
+// passwordHasher returns the hasher new passwords are hashed with
+func (o *Options) passwordHasher() *passwords.Hasher {
+	if o.PasswordHasher != nil {
+		return o.PasswordHasher
+	}
+	// The default settings are valid, so they can not fail to load
+	hasher, _ := passwords.NewHasher(nil)
+	return hasher
+}
+
+// signingKey returns the key tokens are signed with. Without a key ring,
+// tokens are signed with the secret of their principal. A key ring without an
+// active key is an error, verifiers only know the keys of the ring.
+func (o *Options) signingKey(secret string) (*signing.Key, error) {
+	if o.Keys == nil {
+		return signing.NewHMACKey(secret), nil
+	}
+	if key := o.Keys.Active(); key != nil {
+		return key, nil
+	}
+	return nil, signing.ErrNoActiveKey
+}
+
+// relyingParty returns the WebAuthn relying party. Without a configured
+// one, credentials are scoped to the host of the issuer.
+func (o *Options) relyingParty() *webauthn.RelyingParty {
+	if o.RelyingParty != nil {
+		return o.RelyingParty
+	}
+	rp := &webauthn.RelyingParty{Name: RelyingPartyName, Origins: []string{o.Issuer}}
+	if u, err := url.Parse(o.Issuer); err == nil {
+		rp.ID = u.Host
+		if host, _, err := net.SplitHostPort(u.Host); err == nil {
+			rp.ID = host
+		}
+	}
+	return rp
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// PasswordHistoryTable is a reference to the previous passwords of users
+var PasswordHistoryTable = sitrep.PasswordHistoryTableDef()
+
+// checkNewPassword returns a ValidationError for field, if password breaks
+// the password policy. Users can not choose any of their recent passwords
+// again, the current one included.
+func checkNewPassword(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, field string, password string) error {
+	policy := opts.passwordPolicy()
+	if err := policy.Check(password); err != nil {
+		return NewValidationError(map[string]string{field: err.Error()})
+	}
+	if user == nil || policy.HistorySize <= 0 {
+		return nil
+	}
+	reused := NewValidationError(map[string]string{field: "has been used recently, please choose another one"})
+	if user.ValidatePassword(password) == nil {
+		return reused
+	}
+	history, err := findPasswordHistory(cassandra, user.Email, policy.HistorySize-1)
+	if err != nil {
+		return err
+	}
+	for _, previous := range history {
+		hashed := &sitrep.UsersByEmail{EncryptedPassword: previous.EncryptedPassword}
+		if hashed.ValidatePassword(password) == nil {
+			return reused
+		}
+	}
+	return nil
+}
+
+// upgradePasswordHash hashes password again, if the stored hash of user has
+// not been created with the current algorithm and parameters. A failed
+// upgrade does not keep the user from signing in, it is tried again the next
+// time.
+func upgradePasswordHash(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, password string) {
+	hasher := opts.passwordHasher()
+	if !hasher.NeedsRehash(user.EncryptedPassword) {
+		return
+	}
+	hashed, err := hasher.Hash(password)
+	if err != nil {
+		return
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetString(UsersTable.ENCRYPTED_PASSWORD, hashed).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return
+	}
+	user.EncryptedPassword = hashed
+}
+
+// recordPasswordHistory keeps the current password hash of user, before it
+// is replaced
+func recordPasswordHistory(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) error {
+	if user.EncryptedPassword == "" {
+		return nil
+	}
+	previous := &sitrep.PasswordHistory{
+		UserEmail:         user.Email,
+		ChangedAt:         gocql.TimeUUID(),
+		EncryptedPassword: user.EncryptedPassword,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	return ctx.Store(PasswordHistoryTable.Bind(*previous)).Exec(session)
+}
+
+// findPasswordHistory returns up to limit previous password hashes of the
+// user with email, the latest first
+func findPasswordHistory(cassandra *gocql.ClusterConfig, email string, limit int) ([]sitrep.PasswordHistory, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(PasswordHistoryTable).
+		Where(
+		PasswordHistoryTable.USER_EMAIL.Eq(email)).
+		Limit(limit).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	return sitrep.BindPasswordHistory(iter)
+}
@@ -0,0 +1,99 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/mailer/mailertest"
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/passwords"
+)
+
+func TestPasswordPolicy_Change(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	user, err := models.FindUserByEmail(c, "someguy@somedomain.com")
+	if err != nil {
+		t.Fatalf("User could not be found: %v", err)
+	}
+
+	_, err = models.UserChangePassword(c, opts, user, "test1234", "password")
+	if invalid, ok := err.(*models.ValidationError); !ok || invalid.Fields["new_password"] == "" {
+		t.Fatalf("Weak password was not refused: %v", err)
+	}
+	if _, err := models.UserChangePassword(c, opts, user, "test1234", "test1234"); err == nil {
+		t.Fatalf("Current password was chosen again")
+	}
+	if _, err := models.UserChangePassword(c, opts, user, "test1234", "second-password"); err != nil {
+		t.Fatalf("Password could not be changed: %v", err)
+	}
+	if _, err := models.UserChangePassword(c, opts, user, "second-password", "third-password"); err != nil {
+		t.Fatalf("Password could not be changed: %v", err)
+	}
+	if _, err := models.UserChangePassword(c, opts, user, "third-password", "test1234"); err == nil {
+		t.Fatalf("Recent password was chosen again")
+	}
+}
+
+func TestPasswordPolicy_Reset(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	opts.PasswordResetURL = "https://sitrep-vatcinc.com/password-reset"
+
+	if err := models.RequestPasswordReset(c, opts, user.Email); err != nil {
+		t.Fatalf("Password reset could not be requested: %v", err)
+	}
+	token := mailedToken(t, sender, user.Email, opts.PasswordResetURL)
+	if err := models.ResetPassword(c, opts, nil, token, "short"); err == nil {
+		t.Fatalf("Weak password was accepted")
+	} else if _, ok := err.(*models.ValidationError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := models.ResetPassword(c, opts, nil, token, "new-password"); err != nil {
+		t.Fatalf("Refused password used up the reset token: %v", err)
+	}
+}
+
+func TestPasswordPolicy_Registration(t *testing.T) {
+	opts := models.NewOptions()
+	opts.Registration = models.RegistrationOpen
+	r := mockRegistration()
+	r.Password = "password"
+	_, err := models.RegisterUser(dbConn(), opts, nil, r)
+	if invalid, ok := err.(*models.ValidationError); !ok || invalid.Fields["password"] == "" {
+		t.Fatalf("Weak password was not refused: %v", err)
+	}
+}
+
+func TestPasswordHash_Upgrade(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	config := passwords.NewConfig()
+	config.HashAlgorithm = passwords.AlgorithmArgon2id
+	config.Argon2Memory = 1024
+	config.Argon2Threads = 1
+	hasher, err := passwords.NewHasher(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := models.NewOptions()
+	opts.PasswordHasher = hasher
+
+	if _, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password"); err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	user, err := models.FindUserByEmail(c, "someguy@somedomain.com")
+	if err != nil {
+		t.Fatalf("User could not be found: %v", err)
+	}
+	if hasher.NeedsRehash(user.EncryptedPassword) {
+		t.Fatalf("Legacy hash was not upgraded: %s", user.EncryptedPassword)
+	}
+	if _, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password"); err != nil {
+		t.Fatalf("Sign in with the upgraded hash failed: %v", err)
+	}
+}
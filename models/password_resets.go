@@ -0,0 +1,209 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// PasswordResetsTable is a reference to the password reset tokens, that have not been redeemed
+var PasswordResetsTable = sitrep.PasswordResetsTableDef()
+
+// TokenUsePasswordReset marks tokens, which allow to set a new password
+const TokenUsePasswordReset = "password_reset"
+
+// RequestPasswordReset emails a password reset token to the user with
+// email. Unknown and banned users are silently ignored, so the caller can
+// not tell whether an account exists.
+func RequestPasswordReset(cassandra *gocql.ClusterConfig, opts *Options, email string) error {
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" || user.IsBanned {
+		return nil
+	}
+	if opts.Mailer == nil {
+		return NewMailerDisabledError()
+	}
+	token, err := newPasswordResetToken(cassandra, opts, user)
+	if err != nil {
+		return err
+	}
+	return opts.Mailer.Send(passwordResetMessage(opts, user, token))
+}
+
+// ResetPassword sets a new password with an emailed password reset token.
+// Every token can be redeemed once, afterwards all sessions of the user end.
+// A password, which breaks the password policy, leaves the token unused.
+func ResetPassword(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, token string, password string) error {
+	parsed, err := sitrep.ParseJwt(token, opts.Keys, func(claims map[string]interface{}) (string, error) {
+		if use, _ := claims["token_use"].(string); use != TokenUsePasswordReset {
+			return "", NewPasswordResetInvalidError()
+		}
+		subject, _ := claims["sub"].(string)
+		user, err := FindUserByEmail(cassandra, subject)
+		if err != nil {
+			return "", err
+		}
+		return user.JwtEncryptionKey, nil
+	})
+	if err != nil {
+		return NewPasswordResetInvalidError()
+	}
+	if use, _ := parsed.Claims["token_use"].(string); use != TokenUsePasswordReset {
+		return NewPasswordResetInvalidError()
+	}
+	subject, _ := parsed.Claims["sub"].(string)
+	user, err := FindUserByEmail(cassandra, subject)
+	if err != nil || user.Email == "" {
+		return NewPasswordResetInvalidError()
+	}
+	if user.IsBanned {
+		return NewUserInvalidError()
+	}
+	if err := checkNewPassword(cassandra, opts, user, "new_password", password); err != nil {
+		return err
+	}
+	tokenID, _ := parsed.Claims["jti"].(string)
+	email, err := consumePasswordReset(cassandra, tokenID)
+	if err != nil {
+		return err
+	}
+	if email != user.Email {
+		return NewPasswordResetInvalidError()
+	}
+	if err := setUserPassword(cassandra, opts, user, password); err != nil {
+		return err
+	}
+	return RecordAuditEvent(cassandra, user.Email, AuditPasswordReset, client, "")
+}
+
+// newPasswordResetToken signs a token for user. Its id is stored, so the
+// token can only be redeemed once.
+func newPasswordResetToken(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail) (string, error) {
+	key, err := opts.signingKey(user.JwtEncryptionKey)
+	if err != nil {
+		return "", err
+	}
+	tokenID, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(opts.PasswordResetLifetime)
+	reset := &sitrep.PasswordResets{
+		TokenId:   tokenID,
+		ExpiresAt: expiresAt,
+		UserEmail: user.Email,
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := insertWithTTL(session, "password_resets", map[string]interface{}{
+		"token_id":   reset.TokenId,
+		"expires_at": reset.ExpiresAt,
+		"user_email": reset.UserEmail,
+	}, opts.PasswordResetLifetime); err != nil {
+		return "", err
+	}
+	return key.Sign(map[string]interface{}{
+		"sub":       user.Email,
+		"jti":       tokenID,
+		"token_use": TokenUsePasswordReset,
+		"exp":       expiresAt.Unix(),
+	})
+}
+
+// consumePasswordReset redeems the token with tokenID and returns the email
+// of its user
+func consumePasswordReset(cassandra *gocql.ClusterConfig, tokenID string) (string, error) {
+	if tokenID == "" {
+		return "", NewPasswordResetInvalidError()
+	}
+	var reset sitrep.PasswordResets
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(PasswordResetsTable).
+		Where(
+		PasswordResetsTable.TOKEN_ID.Eq(tokenID)).
+		Into(
+		PasswordResetsTable.To(&reset)).
+		FetchOne(session)
+
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", NewPasswordResetInvalidError()
+	}
+
+	applied, err := consumeOnce(session, "password_resets", map[string]interface{}{
+		"token_id": reset.TokenId,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !applied || time.Now().After(reset.ExpiresAt) {
+		return "", NewPasswordResetInvalidError()
+	}
+	return reset.UserEmail, nil
+}
+
+func passwordResetMessage(opts *Options, user *sitrep.UsersByEmail, token string) *mailer.Message {
+	link := token
+	if opts.PasswordResetURL != "" {
+		link = opts.PasswordResetURL + "?" + url.Values{"token": {token}}.Encode()
+	}
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your SITREP password",
+		Body: fmt.Sprintf(`Hello %s,
+
+somebody asked to reset the password of your SITREP account. To choose a
+new password, please follow this link within %d minutes:
+
+%s
+
+If you did not ask for this, you can ignore this mail. Your password stays
+unchanged.
+`, user.RealName, int(opts.PasswordResetLifetime/time.Minute), link),
+	}
+}
+
+// PasswordResetInvalidError is returned, when a password reset token is wrong, expired or used
+type PasswordResetInvalidError struct {
+	Message string
+}
+
+// Error prints the PasswordResetInvalidError
+func (p *PasswordResetInvalidError) Error() string {
+	return p.Message
+}
+
+// NewPasswordResetInvalidError produces a new PasswordResetInvalidError
+func NewPasswordResetInvalidError() *PasswordResetInvalidError {
+	return &PasswordResetInvalidError{
+		Message: "This password reset link is invalid or has expired!",
+	}
+}
+
+// MailerDisabledError is returned, when mail has to be sent without a configured mailer
+type MailerDisabledError struct {
+	Message string
+}
+
+// Error prints the MailerDisabledError
+func (m *MailerDisabledError) Error() string {
+	return m.Message
+}
+
+// NewMailerDisabledError produces a new MailerDisabledError
+func NewMailerDisabledError() *MailerDisabledError {
+	return &MailerDisabledError{
+		Message: "No mail server has been configured!",
+	}
+}
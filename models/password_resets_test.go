@@ -0,0 +1,103 @@
+package models_test
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/mailer/mailertest"
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+// mailedToken extracts the token from a link to page in the last mail to email
+func mailedToken(t *testing.T, sender *mailertest.Sender, email string, page string) string {
+	messages := sender.Messages(email)
+	if len(messages) == 0 {
+		t.Fatalf("No mail was sent")
+	}
+	for _, line := range strings.Split(messages[len(messages)-1].Body, "\n") {
+		if strings.HasPrefix(line, page+"?") {
+			link, _ := url.Parse(line)
+			return link.Query().Get("token")
+		}
+	}
+	t.Fatalf("Mail has no link to %s", page)
+	return ""
+}
+
+func TestPasswordReset(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	opts.PasswordResetURL = "https://sitrep-vatcinc.com/password-reset"
+
+	if err := models.RequestPasswordReset(c, opts, user.Email); err != nil {
+		t.Fatalf("Password reset could not be requested: %v", err)
+	}
+	token := mailedToken(t, sender, user.Email, opts.PasswordResetURL)
+
+	if err := models.ResetPassword(c, opts, nil, token, "new-password"); err != nil {
+		t.Fatalf("Password reset failed unexpectedly: %v", err)
+	}
+	if _, err := models.AuthenticateUser(c, user.Email, "new-password"); err != nil {
+		t.Fatalf("New password is not accepted")
+	}
+	if _, err := models.AuthenticateUser(c, user.Email, "test1234"); err == nil {
+		t.Fatalf("Old password is still accepted")
+	}
+	if err := models.ResetPassword(c, opts, nil, token, "another-password"); err == nil {
+		t.Fatalf("A password reset token was redeemed twice")
+	}
+}
+
+func TestPasswordReset_UnknownUser(t *testing.T) {
+	sender := mailertest.NewSender()
+	opts := models.NewOptions()
+	opts.Mailer = sender
+	if err := models.RequestPasswordReset(dbConn(), opts, "nobody@somedomain.com"); err != nil {
+		t.Fatalf("Unknown users are revealed by an error: %v", err)
+	}
+	if sender.Len() != 0 {
+		t.Fatalf("Mail was sent to an unknown user")
+	}
+}
+
+func TestPasswordReset_AccessToken(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	opts := models.NewOptions()
+	tokens, err := models.UserSignIn(c, opts, nil, user.Email, "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	if err := models.ResetPassword(c, opts, nil, tokens.AccessToken, "new-password"); err == nil {
+		t.Fatalf("An access token was accepted as password reset token")
+	}
+}
+
+func TestPasswordReset_RateLimited(t *testing.T) {
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.RateLimitPerEmail = 2
+	email := fmt.Sprintf("reset-%d@somedomain.com", time.Now().UnixNano())
+
+	for i := 0; i < opts.RateLimitPerEmail; i++ {
+		if err := models.ThrottleRequest(c, opts, models.RateLimitPasswordReset, nil, email); err != nil {
+			t.Fatalf("Request was refused unexpectedly: %v", err)
+		}
+	}
+	if err := models.ThrottleRequest(c, opts, models.RateLimitPasswordReset, nil, email); err == nil {
+		t.Fatalf("Password resets could be requested without limit")
+	} else if _, ok := err.(*models.RateLimitedError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := models.ThrottleRequest(c, opts, models.RateLimitEmailConfirmation, nil, email); err != nil {
+		t.Fatalf("Limit of password resets applied to other requests: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+const (
+	// PrincipalUser marks tokens issued to users
+	PrincipalUser = "user"
+
+	// PrincipalService marks tokens issued to service clients
+	PrincipalService = "service"
+)
+
+// Principal is whoever an access token has been issued to. Exactly one of
+// User and Client is set, depending on Type.
+type Principal struct {
+	Type    string
+	Subject string
+	Scopes  []string
+	User    *sitrep.UsersByEmail
+	Client  *sitrep.OauthClients
+
+	// Actor is the user, who is acting as User with an impersonation token
+	Actor *sitrep.UsersByEmail
+
+	// ExerciseID limits the token to a single exercise. It is empty for
+	// tokens, which are valid in every exercise of the user.
+	ExerciseID string
+}
+
+// IsImpersonated reports whether somebody else is acting as the user
+func (p *Principal) IsImpersonated() bool {
+	return p.Actor != nil
+}
+
+// CheckExercise refuses exercises other than the one the token is limited to
+func (p *Principal) CheckExercise(exerciseID gocql.UUID) error {
+	if p.ExerciseID != "" && p.ExerciseID != exerciseID.String() {
+		return NewExerciseForbiddenError()
+	}
+	return nil
+}
+
+// IsService reports whether the principal is a service client
+func (p *Principal) IsService() bool {
+	return p.Type == PrincipalService
+}
+
+// HasScope reports whether the token of the principal has been granted scope
+func (p *Principal) HasScope(scope string) bool {
+	return containsScope(p.Scopes, scope)
+}
+
+// parseScopes splits a space separated scope list
+func parseScopes(scope string) []string {
+	return strings.Fields(scope)
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
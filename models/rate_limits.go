@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// RateLimitsTable is a reference to the recent requests of rate limited endpoints
+var RateLimitsTable = sitrep.RateLimitsTableDef()
+
+// Unauthenticated requests, which create accounts, send mail or store
+// pending ceremonies, are limited per action
+const (
+	RateLimitRegister          = "register"
+	RateLimitPasswordReset     = "password_reset"
+	RateLimitEmailConfirmation = "email_confirmation"
+	RateLimitMagicLink         = "magic_link"
+	RateLimitWebAuthnSignIn    = "webauthn_sign_in"
+)
+
+// ThrottleRequest counts a request of action and refuses it, once the client
+// address or the email address have made too many requests of action within
+// RateLimitWindow. Refused requests are not counted.
+func ThrottleRequest(cassandra *gocql.ClusterConfig, opts *Options, action string, client *ClientInfo, email string) error {
+	if opts.RateLimitWindow <= 0 {
+		return nil
+	}
+	if client == nil {
+		client = &ClientInfo{}
+	}
+	limits := map[string]int{}
+	if client.RemoteAddr != "" {
+		limits[action+":"+loginSubjectAddr(client.RemoteAddr)] = opts.RateLimitPerAddr
+	}
+	if email != "" {
+		limits[action+":"+loginSubjectEmail(email)] = opts.RateLimitPerEmail
+	}
+	now := time.Now()
+	for subject, limit := range limits {
+		if limit <= 0 {
+			delete(limits, subject)
+			continue
+		}
+		requests, err := findRecentRequests(cassandra, subject, limit)
+		if err != nil {
+			return err
+		}
+		if len(requests) < limit {
+			continue
+		}
+		// The oldest counted request has to leave the window first
+		retryAt := requests[0].RequestId.Time().Add(opts.RateLimitWindow)
+		if now.Before(retryAt) {
+			return NewRateLimitedError(retryAt.Sub(now))
+		}
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	for subject := range limits {
+		// Requests outside of the window are no longer counted
+		if err := insertWithTTL(session, "rate_limits", map[string]interface{}{
+			"subject":    subject,
+			"request_id": gocql.TimeUUID(),
+		}, opts.RateLimitWindow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findRecentRequests returns up to limit requests of subject within the
+// window, the oldest first
+func findRecentRequests(cassandra *gocql.ClusterConfig, subject string, limit int) ([]sitrep.RateLimits, error) {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(RateLimitsTable).
+		Where(
+		RateLimitsTable.SUBJECT.Eq(subject)).
+		Limit(limit).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	return sitrep.BindRateLimits(iter)
+}
+
+// RateLimitedError is returned, when a client or an email address has to
+// wait before the request can be repeated
+type RateLimitedError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+// Error prints the RateLimitedError
+func (r *RateLimitedError) Error() string {
+	return r.Message
+}
+
+// NewRateLimitedError produces a new RateLimitedError
+func NewRateLimitedError(retryAfter time.Duration) *RateLimitedError {
+	seconds := ttlSeconds(retryAfter)
+	return &RateLimitedError{
+		Message:    fmt.Sprintf("Too many requests, please try again in %d seconds!", seconds),
+		RetryAfter: time.Duration(seconds) * time.Second,
+	}
+}
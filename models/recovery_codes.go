@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/totp"
+	"github.com/gocql/gocql"
+)
+
+// RecoveryCodesTable is a reference to the recovery codes of users
+var RecoveryCodesTable = sitrep.RecoveryCodesTableDef()
+
+// RecoveryCodeCount is the number of recovery codes a user gets at once
+const RecoveryCodeCount = 10
+
+// RegenerateRecoveryCodes replaces every recovery code of user. A current
+// code of the authenticator app is required.
+func RegenerateRecoveryCodes(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, code string, client *ClientInfo) ([]string, error) {
+	if !user.TotpEnabled {
+		return nil, NewMfaInvalidError()
+	}
+	if err := validateTotp(cassandra, user, code); err != nil {
+		return nil, err
+	}
+	codes, err := generateRecoveryCodes(cassandra, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := RecordAuditEvent(cassandra, user.Email, AuditRecoveryCodesRegenerated, client, ""); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// generateRecoveryCodes stores new recovery codes for user, after deleting
+// the old ones. Only hashes are stored, the codes are shown to the user once.
+func generateRecoveryCodes(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) ([]string, error) {
+	if err := deleteRecoveryCodes(cassandra, user); err != nil {
+		return nil, err
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	now := time.Now()
+	codes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code, err := sitrep.NewRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		recoveryCode := &sitrep.RecoveryCodes{
+			UserEmail: user.Email,
+			CodeHash:  sitrep.HashRecoveryCode(code),
+			CreatedAt: now,
+		}
+		if err := ctx.Store(RecoveryCodesTable.Bind(*recoveryCode)).Exec(session); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+func deleteRecoveryCodes(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) error {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	return ctx.Delete().
+		From(RecoveryCodesTable).
+		Where(
+		RecoveryCodesTable.USER_EMAIL.Eq(user.Email)).
+		Exec(session)
+}
+
+// useRecoveryCode redeems a recovery code of user. Every use is recorded in
+// the audit log, as it means the authenticator app was not at hand.
+func useRecoveryCode(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, code string, client *ClientInfo) error {
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	applied, err := consumeOnce(session, "recovery_codes", map[string]interface{}{
+		"user_email": user.Email,
+		"code_hash":  sitrep.HashRecoveryCode(code),
+	})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return NewMfaInvalidError()
+	}
+	return RecordAuditEvent(cassandra, user.Email, AuditRecoveryCodeUsed, client, "")
+}
+
+// validateSecondFactor accepts either a code of the authenticator app or a
+// recovery code
+func validateSecondFactor(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, code string, client *ClientInfo) error {
+	if len(strings.TrimSpace(code)) == totp.Digits {
+		return validateTotp(cassandra, user, code)
+	}
+	return useRecoveryCode(cassandra, user, code, client)
+}
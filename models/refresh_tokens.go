@@ -0,0 +1,273 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// RefreshTokensTable is a reference to the refresh tokens table
+var RefreshTokensTable = sitrep.RefreshTokensTableDef()
+
+// RefreshTokenFamiliesTable is a reference to the refresh token families table
+var RefreshTokenFamiliesTable = sitrep.RefreshTokenFamiliesTableDef()
+
+// RefreshSignIn exchanges a refresh token for a new access and refresh token.
+// Every refresh token can only be used once. Presenting an already used token
+// revokes the whole family, as the token has most likely been stolen.
+func RefreshSignIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, refreshToken string) (*sitrep.JWTResponse, error) {
+	var token sitrep.RefreshTokens
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(RefreshTokensTable).
+		Where(
+		RefreshTokensTable.TOKEN_HASH.Eq(sitrep.HashOpaqueToken(refreshToken))).
+		Into(
+		RefreshTokensTable.To(&token)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewRefreshTokenInvalidError()
+	}
+
+	family, err := findTokenFamily(cassandra, token.UserEmail, token.FamilyId)
+	if err != nil || family.IsRevoked {
+		return nil, NewRefreshTokenInvalidError()
+	}
+	if token.IsUsed {
+		if err := revokeTokenFamily(cassandra, token.UserEmail, token.FamilyId); err != nil {
+			return nil, err
+		}
+		return nil, NewRefreshTokenInvalidError()
+	}
+	if token.IsExpired() {
+		return nil, NewRefreshTokenInvalidError()
+	}
+
+	// The token is marked with a lightweight transaction, so two concurrent
+	// requests can not both rotate it
+	var isUsed bool
+	applied, err := session.Query(`UPDATE refresh_tokens SET is_used = true WHERE token_hash = ? IF is_used = false`,
+		token.TokenHash).ScanCAS(&isUsed)
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		if err := revokeTokenFamily(cassandra, token.UserEmail, token.FamilyId); err != nil {
+			return nil, err
+		}
+		return nil, NewRefreshTokenInvalidError()
+	}
+
+	user, err := FindUserByEmail(cassandra, token.UserEmail)
+	if err != nil {
+		return nil, NewRefreshTokenInvalidError()
+	}
+	if user.IsBanned {
+		return nil, NewUserInvalidError()
+	}
+	if err := touchTokenFamily(cassandra, token.UserEmail, token.FamilyId, client); err != nil {
+		return nil, err
+	}
+	return issueTokens(cassandra, opts, user, token.FamilyId)
+}
+
+// issueTokens signs a new access token for user and pairs it with a refresh
+// token belonging to the given token family. Neither token outlives the
+// account of an expiring user.
+func issueTokens(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, familyID gocql.UUID) (*sitrep.JWTResponse, error) {
+	if err := checkAccountExpiry(user); err != nil {
+		return nil, err
+	}
+	key, err := opts.signingKey(user.JwtEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	jwtToken, err := sitrep.NewJwtResponse(key, map[string]interface{}{
+		"sub":            user.Email,
+		"principal_type": PrincipalUser,
+	}, capTokenLifetime(user, opts.AccessTokenLifetime))
+	if err != nil {
+		return nil, NewUserInvalidError()
+	}
+	refreshToken, err := sitrep.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	jwtUser := &sitrep.UsersByJwt{
+		EncryptionKey: user.JwtEncryptionKey,
+		FamilyId:      familyID,
+		Jwt:           jwtToken.AccessToken,
+		UserEmail:     user.Email,
+		UserName:      user.RealName,
+	}
+	jwtByEmail := &sitrep.JwtByUserEmail{
+		UserEmail: user.Email,
+		Jwt:       jwtToken.AccessToken,
+		FamilyId:  familyID,
+		IssuedAt:  now,
+	}
+	refresh := &sitrep.RefreshTokens{
+		TokenHash: sitrep.HashOpaqueToken(refreshToken),
+		ExpiresAt: now.Add(capTokenLifetime(user, opts.RefreshTokenLifetime)),
+		FamilyId:  familyID,
+		IssuedAt:  now,
+		UserEmail: user.Email,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(UsersJwtTable.Bind(*jwtUser)).Exec(session); err != nil {
+		return nil, err
+	}
+	if err := ctx.Store(JwtByUserEmailTable.Bind(*jwtByEmail)).Exec(session); err != nil {
+		return nil, err
+	}
+	if err := ctx.Store(RefreshTokensTable.Bind(*refresh)).Exec(session); err != nil {
+		return nil, err
+	}
+	// The session ends with its latest refresh token
+	if err := ctx.Upsert(RefreshTokenFamiliesTable).
+		SetTimestamp(RefreshTokenFamiliesTable.EXPIRES_AT, refresh.ExpiresAt).
+		Where(
+		RefreshTokenFamiliesTable.USER_EMAIL.Eq(user.Email),
+		RefreshTokenFamiliesTable.FAMILY_ID.Eq(familyID)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+
+	jwtToken.RefreshToken = refreshToken
+	return jwtToken, nil
+}
+
+// startTokenFamily opens a new refresh token family. A family groups all
+// refresh tokens that were rotated from a single sign in, which makes it the
+// session of one device.
+func startTokenFamily(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, client *ClientInfo) (gocql.UUID, error) {
+	familyID, err := gocql.RandomUUID()
+	if err != nil {
+		return familyID, err
+	}
+	if client == nil {
+		client = &ClientInfo{}
+	}
+	now := time.Now()
+	family := &sitrep.RefreshTokenFamilies{
+		UserEmail:  user.Email,
+		FamilyId:   familyID,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		RemoteAddr: client.RemoteAddr,
+		UserAgent:  client.UserAgent,
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Store(RefreshTokenFamiliesTable.Bind(*family)).Exec(session); err != nil {
+		return familyID, err
+	}
+	return familyID, nil
+}
+
+// touchTokenFamilyInterval is how often using an access token updates when
+// its session has last been seen. Every request would be a write otherwise.
+const touchTokenFamilyInterval = time.Minute
+
+func findTokenFamily(cassandra *gocql.ClusterConfig, email string, familyID gocql.UUID) (*sitrep.RefreshTokenFamilies, error) {
+	var family sitrep.RefreshTokenFamilies
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(RefreshTokenFamiliesTable).
+		Where(
+		RefreshTokenFamiliesTable.USER_EMAIL.Eq(email),
+		RefreshTokenFamiliesTable.FAMILY_ID.Eq(familyID)).
+		Into(
+		RefreshTokenFamiliesTable.To(&family)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewRefreshTokenInvalidError()
+	}
+	return &family, nil
+}
+
+// touchTokenFamily records that a family has just been used. The client is
+// updated as well, as devices tend to change their address.
+func touchTokenFamily(cassandra *gocql.ClusterConfig, email string, familyID gocql.UUID, client *ClientInfo) error {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	upsert := ctx.Upsert(RefreshTokenFamiliesTable).
+		SetTimestamp(RefreshTokenFamiliesTable.LAST_SEEN_AT, time.Now())
+	if client != nil {
+		upsert = upsert.
+			SetString(RefreshTokenFamiliesTable.REMOTE_ADDR, client.RemoteAddr).
+			SetString(RefreshTokenFamiliesTable.USER_AGENT, client.UserAgent)
+	}
+	return upsert.
+		Where(
+		RefreshTokenFamiliesTable.USER_EMAIL.Eq(email),
+		RefreshTokenFamiliesTable.FAMILY_ID.Eq(familyID)).
+		Exec(session)
+}
+
+// revokeTokenFamily invalidates every refresh token of a family, together
+// with the access tokens that are still outstanding for it
+func revokeTokenFamily(cassandra *gocql.ClusterConfig, email string, familyID gocql.UUID) error {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(RefreshTokenFamiliesTable).
+		SetBoolean(RefreshTokenFamiliesTable.IS_REVOKED, true).
+		Where(
+		RefreshTokenFamiliesTable.USER_EMAIL.Eq(email),
+		RefreshTokenFamiliesTable.FAMILY_ID.Eq(familyID)).
+		Exec(session); err != nil {
+		return err
+	}
+
+	iter, err := ctx.Select().
+		From(JwtByUserEmailTable).
+		Where(
+		JwtByUserEmailTable.USER_EMAIL.Eq(email)).
+		Fetch(session)
+	if err != nil {
+		return err
+	}
+	tokens, err := sitrep.BindJwtByUserEmail(iter)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.FamilyId != familyID {
+			continue
+		}
+		if err := deleteAccessToken(cassandra, email, token.Jwt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshTokenInvalidError is returned, when a refresh token can not be exchanged
+type RefreshTokenInvalidError struct {
+	Message string
+}
+
+// Error prints the RefreshTokenInvalidError
+func (r *RefreshTokenInvalidError) Error() string {
+	return r.Message
+}
+
+// NewRefreshTokenInvalidError produces a new RefreshTokenInvalidError
+func NewRefreshTokenInvalidError() *RefreshTokenInvalidError {
+	return &RefreshTokenInvalidError{
+		Message: "Your session has expired, please log in again!",
+	}
+}
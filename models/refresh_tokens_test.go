@@ -0,0 +1,58 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func TestUser_RefreshToken_Rotates(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	first, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	if first.RefreshToken == "" {
+		t.Fatalf("No refresh token was issued")
+	}
+
+	second, err := models.RefreshSignIn(c, opts, nil, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh failed unexpectedly: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatalf("Refresh token was not rotated")
+	}
+	if _, err := models.VerifyUserRequest(c, opts, second.AccessToken); err != nil {
+		t.Fatalf("Refreshed access token verification failed")
+	}
+}
+
+func TestUser_RefreshToken_ReplayRevokesFamily(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	first, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	second, err := models.RefreshSignIn(c, opts, nil, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh failed unexpectedly: %v", err)
+	}
+
+	if _, err := models.RefreshSignIn(c, opts, nil, first.RefreshToken); err == nil {
+		t.Fatalf("Used refresh token was accepted twice")
+	}
+	if _, err := models.RefreshSignIn(c, opts, nil, second.RefreshToken); err == nil {
+		t.Fatalf("Refresh token family was not revoked after a replay")
+	}
+}
+
+func TestUser_RefreshToken_Unknown(t *testing.T) {
+	if _, err := models.RefreshSignIn(dbConn(), models.NewOptions(), nil, "1234"); err == nil {
+		t.Fatalf("Unknown refresh token was accepted")
+	}
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"crypto/subtle"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+const (
+	// RegistrationOpen lets anybody create an account
+	RegistrationOpen = "open"
+
+	// RegistrationRestricted only creates accounts for people, who have been
+	// given the join code of an exercise
+	RegistrationRestricted = "restricted"
+
+	// RegistrationDisabled only creates accounts for invited users
+	RegistrationDisabled = "disabled"
+
+	// ExerciseSettingJoinCode is the exercise setting, which holds the code
+	// new users register with to join the exercise. Without a code, nobody
+	// can join by registering.
+	ExerciseSettingJoinCode = "joinCode"
+
+	// maxProfileFieldLength limits the length of free text profile fields
+	maxProfileFieldLength = 100
+)
+
+var twitterNamePattern = regexp.MustCompile(`^@?[A-Za-z0-9_]{1,15}$`)
+
+// Registration holds what a new user enters to create an account. Exercise
+// and join code are optional, unless registration is restricted. Invited
+// users pass their invitation token instead of a join code.
+type Registration struct {
+	Email       string
+	Password    string
+	RealName    string
+	UserTitle   string
+	UserRank    string
+	UserUnit    string
+	TwitterName string
+	ExerciseID  string
+	JoinCode    string
+
+	InvitationToken string
+}
+
+// Validate checks the fields of a registration. Surrounding white space is
+// removed from the email address, its case is kept, because accounts are
+// looked up by their exact address. Whether the password complies with the
+// password policy is checked while registering.
+func (r *Registration) Validate() error {
+	fields := map[string]string{}
+	var ok bool
+	if r.Email, ok = normalizeEmail(r.Email); !ok {
+		fields["email"] = "is not a valid email address"
+	}
+	if r.Password == "" {
+		fields["password"] = "is required"
+	}
+	r.RealName = strings.TrimSpace(r.RealName)
+	if r.RealName == "" {
+		fields["name"] = "is required"
+	}
+	for name, value := range map[string]string{
+		"name":  r.RealName,
+		"title": r.UserTitle,
+		"rank":  r.UserRank,
+		"unit":  r.UserUnit,
+	} {
+		if utf8.RuneCountInString(value) > maxProfileFieldLength {
+			fields[name] = "is too long"
+		}
+	}
+	if r.TwitterName != "" && !twitterNamePattern.MatchString(r.TwitterName) {
+		fields["twitter_alias"] = "is not a valid twitter name"
+	}
+	if len(fields) > 0 {
+		return NewValidationError(fields)
+	}
+	return nil
+}
+
+// RegisterUser creates the account of a new user. With restricted
+// registration, the join code of an exercise or an invitation is required,
+// with disabled registration only invitations are accepted. Users joining
+// with a code become trainees, invited users get the role of their
+// invitation and need not confirm their email address.
+func RegisterUser(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, r *Registration) (*sitrep.UsersByEmail, error) {
+	if r.InvitationToken == "" && opts.Registration != RegistrationOpen && opts.Registration != RegistrationRestricted {
+		return nil, NewRegistrationClosedError()
+	}
+	invalid, _ := r.Validate().(*ValidationError)
+	if invalid == nil {
+		invalid = NewValidationError(map[string]string{})
+	}
+	if _, ok := invalid.Fields["password"]; !ok {
+		if err := opts.passwordPolicy().Check(r.Password); err != nil {
+			invalid.Fields["password"] = err.Error()
+		}
+	}
+	if len(invalid.Fields) > 0 {
+		return nil, invalid
+	}
+	// Counted before the join code is checked, so codes can not be guessed
+	if err := ThrottleRequest(cassandra, opts, RateLimitRegister, client, r.Email); err != nil {
+		return nil, err
+	}
+	var exercise *sitrep.ExerciseByIdentifier
+	var invitation *sitrep.ExerciseInvitations
+	var err error
+	switch {
+	case r.InvitationToken != "":
+		if exercise, invitation, err = findExerciseInvitation(cassandra, r.ExerciseID, r.InvitationToken); err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(invitation.Email, r.Email) {
+			return nil, NewValidationError(map[string]string{"email": "does not match the invitation"})
+		}
+	case r.ExerciseID != "" || opts.Registration == RegistrationRestricted:
+		if exercise, err = findExerciseByJoinCode(cassandra, r.ExerciseID, r.JoinCode); err != nil {
+			return nil, err
+		}
+	}
+
+	user := &sitrep.UsersByEmail{
+		Email:             r.Email,
+		EncryptedPassword: r.Password,
+		RealName:          r.RealName,
+		UserTitle:         r.UserTitle,
+		UserRank:          r.UserRank,
+		UserUnit:          r.UserUnit,
+		TwitterName:       r.TwitterName,
+		IsConfirmed:       invitation != nil,
+	}
+	if err := user.HashCryptPassword(opts.passwordHasher()); err != nil {
+		return nil, err
+	}
+	if err := user.RegenerateEncryptionKey(); err != nil {
+		return nil, err
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	// Two registrations of the same email address must not overwrite each other
+	applied, err := insertOnce(session, "users_by_email", map[string]interface{}{
+		"email":              user.Email,
+		"encrypted_password": user.EncryptedPassword,
+		"jwt_encryption_key": user.JwtEncryptionKey,
+		"real_name":          user.RealName,
+		"user_title":         user.UserTitle,
+		"user_rank":          user.UserRank,
+		"user_unit":          user.UserUnit,
+		"twitter_name":       user.TwitterName,
+		"is_admin":           false,
+		"is_banned":          false,
+		"is_confirmed":       user.IsConfirmed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return nil, NewValidationError(map[string]string{"email": "is already registered"})
+	}
+
+	if err := RecordAuditEvent(cassandra, user.Email, AuditRegistered, client, r.ExerciseID); err != nil {
+		return nil, err
+	}
+	if invitation != nil {
+		if err := joinExerciseByInvitation(cassandra, client, user, r.ExerciseID, r.InvitationToken); err != nil {
+			return nil, err
+		}
+	} else if exercise != nil {
+		if err := AddExerciseMember(cassandra, user, exercise, RoleTrainee); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// PublicExerciseSettings returns the settings of an exercise without the
+// join code. Everybody, who knows the id of an exercise, can read them.
+func PublicExerciseSettings(settings map[string]string) map[string]string {
+	public := make(map[string]string, len(settings))
+	for key, value := range settings {
+		if key != ExerciseSettingJoinCode {
+			public[key] = value
+		}
+	}
+	return public
+}
+
+// normalizeEmail trims email and reports whether it is a plain email address
+func normalizeEmail(email string) (string, bool) {
+	email = strings.TrimSpace(email)
+	address, err := mail.ParseAddress(email)
+	return email, err == nil && address.Address == email
+}
+
+// findExerciseByJoinCode returns the exercise with exerciseID, if its join
+// code matches code
+func findExerciseByJoinCode(cassandra *gocql.ClusterConfig, exerciseID string, code string) (*sitrep.ExerciseByIdentifier, error) {
+	invalid := NewValidationError(map[string]string{"join_code": "is invalid"})
+	id, err := gocql.ParseUUID(exerciseID)
+	if err != nil || code == "" {
+		return nil, invalid
+	}
+	exercise, err := FindExerciseByID(cassandra, id)
+	if err != nil {
+		return nil, err
+	}
+	if exercise.Id != id {
+		return nil, invalid
+	}
+	if exercise.HasActivation && time.Now().After(exercise.ActiveUntil) {
+		return nil, invalid
+	}
+	settings, err := FindOrInitSettingsForExercise(cassandra, id)
+	if err != nil {
+		return nil, err
+	}
+	joinCode := settings[ExerciseSettingJoinCode]
+	if joinCode == "" || subtle.ConstantTimeCompare([]byte(joinCode), []byte(code)) != 1 {
+		return nil, invalid
+	}
+	return exercise, nil
+}
+
+// ValidationError is returned, when fields of a request are invalid. Fields
+// maps the name of every invalid field to what is wrong with it.
+type ValidationError struct {
+	Message string
+	Fields  map[string]string
+}
+
+// Error prints the ValidationError
+func (v *ValidationError) Error() string {
+	return v.Message
+}
+
+// NewValidationError produces a new ValidationError
+func NewValidationError(fields map[string]string) *ValidationError {
+	return &ValidationError{
+		Message: "Please check the highlighted fields!",
+		Fields:  fields,
+	}
+}
+
+// RegistrationClosedError is returned, when new accounts can not be created
+type RegistrationClosedError struct {
+	Message string
+}
+
+// Error prints the RegistrationClosedError
+func (r *RegistrationClosedError) Error() string {
+	return r.Message
+}
+
+// NewRegistrationClosedError produces a new RegistrationClosedError
+func NewRegistrationClosedError() *RegistrationClosedError {
+	return &RegistrationClosedError{
+		Message: "Registration is closed!",
+	}
+}
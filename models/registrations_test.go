@@ -0,0 +1,134 @@
+package models_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func mockRegistration() *models.Registration {
+	return &models.Registration{
+		Email:    fmt.Sprintf("Recruit%d@somedomain.com", time.Now().UnixNano()),
+		Password: "test1234",
+		RealName: "New Recruit",
+		UserRank: "PVT",
+	}
+}
+
+func TestRegistration_Validate(t *testing.T) {
+	r := &models.Registration{
+		Email:       "not an address",
+		TwitterName: "@not a twitter name",
+	}
+	err, ok := r.Validate().(*models.ValidationError)
+	if !ok {
+		t.Fatalf("Invalid registration was accepted")
+	}
+	for _, field := range []string{"email", "password", "name", "twitter_alias"} {
+		if err.Fields[field] == "" {
+			t.Fatalf("Invalid field %s was not reported", field)
+		}
+	}
+	if err := mockRegistration().Validate(); err != nil {
+		t.Fatalf("Valid registration was refused: %v", err)
+	}
+	mixed := mockRegistration()
+	mixed.Email = " New.Recruit@SomeDomain.com "
+	if err := mixed.Validate(); err != nil || mixed.Email != "New.Recruit@SomeDomain.com" {
+		t.Fatalf("Email address was not kept as entered: %q", mixed.Email)
+	}
+}
+
+func TestRegisterUser(t *testing.T) {
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.Registration = models.RegistrationOpen
+	r := mockRegistration()
+
+	user, err := models.RegisterUser(c, opts, nil, r)
+	if err != nil {
+		t.Fatalf("Registration failed unexpectedly: %v", err)
+	}
+	if user.Email != r.Email || user.JwtEncryptionKey == "" {
+		t.Fatalf("Registered user is incomplete: %v", user)
+	}
+	if _, err := models.UserSignIn(c, opts, nil, user.Email, "test1234", "password"); err != nil {
+		t.Fatalf("Registered user could not sign in: %v", err)
+	}
+
+	again := *r
+	again.Password = "other1234"
+	if _, err := models.RegisterUser(c, opts, nil, &again); err == nil {
+		t.Fatalf("Email address was registered twice")
+	} else if v, ok := err.(*models.ValidationError); !ok || v.Fields["email"] == "" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRegisterUser_Restricted(t *testing.T) {
+	exercise := mockExercise()
+	initExercise(exercise)
+	c := dbConn()
+	if _, err := models.UpdateExerciseSetting(c, exercise.Id, map[string]string{models.ExerciseSettingJoinCode: "alpha-bravo"}); err != nil {
+		t.Fatalf("Join code could not be set: %v", err)
+	}
+	opts := models.NewOptions()
+
+	r := mockRegistration()
+	if _, err := models.RegisterUser(c, opts, nil, r); err == nil {
+		t.Fatalf("User registered without join code")
+	}
+	r.ExerciseID = exercise.Id.String()
+	r.JoinCode = "charlie"
+	if _, err := models.RegisterUser(c, opts, nil, r); err == nil {
+		t.Fatalf("User registered with a wrong join code")
+	}
+	r.JoinCode = "alpha-bravo"
+	user, err := models.RegisterUser(c, opts, nil, r)
+	if err != nil {
+		t.Fatalf("Registration failed unexpectedly: %v", err)
+	}
+	permissions, err := models.FindExercisePermissionsForUser(c, user, exercise)
+	if err != nil {
+		t.Fatalf("Permissions could not be read: %v", err)
+	}
+	if !permissions.IsAuthorized || !permissions.IsTrainee {
+		t.Fatalf("Registered user did not join the exercise as trainee")
+	}
+
+	opts.Registration = models.RegistrationDisabled
+	if _, err := models.RegisterUser(c, opts, nil, mockRegistration()); err == nil {
+		t.Fatalf("User registered although registration is disabled")
+	} else if _, ok := err.(*models.RegistrationClosedError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRegisterUser_RateLimited(t *testing.T) {
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.Registration = models.RegistrationOpen
+	opts.RateLimitPerAddr = 1
+	client := &models.ClientInfo{RemoteAddr: fmt.Sprintf("10.0.%d.1", time.Now().UnixNano()%250)}
+
+	if _, err := models.RegisterUser(c, opts, client, mockRegistration()); err != nil {
+		t.Fatalf("Registration failed unexpectedly: %v", err)
+	}
+	if _, err := models.RegisterUser(c, opts, client, mockRegistration()); err == nil {
+		t.Fatalf("Client could register without limit")
+	} else if _, ok := err.(*models.RateLimitedError); !ok {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestPublicExerciseSettings(t *testing.T) {
+	settings := models.PublicExerciseSettings(map[string]string{
+		models.ExerciseSettingJoinCode: "secret",
+		"twitterEnabled":               "true",
+	})
+	if _, ok := settings[models.ExerciseSettingJoinCode]; ok || settings["twitterEnabled"] != "true" {
+		t.Fatalf("Unexpected public settings: %v", settings)
+	}
+}
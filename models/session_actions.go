@@ -0,0 +1,188 @@
+package models
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/gocql/gocql"
+)
+
+// JwtByUserEmailTable is a reference to the access tokens by user table
+var JwtByUserEmailTable = sitrep.JwtByUserEmailTableDef()
+
+// ClientInfo describes the device a session has been opened from
+type ClientInfo struct {
+	UserAgent  string
+	RemoteAddr string
+}
+
+// FindSessions lists the sessions a user is currently logged in with.
+// Sessions, whose latest refresh token has expired, have ended.
+func FindSessions(cassandra *gocql.ClusterConfig, email string) ([]sitrep.RefreshTokenFamilies, error) {
+	now := time.Now()
+	sessions := make([]sitrep.RefreshTokenFamilies, 0)
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(RefreshTokenFamiliesTable).
+		Where(
+		RefreshTokenFamiliesTable.USER_EMAIL.Eq(email)).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	err = sitrep.MapRefreshTokenFamilies(iter, func(family sitrep.RefreshTokenFamilies) (bool, error) {
+		// Sessions started before the expiry was recorded have none
+		expired := !family.ExpiresAt.IsZero() && now.After(family.ExpiresAt)
+		if !family.IsRevoked && !expired {
+			sessions = append(sessions, family)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession ends a single session of a user
+func RevokeSession(cassandra *gocql.ClusterConfig, email string, id gocql.UUID) error {
+	family, err := findTokenFamily(cassandra, email, id)
+	if err != nil {
+		return NewSessionNotFoundError()
+	}
+	if family.IsRevoked {
+		return NewSessionNotFoundError()
+	}
+	return revokeTokenFamily(cassandra, email, id)
+}
+
+// UserSignOut revokes an access token, together with the refresh tokens that
+// were issued alongside it
+func UserSignOut(cassandra *gocql.ClusterConfig, accessToken string) error {
+	var jwt sitrep.UsersByJwt
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(UsersJwtTable).
+		Where(
+		UsersJwtTable.JWT.Eq(accessToken)).
+		Into(
+		UsersJwtTable.To(&jwt)).
+		FetchOne(session)
+
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	if err := deleteAccessToken(cassandra, jwt.UserEmail, jwt.Jwt); err != nil {
+		return err
+	}
+	if jwt.FamilyId == (gocql.UUID{}) {
+		return nil
+	}
+	return revokeTokenFamily(cassandra, jwt.UserEmail, jwt.FamilyId)
+}
+
+// UserSignOutEverywhere revokes every access and refresh token of a user
+func UserSignOutEverywhere(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) error {
+	if user == nil {
+		return NewUserInvalidError()
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(JwtByUserEmailTable).
+		Where(
+		JwtByUserEmailTable.USER_EMAIL.Eq(user.Email)).
+		Fetch(session)
+	if err != nil {
+		return err
+	}
+	tokens, err := sitrep.BindJwtByUserEmail(iter)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := ctx.Delete().
+			From(UsersJwtTable).
+			Where(
+			UsersJwtTable.JWT.Eq(token.Jwt)).
+			Exec(session); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Delete().
+		From(JwtByUserEmailTable).
+		Where(
+		JwtByUserEmailTable.USER_EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return err
+	}
+
+	iter, err = ctx.Select().
+		From(RefreshTokenFamiliesTable).
+		Where(
+		RefreshTokenFamiliesTable.USER_EMAIL.Eq(user.Email)).
+		Fetch(session)
+	if err != nil {
+		return err
+	}
+	families, err := sitrep.BindRefreshTokenFamilies(iter)
+	if err != nil {
+		return err
+	}
+	for _, family := range families {
+		if family.IsRevoked {
+			continue
+		}
+		if err := ctx.Upsert(RefreshTokenFamiliesTable).
+			SetBoolean(RefreshTokenFamiliesTable.IS_REVOKED, true).
+			Where(
+			RefreshTokenFamiliesTable.USER_EMAIL.Eq(user.Email),
+			RefreshTokenFamiliesTable.FAMILY_ID.Eq(family.FamilyId)).
+			Exec(session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAccessToken removes an access token from both token tables
+func deleteAccessToken(cassandra *gocql.ClusterConfig, email string, accessToken string) error {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Delete().
+		From(UsersJwtTable).
+		Where(
+		UsersJwtTable.JWT.Eq(accessToken)).
+		Exec(session); err != nil {
+		return err
+	}
+	return ctx.Delete().
+		From(JwtByUserEmailTable).
+		Where(
+		JwtByUserEmailTable.USER_EMAIL.Eq(email),
+		JwtByUserEmailTable.JWT.Eq(accessToken)).
+		Exec(session)
+}
+
+// SessionNotFoundError is returned, when a session does not exist or has
+// already ended
+type SessionNotFoundError struct {
+	Message string
+}
+
+// Error prints the SessionNotFoundError
+func (s *SessionNotFoundError) Error() string {
+	return s.Message
+}
+
+// NewSessionNotFoundError produces a new SessionNotFoundError
+func NewSessionNotFoundError() *SessionNotFoundError {
+	return &SessionNotFoundError{
+		Message: "This session does not exist!",
+	}
+}
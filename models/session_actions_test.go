@@ -0,0 +1,107 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func TestUser_SignOut(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	if err := models.UserSignOut(c, req.AccessToken); err != nil {
+		t.Fatalf("Sign out failed unexpectedly: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, req.AccessToken); err == nil {
+		t.Fatalf("Access token is still valid after signing out")
+	}
+	if _, err := models.RefreshSignIn(c, opts, nil, req.RefreshToken); err == nil {
+		t.Fatalf("Refresh token is still valid after signing out")
+	}
+}
+
+func TestUser_SignOutEverywhere(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	first, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	second, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	if err := models.UserSignOutEverywhere(c, mockUser()); err != nil {
+		t.Fatalf("Sign out failed unexpectedly: %v", err)
+	}
+	for _, req := range []string{first.AccessToken, second.AccessToken} {
+		if _, err := models.VerifyUserRequest(c, opts, req); err == nil {
+			t.Fatalf("Access token is still valid after signing out everywhere")
+		}
+	}
+	if _, err := models.RefreshSignIn(c, opts, nil, second.RefreshToken); err == nil {
+		t.Fatalf("Refresh token is still valid after signing out everywhere")
+	}
+}
+
+func TestUser_Sessions(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	if err := models.UserSignOutEverywhere(c, mockUser()); err != nil {
+		t.Fatalf("Sign out failed unexpectedly: %v", err)
+	}
+	client := &models.ClientInfo{UserAgent: "test-agent", RemoteAddr: "127.0.0.1"}
+	req, err := models.UserSignIn(c, opts, client, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	sessions, err := models.FindSessions(c, "someguy@somedomain.com")
+	if err != nil {
+		t.Fatalf("Listing sessions failed unexpectedly: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("Expected 1 session, got %d", len(sessions))
+	}
+	if sessions[0].UserAgent != "test-agent" || sessions[0].RemoteAddr != "127.0.0.1" {
+		t.Fatalf("Client was not recorded: %+v", sessions[0])
+	}
+
+	if err := models.RevokeSession(c, "someguy@somedomain.com", sessions[0].FamilyId); err != nil {
+		t.Fatalf("Revoking the session failed unexpectedly: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, req.AccessToken); err == nil {
+		t.Fatalf("Access token is still valid after revoking its session")
+	}
+	if err := models.RevokeSession(c, "someguy@somedomain.com", sessions[0].FamilyId); err == nil {
+		t.Fatalf("A revoked session was revoked twice")
+	}
+}
+
+func TestUser_SessionsExpire(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	opts.RefreshTokenLifetime = time.Second
+	if err := models.UserSignOutEverywhere(c, mockUser()); err != nil {
+		t.Fatalf("Sign out failed unexpectedly: %v", err)
+	}
+	if _, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password"); err != nil {
+		t.Fatalf("Sign in failed unexpectedly")
+	}
+	time.Sleep(1500 * time.Millisecond)
+	sessions, err := models.FindSessions(c, "someguy@somedomain.com")
+	if err != nil {
+		t.Fatalf("Listing sessions failed unexpectedly: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("Session with an expired refresh token was listed: %+v", sessions)
+	}
+}
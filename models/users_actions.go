@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/fkasper/sitrep-authentication/schema"
 	"github.com/gocql/gocql"
 )
@@ -30,9 +32,36 @@ func FindUserByEmail(cassandra *gocql.ClusterConfig, email string) (*sitrep.User
 	return &user, nil
 }
 
-// UserSignIn verifies and authenticates a user from database
-func UserSignIn(cassandra *gocql.ClusterConfig, email string, password string, scope string) (*sitrep.JWTResponse, error) {
+// UserSignIn verifies and authenticates a user from database. Users with two
+// factor authentication get a MfaRequiredError instead of tokens, users with
+// an unconfirmed email address are refused, if opts require confirmed ones.
+func UserSignIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, email string, password string, scope string) (*sitrep.JWTResponse, error) {
+	user, err := AuthenticateLogin(cassandra, opts, client, email, password)
+	if err != nil {
+		return nil, err
+	}
+	return completeSignIn(cassandra, opts, client, user)
+}
+
+// completeSignIn issues tokens to a user, who has proven the first factor,
+// unless the user has to confirm the email address or pass a second factor
+func completeSignIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, user *sitrep.UsersByEmail) (*sitrep.JWTResponse, error) {
+	if err := CheckEmailConfirmed(opts, user); err != nil {
+		return nil, err
+	}
+	if user.TotpEnabled {
+		mfaToken, err := CreateMfaChallenge(cassandra, opts, user)
+		if err != nil {
+			return nil, err
+		}
+		return nil, NewMfaRequiredError(mfaToken)
+	}
 
+	return signIn(cassandra, opts, client, user)
+}
+
+// AuthenticateUser checks the credentials of a user without issuing tokens
+func AuthenticateUser(cassandra *gocql.ClusterConfig, email string, password string) (*sitrep.UsersByEmail, error) {
 	user, err := FindUserByEmail(cassandra, email)
 	if err != nil {
 		return nil, NewUserInvalidError()
@@ -43,28 +72,62 @@ func UserSignIn(cassandra *gocql.ClusterConfig, email string, password string, s
 	if user.IsBanned {
 		return nil, NewUserInvalidError()
 	}
+	if err := checkAccountExpiry(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
 
-	jwtToken, err := sitrep.NewJwtResponse(user.JwtEncryptionKey, user.Email)
+// VerifyUserRequest verfies a request - as efficient as possible.
+// Tokens of service clients are rejected.
+func VerifyUserRequest(cassandra *gocql.ClusterConfig, opts *Options, accessToken string) (*sitrep.UsersByEmail, error) {
+	principal, err := VerifyRequest(cassandra, opts, accessToken)
 	if err != nil {
-		return nil, NewUserInvalidError()
+		return nil, err
 	}
-	jwtUser := &sitrep.UsersByJwt{
-		EncryptionKey: user.JwtEncryptionKey,
-		Jwt:           jwtToken.AccessToken,
-		UserEmail:     user.Email,
-		UserName:      user.RealName,
+	if principal.User == nil {
+		return nil, NewUserInvalidError()
 	}
-	session, ctx, _ := WithSession(cassandra)
-	defer session.Close()
-	if err := ctx.Store(UsersJwtTable.Bind(*jwtUser)).Exec(session); err != nil {
+	return principal.User, nil
+}
+
+// VerifyRequest verifies a request of either a user or a service client
+func VerifyRequest(cassandra *gocql.ClusterConfig, opts *Options, accessToken string) (*Principal, error) {
+	jwt, _, principal, err := verifyAccessToken(cassandra, opts, accessToken)
+	if err != nil {
 		return nil, err
 	}
-
-	return jwtToken, nil
+	if principal.User != nil {
+		if principal.User.IsBanned {
+			return nil, NewUserInvalidError()
+		}
+		if err := checkAccountExpiry(principal.User); err != nil {
+			return nil, err
+		}
+	}
+	if principal.IsImpersonated() {
+		if err := RecordAuditEvent(cassandra, principal.User.Email, AuditImpersonationUsed, nil, principal.Actor.Email); err != nil {
+			return nil, err
+		}
+	}
+	if jwt.FamilyId != (gocql.UUID{}) {
+		family, err := findTokenFamily(cassandra, jwt.UserEmail, jwt.FamilyId)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(family.LastSeenAt) >= touchTokenFamilyInterval {
+			if err := touchTokenFamily(cassandra, jwt.UserEmail, jwt.FamilyId, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return principal, nil
 }
 
-// VerifyUserRequest verfies a request - as efficient as possible.
-func VerifyUserRequest(cassandra *gocql.ClusterConfig, accessToken string) (*sitrep.UsersByEmail, error) {
+// verifyAccessToken checks the signature of an access token and that it has
+// not been revoked. It returns the stored token, its claims and its principal,
+// leaving it to the caller to decide about banned and expired users.
+func verifyAccessToken(cassandra *gocql.ClusterConfig, opts *Options, accessToken string) (*sitrep.UsersByJwt, map[string]interface{}, *Principal, error) {
 	var jwt sitrep.UsersByJwt
 	session, ctx, _ := WithSession(cassandra)
 	defer session.Close()
@@ -77,31 +140,80 @@ func VerifyUserRequest(cassandra *gocql.ClusterConfig, accessToken string) (*sit
 		FetchOne(session)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	token, err := jwt.Verify()
+	token, err := jwt.Verify(opts.Keys)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	// ID tokens are signed by the same keys, but only tell clients who
+	// signed in
+	if use, _ := token.Claims["token_use"].(string); use == TokenUseID {
+		return nil, nil, nil, NewUserInvalidError()
+	}
+	subject, _ := token.Claims["sub"].(string)
+	scope, _ := token.Claims["scope"].(string)
+	principal := &Principal{
+		Type:    PrincipalUser,
+		Subject: subject,
+		Scopes:  parseScopes(scope),
 	}
-	user, err := FindUserByEmail(cassandra, token.Claims["sub"].(string))
+
+	if principalType, _ := token.Claims["principal_type"].(string); principalType == PrincipalService {
+		client, err := FindClientByID(cassandra, subject)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// Tokens issued before the key of the client was regenerated are void
+		if jwt.EncryptionKey != client.JwtEncryptionKey {
+			return nil, nil, nil, NewClientInvalidError()
+		}
+		principal.Type = PrincipalService
+		principal.Client = client
+		return &jwt, token.Claims, principal, nil
+	}
+
+	user, err := FindUserByEmail(cassandra, subject)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	if user.IsBanned {
-		return nil, NewUserInvalidError()
+	// Tokens issued before the key of the user was regenerated are void
+	if jwt.EncryptionKey != user.JwtEncryptionKey {
+		return nil, nil, nil, NewUserInvalidError()
 	}
-	return user, nil
+	principal.User = user
+	if principal.Actor, err = findActor(cassandra, token.Claims); err != nil {
+		return nil, nil, nil, err
+	}
+	principal.ExerciseID, _ = token.Claims["exercise_id"].(string)
+	return &jwt, token.Claims, principal, nil
 }
 
 // UserChangePassword changes a users password, if they match the previous one
-func UserChangePassword(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, oldPasswd string, newPasswd string) (*map[string]string, error) {
+// and the new one complies with the password policy
+func UserChangePassword(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, oldPasswd string, newPasswd string) (*map[string]string, error) {
 	if err := user.ValidatePassword(oldPasswd); err != nil {
 		return nil, NewUserInvalidError()
 	}
-	user.EncryptedPassword = newPasswd
-	if err := user.HashCryptPassword(); err != nil {
+	if err := checkNewPassword(cassandra, opts, user, "new_password", newPasswd); err != nil {
 		return nil, err
 	}
+	if err := setUserPassword(cassandra, opts, user, newPasswd); err != nil {
+		return nil, err
+	}
+	return &map[string]string{"status": "changed"}, nil
+}
+
+// setUserPassword stores a new password of user and ends all sessions. The
+// password has to be checked against the password policy before.
+func setUserPassword(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, password string) error {
+	if err := recordPasswordHistory(cassandra, user); err != nil {
+		return err
+	}
+	user.EncryptedPassword = password
+	if err := user.HashCryptPassword(opts.passwordHasher()); err != nil {
+		return err
+	}
 	session, ctx, _ := WithSession(cassandra)
 	defer session.Close()
 	if err := ctx.Upsert(UsersTable).
@@ -109,9 +221,36 @@ func UserChangePassword(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmai
 		Where(
 		UsersTable.EMAIL.Eq(user.Email)).
 		Exec(session); err != nil {
-		return nil, err
+		return err
 	}
-	return &map[string]string{"status": "changed"}, nil
+	return RotateUserEncryptionKey(cassandra, user)
+}
+
+// RotateUserEncryptionKey regenerates the key the tokens of a user are bound
+// to, which voids every token issued so far, and ends all sessions of the user
+func RotateUserEncryptionKey(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail) error {
+	if err := user.RegenerateEncryptionKey(); err != nil {
+		return err
+	}
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := ctx.Upsert(UsersTable).
+		SetString(UsersTable.JWT_ENCRYPTION_KEY, user.JwtEncryptionKey).
+		Where(
+		UsersTable.EMAIL.Eq(user.Email)).
+		Exec(session); err != nil {
+		return err
+	}
+	return UserSignOutEverywhere(cassandra, user)
+}
+
+// ForceUserRelogin makes a user log in again on every device
+func ForceUserRelogin(cassandra *gocql.ClusterConfig, email string) error {
+	user, err := FindUserByEmail(cassandra, email)
+	if err != nil || user.Email == "" {
+		return NewUserNotFoundError()
+	}
+	return RotateUserEncryptionKey(cassandra, user)
 }
 
 // FetchAllUsers retreives all SAFE fields for users
@@ -144,3 +283,20 @@ func NewUserInvalidError() *UserInvalidError {
 		Message: "We were not able to log you in!",
 	}
 }
+
+// UserNotFoundError is returned, when a user does not exist
+type UserNotFoundError struct {
+	Message string
+}
+
+// Error prints the UserNotFoundError
+func (u *UserNotFoundError) Error() string {
+	return u.Message
+}
+
+// NewUserNotFoundError produces a new UserNotFoundError
+func NewUserNotFoundError() *UserNotFoundError {
+	return &UserNotFoundError{
+		Message: "This user does not exist!",
+	}
+}
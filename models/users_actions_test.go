@@ -64,6 +64,15 @@ func initUser(user *sitrep.UsersByEmail) {
 	if err != nil {
 		panic(err)
 	}
+	// Passwords of earlier runs must not count as recently used
+	err = ctx.Delete().
+		From(models.PasswordHistoryTable).
+		Where(
+		models.PasswordHistoryTable.USER_EMAIL.Eq(usr.Email)).
+		Exec(session)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func initJwtUser(user *sitrep.UsersByJwt, jwt string) {
@@ -83,7 +92,7 @@ func initJwtUser(user *sitrep.UsersByJwt, jwt string) {
 
 func TestUser_Authentication_WithoutData(t *testing.T) {
 	initUser(nil)
-	_, err := models.UserSignIn(dbConn(), "", "", "")
+	_, err := models.UserSignIn(dbConn(), models.NewOptions(), nil, "", "", "")
 	if err == nil {
 		t.Fatalf("User was signed in without an email oO")
 	}
@@ -91,7 +100,7 @@ func TestUser_Authentication_WithoutData(t *testing.T) {
 
 func TestUser_Authentication_WithInCorrectPassword(t *testing.T) {
 	initUser(nil)
-	_, err := models.UserSignIn(dbConn(), "someguy@somedomain.com", "test1235", "password")
+	_, err := models.UserSignIn(dbConn(), models.NewOptions(), nil, "someguy@somedomain.com", "test1235", "password")
 	if err == nil {
 		t.Fatalf("Incorrect password was accepted!")
 	}
@@ -99,7 +108,7 @@ func TestUser_Authentication_WithInCorrectPassword(t *testing.T) {
 
 func TestUser_Authentication_WithCorrectPassword(t *testing.T) {
 	initUser(nil)
-	_, err := models.UserSignIn(dbConn(), "someguy@somedomain.com", "test1234", "password")
+	_, err := models.UserSignIn(dbConn(), models.NewOptions(), nil, "someguy@somedomain.com", "test1234", "password")
 	if err != nil {
 		t.Fatalf("Correct password was not accepted! %v", err.Error())
 	}
@@ -109,7 +118,7 @@ func TestUser_IsBanned(t *testing.T) {
 	user := mockUser()
 	user.IsBanned = true
 	initUser(user)
-	_, err := models.UserSignIn(dbConn(), "someguy@somedomain.com", "test1234", "password")
+	_, err := models.UserSignIn(dbConn(), models.NewOptions(), nil, "someguy@somedomain.com", "test1234", "password")
 	if err == nil {
 		t.Fatalf("Banned User was allowed into the system")
 	}
@@ -122,13 +131,13 @@ func TestUser_AccessTokenValid(t *testing.T) {
 	//VerifyUserRequest
 	initUser(nil)
 	c := dbConn()
-	user, err := models.UserSignIn(c, "someguy@somedomain.com", "test1234", "password")
+	user, err := models.UserSignIn(c, models.NewOptions(), nil, "someguy@somedomain.com", "test1234", "password")
 	if err != nil {
 		t.Fatalf("Sign in failed unexpectedly")
 	}
 	initJwtUser(nil, user.AccessToken)
 
-	if _, err := models.VerifyUserRequest(c, user.AccessToken); err != nil {
+	if _, err := models.VerifyUserRequest(c, models.NewOptions(), user.AccessToken); err != nil {
 		t.Fatalf("Access token verification failed")
 	}
 }
@@ -138,13 +147,13 @@ func TestUser_AccessTokenInValid(t *testing.T) {
 	initUser(nil)
 	fuser := mockJwtUser("1234")
 	c := dbConn()
-	user, err := models.UserSignIn(c, "someguy@somedomain.com", "test1234", "password")
+	user, err := models.UserSignIn(c, models.NewOptions(), nil, "someguy@somedomain.com", "test1234", "password")
 	if err != nil {
 		t.Fatalf("Sign in failed unexpectedly")
 	}
 	initJwtUser(fuser, user.AccessToken)
 
-	if _, err := models.VerifyUserRequest(c, "1234"); err == nil {
+	if _, err := models.VerifyUserRequest(c, models.NewOptions(), "1234"); err == nil {
 		t.Fatalf("Access token accidientially Verified. Should be false")
 	}
 }
@@ -153,18 +162,18 @@ func TestUser_Change_Passwd_ValidCurrent(t *testing.T) {
 	//UserChangePassword
 	initUser(nil)
 	c := dbConn()
-	req, err := models.UserSignIn(c, "someguy@somedomain.com", "test1234", "password")
+	req, err := models.UserSignIn(c, models.NewOptions(), nil, "someguy@somedomain.com", "test1234", "password")
 	if err != nil {
 		t.Fatalf("login failed unexpectedly")
 		return
 	}
-	u, err := models.VerifyUserRequest(c, req.AccessToken)
-	if _, err := models.UserChangePassword(c, u, "test1234", "test12345"); err != nil {
+	u, err := models.VerifyUserRequest(c, models.NewOptions(), req.AccessToken)
+	if _, err := models.UserChangePassword(c, models.NewOptions(), u, "test1234", "test12345"); err != nil {
 		t.Fatalf("password change failed unexpectedly")
 		return
 	}
 
-	if _, err := models.UserSignIn(c, "someguy@somedomain.com", "test12345", "password"); err != nil {
+	if _, err := models.UserSignIn(c, models.NewOptions(), nil, "someguy@somedomain.com", "test12345", "password"); err != nil {
 		t.Fatalf("second login failed unexpectedly")
 		return
 	}
@@ -175,13 +184,13 @@ func TestUser_Change_Passwd_In_ValidCurrent(t *testing.T) {
 	//UserChangePassword
 	initUser(nil)
 	c := dbConn()
-	req, err := models.UserSignIn(c, "someguy@somedomain.com", "test1234", "password")
+	req, err := models.UserSignIn(c, models.NewOptions(), nil, "someguy@somedomain.com", "test1234", "password")
 	if err != nil {
 		t.Fatalf("login failed unexpectedly")
 		return
 	}
-	u, err := models.VerifyUserRequest(c, req.AccessToken)
-	if _, err := models.UserChangePassword(c, u, "test12355", "test12345"); err == nil {
+	u, err := models.VerifyUserRequest(c, models.NewOptions(), req.AccessToken)
+	if _, err := models.UserChangePassword(c, models.NewOptions(), u, "test12355", "test12345"); err == nil {
 		t.Fatalf("password change was unexpectedly successful")
 		return
 	}
@@ -202,3 +211,47 @@ func TestUser_FetchAll(t *testing.T) {
 		return
 	}
 }
+
+func TestUser_Change_Passwd_RevokesTokens(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("login failed unexpectedly")
+	}
+	u, err := models.VerifyUserRequest(c, opts, req.AccessToken)
+	if err != nil {
+		t.Fatalf("Access token verification failed")
+	}
+	if _, err := models.UserChangePassword(c, models.NewOptions(), u, "test1234", "test12345"); err != nil {
+		t.Fatalf("password change failed unexpectedly")
+	}
+	if _, err := models.VerifyUserRequest(c, opts, req.AccessToken); err == nil {
+		t.Fatalf("Access token is still valid after a password change")
+	}
+	if _, err := models.RefreshSignIn(c, opts, nil, req.RefreshToken); err == nil {
+		t.Fatalf("Refresh token is still valid after a password change")
+	}
+}
+
+func TestUser_ForceRelogin(t *testing.T) {
+	initUser(nil)
+	c := dbConn()
+	opts := models.NewOptions()
+	req, err := models.UserSignIn(c, opts, nil, "someguy@somedomain.com", "test1234", "password")
+	if err != nil {
+		t.Fatalf("login failed unexpectedly")
+	}
+	if err := models.ForceUserRelogin(c, "someguy@somedomain.com"); err != nil {
+		t.Fatalf("force relogin failed unexpectedly: %v", err)
+	}
+	// A token row surviving the sign out must fail as well, as its key is stale
+	initJwtUser(nil, req.AccessToken)
+	if _, err := models.VerifyUserRequest(c, opts, req.AccessToken); err == nil {
+		t.Fatalf("Access token is still valid after a forced relogin")
+	}
+	if err := models.ForceUserRelogin(c, "nobody@somedomain.com"); err == nil {
+		t.Fatalf("force relogin of an unknown user succeeded")
+	}
+}
@@ -0,0 +1,357 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/webauthn"
+	"github.com/gocql/gocql"
+)
+
+// WebAuthnCredentialsTable is a reference to the passkeys and security keys of users
+var WebAuthnCredentialsTable = sitrep.WebauthnCredentialsTableDef()
+
+// WebAuthnChallengesTable is a reference to the pending WebAuthn ceremonies
+var WebAuthnChallengesTable = sitrep.WebauthnChallengesTableDef()
+
+const (
+	// RelyingPartyName is how this service is presented by authenticators
+	RelyingPartyName = "SITREP"
+
+	ceremonyRegistration = "registration"
+	ceremonySignIn       = "sign_in"
+)
+
+// decoyCredentialKey derives the credential ids offered to users without
+// credentials. It only lives as long as the process, the ids are stable
+// between requests but can not be predicted.
+var decoyCredentialKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// BeginWebAuthnRegistration starts the registration of a new passkey or
+// security key for user. The returned options are passed to the browser.
+func BeginWebAuthnRegistration(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail) (*webauthn.CreationOptions, error) {
+	credentials, err := FindWebAuthnCredentials(cassandra, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := createWebAuthnChallenge(cassandra, user.Email, ceremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	entity := webauthn.UserEntity{
+		ID:          webAuthnUserHandle(user.Email),
+		Name:        user.Email,
+		DisplayName: user.RealName,
+	}
+	return opts.relyingParty().NewCreationOptions(challenge, entity, credentialIDs(credentials)), nil
+}
+
+// FinishWebAuthnRegistration verifies the response of the authenticator and
+// stores the new credential under name
+func FinishWebAuthnRegistration(cassandra *gocql.ClusterConfig, opts *Options, user *sitrep.UsersByEmail, client *ClientInfo, name string, clientDataJSON []byte, attestationObject []byte) (*sitrep.WebauthnCredentials, error) {
+	challenge, err := consumeWebAuthnChallenge(cassandra, clientDataJSON, ceremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserEmail != user.Email {
+		return nil, NewWebAuthnInvalidError()
+	}
+	verified, err := opts.relyingParty().VerifyRegistration(challenge.challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		return nil, NewWebAuthnInvalidError()
+	}
+	now := time.Now()
+	credential := &sitrep.WebauthnCredentials{
+		UserEmail:    user.Email,
+		CredentialId: webauthn.EncodeID(verified.ID),
+		PublicKey:    webauthn.EncodeID(verified.PublicKey),
+		Algorithm:    verified.Algorithm,
+		SignCount:    int64(verified.SignCount),
+		Name:         name,
+		CreatedAt:    now,
+		LastUsedAt:   now,
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	// The public key of a registered credential must never be replaced
+	applied, err := insertOnce(session, "webauthn_credentials", map[string]interface{}{
+		"user_email":    credential.UserEmail,
+		"credential_id": credential.CredentialId,
+		"public_key":    credential.PublicKey,
+		"algorithm":     credential.Algorithm,
+		"sign_count":    credential.SignCount,
+		"name":          credential.Name,
+		"created_at":    credential.CreatedAt,
+		"last_used_at":  credential.LastUsedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return nil, NewWebAuthnInvalidError()
+	}
+	if err := RecordAuditEvent(cassandra, user.Email, AuditWebAuthnRegistered, client, credential.CredentialId); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// FindWebAuthnCredentials lists the registered credentials of a user
+func FindWebAuthnCredentials(cassandra *gocql.ClusterConfig, email string) ([]sitrep.WebauthnCredentials, error) {
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	iter, err := ctx.Select().
+		From(WebAuthnCredentialsTable).
+		Where(
+		WebAuthnCredentialsTable.USER_EMAIL.Eq(email)).
+		Fetch(session)
+	if err != nil {
+		return nil, err
+	}
+	return sitrep.BindWebauthnCredentials(iter)
+}
+
+// DeleteWebAuthnCredential removes a credential of user, it can no longer
+// be used to sign in
+func DeleteWebAuthnCredential(cassandra *gocql.ClusterConfig, user *sitrep.UsersByEmail, client *ClientInfo, credentialID string) error {
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	applied, err := consumeOnce(session, "webauthn_credentials", map[string]interface{}{
+		"user_email":    user.Email,
+		"credential_id": credentialID,
+	})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return NewWebAuthnCredentialNotFoundError()
+	}
+	return RecordAuditEvent(cassandra, user.Email, AuditWebAuthnRemoved, client, credentialID)
+}
+
+// BeginWebAuthnSignIn starts a sign in with one of the credentials of the
+// user with email. Unknown users and users without credentials are offered
+// a decoy credential, so the response does not reveal whether an account
+// exists.
+func BeginWebAuthnSignIn(cassandra *gocql.ClusterConfig, opts *Options, email string) (*webauthn.RequestOptions, error) {
+	credentials, err := FindWebAuthnCredentials(cassandra, email)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := createWebAuthnChallenge(cassandra, email, ceremonySignIn)
+	if err != nil {
+		return nil, err
+	}
+	ids := credentialIDs(credentials)
+	if len(ids) == 0 {
+		ids = [][]byte{decoyCredentialID(email)}
+	}
+	return opts.relyingParty().NewRequestOptions(challenge, ids), nil
+}
+
+// WebAuthnSignIn signs a user in with an assertion of a registered
+// credential. It takes the place of the password in UserSignIn. The
+// authenticator has verified the user, so no second step is required.
+func WebAuthnSignIn(cassandra *gocql.ClusterConfig, opts *Options, client *ClientInfo, credentialID string, clientDataJSON []byte, authenticatorData []byte, signature []byte) (*sitrep.JWTResponse, error) {
+	challenge, err := consumeWebAuthnChallenge(cassandra, clientDataJSON, ceremonySignIn)
+	if err != nil {
+		return nil, NewUserInvalidError()
+	}
+	var credential sitrep.WebauthnCredentials
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(WebAuthnCredentialsTable).
+		Where(
+		WebAuthnCredentialsTable.USER_EMAIL.Eq(challenge.UserEmail),
+		WebAuthnCredentialsTable.CREDENTIAL_ID.Eq(credentialID)).
+		Into(
+		WebAuthnCredentialsTable.To(&credential)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewUserInvalidError()
+	}
+	publicKey, err := webauthn.DecodeID(credential.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	signCount, err := opts.relyingParty().VerifyAssertion(challenge.challenge, &webauthn.Credential{
+		PublicKey: publicKey,
+		Algorithm: credential.Algorithm,
+		SignCount: uint32(credential.SignCount),
+	}, clientDataJSON, authenticatorData, signature)
+	if err != nil {
+		if err := recordLogin(cassandra, opts, client, credential.UserEmail, LoginFailureInvalidPasskey); err != nil {
+			return nil, err
+		}
+		return nil, NewUserInvalidError()
+	}
+	if err := ctx.Upsert(WebAuthnCredentialsTable).
+		SetInt64(WebAuthnCredentialsTable.SIGN_COUNT, int64(signCount)).
+		SetTimestamp(WebAuthnCredentialsTable.LAST_USED_AT, time.Now()).
+		Where(
+		WebAuthnCredentialsTable.USER_EMAIL.Eq(credential.UserEmail),
+		WebAuthnCredentialsTable.CREDENTIAL_ID.Eq(credential.CredentialId)).
+		Exec(session); err != nil {
+		return nil, err
+	}
+
+	user, err := FindUserByEmail(cassandra, credential.UserEmail)
+	if err != nil || user.Email == "" || user.IsBanned {
+		return nil, NewUserInvalidError()
+	}
+	if err := CheckEmailConfirmed(opts, user); err != nil {
+		return nil, err
+	}
+	return signIn(cassandra, opts, client, user)
+}
+
+// hasWebAuthnCredentials reports whether a user can sign in with a passkey
+// or security key
+func hasWebAuthnCredentials(cassandra *gocql.ClusterConfig, email string) (bool, error) {
+	credentials, err := FindWebAuthnCredentials(cassandra, email)
+	if err != nil {
+		return false, err
+	}
+	return len(credentials) > 0, nil
+}
+
+// webAuthnChallenge is a pending ceremony together with its challenge, which
+// is only stored as hash
+type webAuthnChallenge struct {
+	sitrep.WebauthnChallenges
+	challenge string
+}
+
+func createWebAuthnChallenge(cassandra *gocql.ClusterConfig, email string, ceremony string) (string, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", err
+	}
+	pending := &sitrep.WebauthnChallenges{
+		ChallengeHash: sitrep.HashOpaqueToken(challenge),
+		Ceremony:      ceremony,
+		ExpiresAt:     time.Now().Add(webauthn.CeremonyTimeout),
+		UserEmail:     email,
+	}
+	session, _, _ := WithSession(cassandra)
+	defer session.Close()
+	if err := insertWithTTL(session, "webauthn_challenges", map[string]interface{}{
+		"challenge_hash": pending.ChallengeHash,
+		"ceremony":       pending.Ceremony,
+		"expires_at":     pending.ExpiresAt,
+		"user_email":     pending.UserEmail,
+	}, webauthn.CeremonyTimeout); err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
+
+// consumeWebAuthnChallenge looks up the ceremony the client data answers. A
+// challenge can only be answered once.
+func consumeWebAuthnChallenge(cassandra *gocql.ClusterConfig, clientDataJSON []byte, ceremony string) (*webAuthnChallenge, error) {
+	clientData, err := webauthn.ParseClientData(clientDataJSON)
+	if err != nil || clientData.Challenge == "" {
+		return nil, NewWebAuthnInvalidError()
+	}
+	var pending sitrep.WebauthnChallenges
+	session, ctx, _ := WithSession(cassandra)
+	defer session.Close()
+	found, err := ctx.Select().
+		From(WebAuthnChallengesTable).
+		Where(
+		WebAuthnChallengesTable.CHALLENGE_HASH.Eq(sitrep.HashOpaqueToken(clientData.Challenge))).
+		Into(
+		WebAuthnChallengesTable.To(&pending)).
+		FetchOne(session)
+
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewWebAuthnInvalidError()
+	}
+
+	applied, err := consumeOnce(session, "webauthn_challenges", map[string]interface{}{
+		"challenge_hash": pending.ChallengeHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied || pending.Ceremony != ceremony || time.Now().After(pending.ExpiresAt) {
+		return nil, NewWebAuthnInvalidError()
+	}
+	return &webAuthnChallenge{WebauthnChallenges: pending, challenge: clientData.Challenge}, nil
+}
+
+// webAuthnUserHandle identifies a user towards authenticators without
+// revealing the email address
+func webAuthnUserHandle(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return webauthn.EncodeID(sum[:])
+}
+
+// decoyCredentialID is the credential id offered for email, when it has no
+// credentials. It is as long as the ids of the software authenticators.
+func decoyCredentialID(email string) []byte {
+	mac := hmac.New(sha256.New, decoyCredentialKey)
+	mac.Write([]byte(email))
+	return mac.Sum(nil)[:16]
+}
+
+func credentialIDs(credentials []sitrep.WebauthnCredentials) [][]byte {
+	ids := make([][]byte, 0, len(credentials))
+	for _, c := range credentials {
+		if id, err := webauthn.DecodeID(c.CredentialId); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// WebAuthnInvalidError is returned, when a passkey or security key could not be verified
+type WebAuthnInvalidError struct {
+	Message string
+}
+
+// Error prints the WebAuthnInvalidError
+func (w *WebAuthnInvalidError) Error() string {
+	return w.Message
+}
+
+// NewWebAuthnInvalidError produces a new WebAuthnInvalidError
+func NewWebAuthnInvalidError() *WebAuthnInvalidError {
+	return &WebAuthnInvalidError{
+		Message: "The security key could not be verified!",
+	}
+}
+
+// WebAuthnCredentialNotFoundError is returned, when a credential does not exist
+type WebAuthnCredentialNotFoundError struct {
+	Message string
+}
+
+// Error prints the WebAuthnCredentialNotFoundError
+func (w *WebAuthnCredentialNotFoundError) Error() string {
+	return w.Message
+}
+
+// NewWebAuthnCredentialNotFoundError produces a new WebAuthnCredentialNotFoundError
+func NewWebAuthnCredentialNotFoundError() *WebAuthnCredentialNotFoundError {
+	return &WebAuthnCredentialNotFoundError{
+		Message: "This security key does not exist!",
+	}
+}
@@ -0,0 +1,91 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/webauthn"
+	"github.com/fkasper/sitrep-authentication/webauthn/webauthntest"
+)
+
+func webAuthnOptions() *models.Options {
+	opts := models.NewOptions()
+	opts.Issuer = "https://sitrep-vatcinc.com"
+	return opts
+}
+
+func TestWebAuthn_SignIn(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	opts := webAuthnOptions()
+	authenticator, err := webauthntest.NewAuthenticator("sitrep-vatcinc.com", opts.Issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creation, err := models.BeginWebAuthnRegistration(c, opts, user)
+	if err != nil {
+		t.Fatalf("Registration could not be started: %v", err)
+	}
+	clientData, attestation, _ := authenticator.Register(creation.Challenge)
+	credential, err := models.FinishWebAuthnRegistration(c, opts, user, nil, "Laptop", clientData, attestation)
+	if err != nil {
+		t.Fatalf("Registration failed unexpectedly: %v", err)
+	}
+	defer models.DeleteWebAuthnCredential(c, user, nil, credential.CredentialId)
+	if _, err := models.FinishWebAuthnRegistration(c, opts, user, nil, "Laptop", clientData, attestation); err == nil {
+		t.Fatalf("A registration challenge was answered twice")
+	}
+
+	request, err := models.BeginWebAuthnSignIn(c, opts, user.Email)
+	if err != nil {
+		t.Fatalf("Sign in could not be started: %v", err)
+	}
+	if len(request.AllowCredentials) != 1 || request.AllowCredentials[0].ID != credential.CredentialId {
+		t.Fatalf("Registered credential is not offered for the sign in")
+	}
+	clientData, authData, signature, _ := authenticator.Assert(request.Challenge)
+	tokens, err := models.WebAuthnSignIn(c, opts, nil, credential.CredentialId, clientData, authData, signature)
+	if err != nil {
+		t.Fatalf("Sign in failed unexpectedly: %v", err)
+	}
+	if _, err := models.VerifyUserRequest(c, opts, tokens.AccessToken); err != nil {
+		t.Fatalf("Access token of the passkey sign in is invalid")
+	}
+	if _, err := models.WebAuthnSignIn(c, opts, nil, credential.CredentialId, clientData, authData, signature); err == nil {
+		t.Fatalf("An assertion was replayed")
+	}
+}
+
+func TestWebAuthn_UnknownCredential(t *testing.T) {
+	user := mockUser()
+	initUser(user)
+	c := dbConn()
+	opts := webAuthnOptions()
+	authenticator, _ := webauthntest.NewAuthenticator("sitrep-vatcinc.com", opts.Issuer)
+
+	request, err := models.BeginWebAuthnSignIn(c, opts, user.Email)
+	if err != nil {
+		t.Fatalf("Sign in could not be started: %v", err)
+	}
+	clientData, authData, signature, _ := authenticator.Assert(request.Challenge)
+	credentialID := webauthn.EncodeID(authenticator.CredentialID())
+	if _, err := models.WebAuthnSignIn(c, opts, nil, credentialID, clientData, authData, signature); err == nil {
+		t.Fatalf("An unregistered credential was accepted")
+	}
+}
+
+func TestWebAuthn_UnknownUser(t *testing.T) {
+	c := dbConn()
+	opts := webAuthnOptions()
+
+	first, err := models.BeginWebAuthnSignIn(c, opts, "nobody@somedomain.com")
+	if err != nil {
+		t.Fatalf("Sign in could not be started: %v", err)
+	}
+	second, _ := models.BeginWebAuthnSignIn(c, opts, "nobody@somedomain.com")
+	if len(first.AllowCredentials) != 1 || len(second.AllowCredentials) != 1 || first.AllowCredentials[0].ID != second.AllowCredentials[0].ID {
+		t.Fatalf("Unknown users can be told apart: %+v", first.AllowCredentials)
+	}
+}
@@ -0,0 +1,63 @@
+package passwords
+
+import "golang.org/x/crypto/bcrypt"
+
+const (
+	// DefaultMinLength is the number of characters a password needs at least
+	DefaultMinLength = 8
+
+	// DefaultCharacterClasses is the number of different character classes
+	// a password has to mix
+	DefaultCharacterClasses = 2
+
+	// DefaultHistorySize is the number of recent passwords, which can not be
+	// chosen again
+	DefaultHistorySize = 5
+
+	// DefaultHashAlgorithm is the algorithm new password hashes are created with
+	DefaultHashAlgorithm = AlgorithmBcrypt
+
+	// DefaultBcryptCost is the cost of new bcrypt hashes
+	DefaultBcryptCost = bcrypt.DefaultCost
+
+	// DefaultArgon2Time, DefaultArgon2Memory in KiB and DefaultArgon2Threads
+	// are the parameters of new Argon2id hashes, as recommended by RFC 9106
+	DefaultArgon2Time    = 3
+	DefaultArgon2Memory  = 64 * 1024
+	DefaultArgon2Threads = 4
+)
+
+// Config represents the password policy. Passwords listed in the breached
+// hashes file are refused. It holds one hex encoded SHA-1 hash per line, the
+// format of the Pwned Passwords downloads with their ":count" suffix works
+// as well.
+//
+// New passwords are hashed with the hash algorithm and its parameters.
+// Stored hashes of other algorithms or parameters are replaced, when their
+// users sign in.
+type Config struct {
+	MinLength          int    `toml:"min-length"`
+	CharacterClasses   int    `toml:"character-classes"`
+	HistorySize        int    `toml:"history-size"`
+	BreachedHashesFile string `toml:"breached-hashes-file"`
+
+	HashAlgorithm string `toml:"hash-algorithm"`
+	BcryptCost    int    `toml:"bcrypt-cost"`
+	Argon2Time    int    `toml:"argon2-time"`
+	Argon2Memory  int    `toml:"argon2-memory"`
+	Argon2Threads int    `toml:"argon2-threads"`
+}
+
+// NewConfig builds a new configuration with default values.
+func NewConfig() *Config {
+	return &Config{
+		MinLength:        DefaultMinLength,
+		CharacterClasses: DefaultCharacterClasses,
+		HistorySize:      DefaultHistorySize,
+		HashAlgorithm:    DefaultHashAlgorithm,
+		BcryptCost:       DefaultBcryptCost,
+		Argon2Time:       DefaultArgon2Time,
+		Argon2Memory:     DefaultArgon2Memory,
+		Argon2Threads:    DefaultArgon2Threads,
+	}
+}
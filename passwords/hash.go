@@ -0,0 +1,150 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// AlgorithmBcrypt hashes passwords with bcrypt
+	AlgorithmBcrypt = "bcrypt"
+
+	// AlgorithmArgon2id hashes passwords with Argon2id
+	AlgorithmArgon2id = "argon2id"
+
+	// argon2SaltLength and argon2KeyLength are the sizes of the salt and
+	// the derived key of Argon2id hashes in bytes
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+var (
+	// ErrMismatch is returned, when a password does not match a hash
+	ErrMismatch = errors.New("password does not match")
+
+	// ErrUnknownHash is returned for hashes of an unknown format
+	ErrUnknownHash = errors.New("unknown password hash format")
+)
+
+// Hasher hashes passwords with the target algorithm and parameters. Hashes
+// describe how they have been created, so hashes of earlier targets can
+// still be verified.
+type Hasher struct {
+	Algorithm     string
+	BcryptCost    int
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+}
+
+// NewHasher builds a hasher for the hash settings of c
+func NewHasher(c *Config) (*Hasher, error) {
+	if c == nil {
+		c = NewConfig()
+	}
+	h := &Hasher{
+		Algorithm:     c.HashAlgorithm,
+		BcryptCost:    c.BcryptCost,
+		Argon2Time:    uint32(c.Argon2Time),
+		Argon2Memory:  uint32(c.Argon2Memory),
+		Argon2Threads: uint8(c.Argon2Threads),
+	}
+	switch h.Algorithm {
+	case AlgorithmBcrypt:
+		if h.BcryptCost < bcrypt.MinCost || h.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case AlgorithmArgon2id:
+		if c.Argon2Time < 1 || c.Argon2Memory < 8*c.Argon2Threads || c.Argon2Threads < 1 || c.Argon2Threads > 255 {
+			return nil, errors.New("argon2id needs a time of at least 1, 1 to 255 threads and 8 KiB of memory per thread")
+		}
+	default:
+		return nil, fmt.Errorf("unknown hash algorithm: %q", h.Algorithm)
+	}
+	return h, nil
+}
+
+// Hash hashes password with the target algorithm
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.Algorithm == AlgorithmArgon2id {
+		salt := make([]byte, argon2SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, h.Argon2Time, h.Argon2Memory, h.Argon2Threads, argon2KeyLength)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.Argon2Memory, h.Argon2Time, h.Argon2Threads,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// NeedsRehash reports whether hash has not been created with the target
+// algorithm and parameters
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if h.Algorithm == AlgorithmArgon2id {
+		params, _, _, err := parseArgon2id(hash)
+		return err != nil || params != [3]uint32{h.Argon2Memory, h.Argon2Time, uint32(h.Argon2Threads)}
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.BcryptCost
+}
+
+// Verify checks password against hash, whichever supported algorithm
+// created it
+func Verify(hash string, password string) error {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		params, salt, key, err := parseArgon2id(hash)
+		if err != nil {
+			return err
+		}
+		computed := argon2.IDKey([]byte(password), salt, params[1], params[0], uint8(params[2]), uint32(len(key)))
+		if subtle.ConstantTimeCompare(computed, key) != 1 {
+			return ErrMismatch
+		}
+		return nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return ErrMismatch
+		}
+		return ErrUnknownHash
+	}
+	return nil
+}
+
+// parseArgon2id splits an Argon2id hash in PHC string format into its
+// memory, time and threads parameters, its salt and its key
+func parseArgon2id(hash string) ([3]uint32, []byte, []byte, error) {
+	var params [3]uint32
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, ErrUnknownHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params[0], &params[1], &params[2]); err != nil || params[2] < 1 || params[2] > 255 {
+		return params, nil, nil, ErrUnknownHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnknownHash
+	}
+	return params, salt, key, nil
+}
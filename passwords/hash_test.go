@@ -0,0 +1,105 @@
+package passwords_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/passwords"
+)
+
+// fixtureHash is a bcrypt hash of "test1234" with cost 4
+const fixtureHash = "$2a$04$5/1FR1jhqkxr92WvuXsa8ep46PoSBbuuwM6SLuqptqG5j4f3lQyTS"
+
+func argon2Config() *passwords.Config {
+	c := passwords.NewConfig()
+	c.HashAlgorithm = passwords.AlgorithmArgon2id
+	c.Argon2Time = 1
+	c.Argon2Memory = 64
+	c.Argon2Threads = 1
+	return c
+}
+
+func TestHasher_Bcrypt(t *testing.T) {
+	c := passwords.NewConfig()
+	c.BcryptCost = 5
+	h, err := passwords.NewHasher(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := h.Hash("test1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := passwords.Verify(hash, "test1234"); err != nil {
+		t.Fatalf("password does not match its hash: %v", err)
+	}
+	if err := passwords.Verify(hash, "test1235"); err != passwords.ErrMismatch {
+		t.Fatalf("wrong password was accepted: %v", err)
+	}
+	if h.NeedsRehash(hash) {
+		t.Fatalf("hash of the target cost needs a rehash")
+	}
+	if !h.NeedsRehash(fixtureHash) {
+		t.Fatalf("hash of a lower cost needs no rehash")
+	}
+}
+
+func TestHasher_Argon2id(t *testing.T) {
+	h, err := passwords.NewHasher(argon2Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := h.Hash("test1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Fatalf("unexpected hash: %s", hash)
+	}
+	if err := passwords.Verify(hash, "test1234"); err != nil {
+		t.Fatalf("password does not match its hash: %v", err)
+	}
+	if err := passwords.Verify(hash, "test1235"); err != passwords.ErrMismatch {
+		t.Fatalf("wrong password was accepted: %v", err)
+	}
+	if h.NeedsRehash(hash) {
+		t.Fatalf("hash of the target parameters needs a rehash")
+	}
+	if !h.NeedsRehash(fixtureHash) {
+		t.Fatalf("bcrypt hash needs no rehash")
+	}
+
+	c := argon2Config()
+	c.Argon2Time = 2
+	stronger, err := passwords.NewHasher(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stronger.NeedsRehash(hash) {
+		t.Fatalf("hash of other parameters needs no rehash")
+	}
+}
+
+func TestVerify_Legacy(t *testing.T) {
+	if err := passwords.Verify(fixtureHash, "test1234"); err != nil {
+		t.Fatalf("legacy bcrypt hash was not accepted: %v", err)
+	}
+	for _, hash := range []string{"", "plain text", "$argon2id$v=19$m=64,t=1,p=0$AAAA$AAAA"} {
+		if err := passwords.Verify(hash, "test1234"); err != passwords.ErrUnknownHash {
+			t.Fatalf("hash %q was not refused: %v", hash, err)
+		}
+	}
+}
+
+func TestNewHasher_Invalid(t *testing.T) {
+	c := passwords.NewConfig()
+	c.HashAlgorithm = "md5"
+	if _, err := passwords.NewHasher(c); err == nil {
+		t.Fatalf("unknown algorithm was accepted")
+	}
+	c = argon2Config()
+	c.Argon2Threads = 0
+	if _, err := passwords.NewHasher(c); err == nil {
+		t.Fatalf("argon2id without threads was accepted")
+	}
+}
@@ -0,0 +1,138 @@
+package passwords
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLength is the number of bytes of a password, which are taken into
+// account when it is hashed
+const MaxLength = 72
+
+// Policy decides which passwords users can choose
+type Policy struct {
+	MinLength        int
+	CharacterClasses int
+
+	// HistorySize is the number of recent passwords of a user, the current
+	// one included, which can not be chosen again. Checking them is left to
+	// the caller, who knows the stored hashes.
+	HistorySize int
+
+	breached map[[sha1.Size]byte]struct{}
+}
+
+// NewPolicy builds a policy from c and reads its breached hashes file
+func NewPolicy(c *Config) (*Policy, error) {
+	if c == nil {
+		c = NewConfig()
+	}
+	p := &Policy{
+		MinLength:        c.MinLength,
+		CharacterClasses: c.CharacterClasses,
+		HistorySize:      c.HistorySize,
+	}
+	if c.BreachedHashesFile != "" {
+		breached, err := LoadBreachedHashes(c.BreachedHashesFile)
+		if err != nil {
+			return nil, err
+		}
+		p.breached = breached
+	}
+	return p, nil
+}
+
+// LoadBreachedHashes reads a file of hex encoded SHA-1 hashes, one per line.
+// Anything after a colon, like the counts of the Pwned Passwords downloads,
+// is ignored.
+func LoadBreachedHashes(path string) (map[[sha1.Size]byte]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hashes := make(map[[sha1.Size]byte]struct{})
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(text, ':'); i >= 0 {
+			text = text[:i]
+		}
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var hash [sha1.Size]byte
+		if n, err := hex.Decode(hash[:], []byte(text)); err != nil || n != sha1.Size {
+			return nil, fmt.Errorf("%s:%d: not a SHA-1 hash", path, line)
+		}
+		hashes[hash] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// Check returns what is wrong with password, or nil if it can be chosen
+func (p *Policy) Check(password string) error {
+	if password == "" {
+		return &Violation{Message: "is required"}
+	}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return &Violation{Message: fmt.Sprintf("must be at least %d characters long", p.MinLength)}
+	}
+	if len(password) > MaxLength {
+		return &Violation{Message: fmt.Sprintf("must be at most %d bytes long", MaxLength)}
+	}
+	if classes := characterClasses(password); classes < p.CharacterClasses {
+		return &Violation{Message: fmt.Sprintf("must mix at least %d of lower case letters, upper case letters, digits and symbols", p.CharacterClasses)}
+	}
+	if p.IsBreached(password) {
+		return &Violation{Message: "has appeared in a data breach, please choose another one"}
+	}
+	return nil
+}
+
+// IsBreached reports whether password is listed in the breached hashes
+func (p *Policy) IsBreached(password string) bool {
+	if p.breached == nil {
+		return false
+	}
+	_, ok := p.breached[sha1.Sum([]byte(password))]
+	return ok
+}
+
+// characterClasses counts the classes of characters password mixes
+func characterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+// Violation describes why a password can not be chosen
+type Violation struct {
+	Message string
+}
+
+// Error prints the Violation
+func (v *Violation) Error() string {
+	return v.Message
+}
@@ -0,0 +1,65 @@
+package passwords_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/passwords"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	p, err := passwords.NewPolicy(passwords.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, password := range []string{"", "Sh0rt", "password", strings.Repeat("aB", 40)} {
+		if err := p.Check(password); err == nil {
+			t.Fatalf("password %q was accepted", password)
+		}
+	}
+	for _, password := range []string{"correct horse", "Tr0ub4dor", "höhenmesser7"} {
+		if err := p.Check(password); err != nil {
+			t.Fatalf("password %q was refused: %v", password, err)
+		}
+	}
+}
+
+func TestPolicy_Breached(t *testing.T) {
+	f, err := ioutil.TempFile("", "breached")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	// SHA-1 of "Passw0rd!" in the format of the Pwned Passwords downloads
+	f.WriteString("# known breached passwords\nF4A69973E7B0BF9D160F9F60E3C3ACD2494BEB0D:4\n")
+	f.Close()
+
+	c := passwords.NewConfig()
+	c.BreachedHashesFile = f.Name()
+	p, err := passwords.NewPolicy(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Check("Passw0rd!"); err == nil {
+		t.Fatalf("breached password was accepted")
+	}
+	if err := p.Check("Passw0rd?"); err != nil {
+		t.Fatalf("password was refused: %v", err)
+	}
+}
+
+func TestLoadBreachedHashes_Invalid(t *testing.T) {
+	f, err := ioutil.TempFile("", "breached")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("not a hash\n")
+	f.Close()
+
+	if _, err := passwords.LoadBreachedHashes(f.Name()); err == nil {
+		t.Fatalf("invalid hash was accepted")
+	}
+}
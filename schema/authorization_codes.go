@@ -0,0 +1,28 @@
+package sitrep
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+)
+
+// CodeChallengeMethodS256 is the only PKCE method accepted, plain challenges
+// would leak the verifier to anybody reading the authorization request
+const CodeChallengeMethodS256 = "S256"
+
+// IsExpired reports whether the authorization code can no longer be exchanged
+func (a *AuthorizationCodes) IsExpired() bool {
+	return time.Now().After(a.ExpiresAt)
+}
+
+// VerifyCodeChallenge checks a PKCE code verifier against the challenge the
+// authorization code has been issued for
+func (a *AuthorizationCodes) VerifyCodeChallenge(verifier string) bool {
+	if a.CodeChallengeMethod != CodeChallengeMethodS256 || verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(a.CodeChallenge)) == 1
+}
@@ -0,0 +1,27 @@
+package sitrep
+
+// TokenIntrospection describes the state of a token, as defined by RFC 7662
+type TokenIntrospection struct {
+	Active        bool            `json:"active"`
+	Subject       string          `json:"sub,omitempty"`
+	ExpiresAt     int64           `json:"exp,omitempty"`
+	Scope         string          `json:"scope,omitempty"`
+	TokenType     string          `json:"token_type,omitempty"`
+	PrincipalType string          `json:"principal_type,omitempty"`
+	IsBanned      bool            `json:"is_banned,omitempty"`
+	ExerciseRoles []*ExerciseRole `json:"exercise_roles,omitempty"`
+
+	// Act names the user acting as the subject of an impersonation token
+	Act map[string]string `json:"act,omitempty"`
+}
+
+// ExerciseRole describes the permissions of a user within an exercise
+type ExerciseRole struct {
+	ExerciseID   string `json:"exercise_id"`
+	IsOc         bool   `json:"is_oc"`
+	IsAdmin      bool   `json:"is_admin"`
+	IsTrainee    bool   `json:"is_trainee"`
+	IsAuthorized bool   `json:"is_authorized"`
+	IsInvisible  bool   `json:"is_invisible"`
+	Description  string `json:"role_description"`
+}
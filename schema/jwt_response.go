@@ -1,49 +1,87 @@
 package sitrep
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/fkasper/sitrep-authentication/signing"
 )
 
+// DefaultScope is the scope of tokens issued to users
+const DefaultScope = "exercise"
+
 // JWTResponse holds the structure for an OAuth2.0 Bearer Token
 type JWTResponse struct {
-	AccessToken string `json:"access_token"`
-	Scope       string `json:"scope"`
-	TokenType   string `json:"token_type"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Scope        string `json:"scope"`
+	TokenType    string `json:"token_type"`
+	IDToken      string `json:"id_token,omitempty"`
 }
 
-// NewJwtResponse creates a new JWT response object
-func NewJwtResponse(key string, subj string) (*JWTResponse, error) {
-	accessToken, err := generateToken([]byte(key), subj)
+// NewJwtResponse creates a new JWT response object, whose access token is
+// signed with key and expires after lifetime. claims need to hold at least
+// the subject, a missing scope defaults to DefaultScope.
+func NewJwtResponse(key *signing.Key, claims map[string]interface{}, lifetime time.Duration) (*JWTResponse, error) {
+	if _, ok := claims["scope"]; !ok {
+		claims["scope"] = DefaultScope
+	}
+	accessToken, err := generateToken(key, claims, lifetime)
 	if err != nil {
 		return nil, err
 	}
 	return &JWTResponse{
 		AccessToken: accessToken,
-		Scope:       "exercise",
+		ExpiresIn:   int64(lifetime / time.Second),
+		Scope:       claims["scope"].(string),
 		TokenType:   "bearer",
 	}, nil
 }
 
-func generateToken(key []byte, subj string) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS512)
-	token.Claims["sub"] = subj
-	token.Claims["exp"] = time.Now().Add(time.Hour * 72).Unix()
-	accessToken, err := token.SignedString(key)
+func generateToken(key *signing.Key, claims map[string]interface{}, lifetime time.Duration) (string, error) {
+	claims["exp"] = time.Now().Add(lifetime).Unix()
+	accessToken, err := key.Sign(claims)
 	if err != nil {
 		return "", err
 	}
 	return accessToken, nil
 }
 
-// Verify validates a JWT token against its validity for a given key. It returns the token, if valid
-func (j *UsersByJwt) Verify() (*jwt.Token, error) {
-	token, err := jwt.Parse(j.Jwt, func(token *jwt.Token) (interface{}, error) {
-		// if _, ok := token.Method.(jwt.GetSigningMethod("HS512")); !ok {
-		//     return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		// }
-		return []byte(j.EncryptionKey), nil
+// Verify validates a JWT token against its validity for a given key. It returns the token, if valid.
+// HS512 tokens are verified with the key of the user, every other token has to be signed
+// by a key of keys with the exact algorithm the key has been created for.
+func (j *UsersByJwt) Verify(keys *signing.KeyRing) (*jwt.Token, error) {
+	return ParseJwt(j.Jwt, keys, func(claims map[string]interface{}) (string, error) {
+		return j.EncryptionKey, nil
+	})
+}
+
+// ParseJwt parses and validates a token that has not been stored. HS512
+// tokens are verified with the secret returned by hmacSecret, every other
+// token has to be signed by a key of keys.
+func ParseJwt(raw string, keys *signing.KeyRing, hmacSecret func(claims map[string]interface{}) (string, error)) (*jwt.Token, error) {
+	token, err := jwt.Parse(raw, func(token *jwt.Token) (interface{}, error) {
+		if token.Method == jwt.SigningMethodHS512 {
+			secret, err := hmacSecret(token.Claims)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(secret), nil
+		}
+		kid, _ := token.Header["kid"].(string)
+		if keys == nil || kid == "" {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		key, ok := keys.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("Unknown signing key: %v", kid)
+		}
+		if key.Method.Alg() != token.Method.Alg() {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.VerifyKey(), nil
 	})
 
 	if err == nil && token.Valid {
@@ -0,0 +1,33 @@
+package sitrep
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ValidateSecret validates a client secret against the hash, received from the Database
+func (c *OauthClients) ValidateSecret(secret string) error {
+	return bcrypt.CompareHashAndPassword([]byte(c.ClientSecretHash), []byte(secret))
+}
+
+// HashSecret stores the hash of secret on the client
+func (c *OauthClients) HashSecret(secret string) error {
+	hashedSecret, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	c.ClientSecretHash = string(hashedSecret)
+	return nil
+}
+
+// AllowsRedirectURI reports whether uri is one of the registered redirect
+// URIs of the client. URIs have to match exactly.
+func (c *OauthClients) AllowsRedirectURI(uri string) bool {
+	for _, allowed := range strings.Fields(c.RedirectUris) {
+		if allowed == uri {
+			return true
+		}
+	}
+	return false
+}
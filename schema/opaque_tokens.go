@@ -0,0 +1,31 @@
+package sitrep
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// NewOpaqueToken generates a random, url safe token. Opaque tokens carry no
+// information by themselves and have to be looked up in the database.
+func NewOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashOpaqueToken returns the representation of an opaque token, that is safe
+// to be stored in the database
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired checks whether a refresh token can still be exchanged
+func (r *RefreshTokens) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
@@ -0,0 +1,27 @@
+package sitrep
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+)
+
+// NewRecoveryCode generates a random single-use code, that is short enough to
+// be written down and typed in by hand
+func NewRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:4] + "-" + code[4:], nil
+}
+
+// HashRecoveryCode returns the representation of a recovery code, that is
+// safe to be stored in the database. Case, dashes and spaces are ignored.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.Replace(code, "-", "", -1)
+	code = strings.Replace(code, " ", "", -1)
+	return HashOpaqueToken(code)
+}
@@ -20,88 +20,222 @@ const (
 	CQLC_VERSION = "0.10.5"
 )
 
-type CreateUsersInExerciseEmailColumn struct {
+type AuditEventsDetailsColumn struct {
 }
 
-func (b *CreateUsersInExerciseEmailColumn) ColumnName() string {
-	return "email"
+func (b *AuditEventsDetailsColumn) ColumnName() string {
+	return "details"
 }
 
-func (b *CreateUsersInExerciseEmailColumn) To(value *string) cqlc.ColumnBinding {
+func (b *AuditEventsDetailsColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *CreateUsersInExerciseEmailColumn) Eq(value string) cqlc.Condition {
-	column := &CreateUsersInExerciseEmailColumn{}
+type AuditEventsEventColumn struct {
+}
+
+func (b *AuditEventsEventColumn) ColumnName() string {
+	return "event"
+}
+
+func (b *AuditEventsEventColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type AuditEventsEventIdColumn struct {
+	desc bool
+}
+
+func (b *AuditEventsEventIdColumn) ColumnName() string {
+	return "event_id"
+}
+
+func (b *AuditEventsEventIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *AuditEventsEventIdColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *AuditEventsEventIdColumn) Desc() cqlc.ClusteredColumn {
+	return &AuditEventsEventIdColumn{desc: true}
+}
+
+func (b *AuditEventsEventIdColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *AuditEventsEventIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &AuditEventsEventIdColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *CreateUsersInExerciseEmailColumn) PartitionBy() cqlc.Column {
+func (b *AuditEventsEventIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &AuditEventsEventIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *AuditEventsEventIdColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &AuditEventsEventIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *AuditEventsEventIdColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &AuditEventsEventIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *AuditEventsEventIdColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &AuditEventsEventIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *AuditEventsEventIdColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &AuditEventsEventIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type AuditEventsRemoteAddrColumn struct {
+}
+
+func (b *AuditEventsRemoteAddrColumn) ColumnName() string {
+	return "remote_addr"
+}
+
+func (b *AuditEventsRemoteAddrColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type AuditEventsUserAgentColumn struct {
+}
+
+func (b *AuditEventsUserAgentColumn) ColumnName() string {
+	return "user_agent"
+}
+
+func (b *AuditEventsUserAgentColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type AuditEventsUserEmailColumn struct {
+}
+
+func (b *AuditEventsUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *AuditEventsUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *AuditEventsUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &AuditEventsUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *AuditEventsUserEmailColumn) PartitionBy() cqlc.Column {
 	return b
 }
 
-func (b *CreateUsersInExerciseEmailColumn) In(value ...string) cqlc.Condition {
-	column := &CreateUsersInExerciseEmailColumn{}
+func (b *AuditEventsUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &AuditEventsUserEmailColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-type CreateUsersInExerciseExercisesColumn struct {
+type AuditEvents struct {
+	Details string
+
+	Event string
+
+	EventId gocql.UUID
+
+	RemoteAddr string
+
+	UserAgent string
+
+	UserEmail string
 }
 
-func (b *CreateUsersInExerciseExercisesColumn) ColumnName() string {
-	return "exercises"
+func (s *AuditEvents) DetailsValue() string {
+	return s.Details
 }
 
-func (b *CreateUsersInExerciseExercisesColumn) To(value *map[string]string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *AuditEvents) EventValue() string {
+	return s.Event
 }
 
-type CreateUsersInExercise struct {
-	Email string
+func (s *AuditEvents) EventIdValue() gocql.UUID {
+	return s.EventId
+}
 
-	Exercises map[string]string
+func (s *AuditEvents) RemoteAddrValue() string {
+	return s.RemoteAddr
 }
 
-func (s *CreateUsersInExercise) EmailValue() string {
-	return s.Email
+func (s *AuditEvents) UserAgentValue() string {
+	return s.UserAgent
 }
 
-func (s *CreateUsersInExercise) ExercisesValue() map[string]string {
-	return s.Exercises
+func (s *AuditEvents) UserEmailValue() string {
+	return s.UserEmail
 }
 
-type CreateUsersInExerciseDef struct {
-	EMAIL cqlc.LastPartitionedStringColumn
+type AuditEventsDef struct {
+	DETAILS cqlc.StringColumn
 
-	EXERCISES cqlc.StringStringMapColumn
+	EVENT cqlc.StringColumn
+
+	EVENT_ID cqlc.LastClusteredTimeUUIDColumn
+
+	REMOTE_ADDR cqlc.StringColumn
+
+	USER_AGENT cqlc.StringColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
 }
 
-func BindCreateUsersInExercise(iter *gocql.Iter) ([]CreateUsersInExercise, error) {
-	array := make([]CreateUsersInExercise, 0)
-	err := MapCreateUsersInExercise(iter, func(t CreateUsersInExercise) (bool, error) {
+func BindAuditEvents(iter *gocql.Iter) ([]AuditEvents, error) {
+	array := make([]AuditEvents, 0)
+	err := MapAuditEvents(iter, func(t AuditEvents) (bool, error) {
 		array = append(array, t)
 		return true, nil
 	})
 	return array, err
 }
 
-func MapCreateUsersInExercise(iter *gocql.Iter, callback func(t CreateUsersInExercise) (bool, error)) error {
+func MapAuditEvents(iter *gocql.Iter, callback func(t AuditEvents) (bool, error)) error {
 	columns := iter.Columns()
 	row := make([]interface{}, len(columns))
 
 	for {
-		t := CreateUsersInExercise{}
+		t := AuditEvents{}
 
 		for i := 0; i < len(columns); i++ {
 			switch columns[i].Name {
 
-			case "email":
-				row[i] = &t.Email
+			case "details":
+				row[i] = &t.Details
 
-			case "exercises":
-				row[i] = &t.Exercises
+			case "event":
+				row[i] = &t.Event
+
+			case "event_id":
+				row[i] = &t.EventId
+
+			case "remote_addr":
+				row[i] = &t.RemoteAddr
+
+			case "user_agent":
+				row[i] = &t.UserAgent
+
+			case "user_email":
+				row[i] = &t.UserEmail
 
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
@@ -123,234 +257,348 @@ func MapCreateUsersInExercise(iter *gocql.Iter, callback func(t CreateUsersInExe
 	return nil
 }
 
-func (s *CreateUsersInExerciseDef) SupportsUpsert() bool {
+func (s *AuditEventsDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *CreateUsersInExerciseDef) TableName() string {
-	return "create_users_in_exercise"
+func (s *AuditEventsDef) TableName() string {
+	return "audit_events"
 }
 
-func (s *CreateUsersInExerciseDef) Keyspace() string {
+func (s *AuditEventsDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *CreateUsersInExerciseDef) Bind(v CreateUsersInExercise) cqlc.TableBinding {
+func (s *AuditEventsDef) Bind(v AuditEvents) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &CreateUsersInExerciseEmailColumn{}, Value: v.Email},
+		cqlc.ColumnBinding{Column: &AuditEventsDetailsColumn{}, Value: v.Details},
 
-		cqlc.ColumnBinding{Column: &CreateUsersInExerciseExercisesColumn{}, Value: v.Exercises},
+		cqlc.ColumnBinding{Column: &AuditEventsEventColumn{}, Value: v.Event},
+
+		cqlc.ColumnBinding{Column: &AuditEventsEventIdColumn{}, Value: v.EventId},
+
+		cqlc.ColumnBinding{Column: &AuditEventsRemoteAddrColumn{}, Value: v.RemoteAddr},
+
+		cqlc.ColumnBinding{Column: &AuditEventsUserAgentColumn{}, Value: v.UserAgent},
+
+		cqlc.ColumnBinding{Column: &AuditEventsUserEmailColumn{}, Value: v.UserEmail},
 	}
-	return cqlc.TableBinding{Table: &CreateUsersInExerciseDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &AuditEventsDef{}, Columns: cols}
 }
 
-func (s *CreateUsersInExerciseDef) To(v *CreateUsersInExercise) cqlc.TableBinding {
+func (s *AuditEventsDef) To(v *AuditEvents) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &CreateUsersInExerciseEmailColumn{}, Value: &v.Email},
+		cqlc.ColumnBinding{Column: &AuditEventsDetailsColumn{}, Value: &v.Details},
 
-		cqlc.ColumnBinding{Column: &CreateUsersInExerciseExercisesColumn{}, Value: &v.Exercises},
+		cqlc.ColumnBinding{Column: &AuditEventsEventColumn{}, Value: &v.Event},
+
+		cqlc.ColumnBinding{Column: &AuditEventsEventIdColumn{}, Value: &v.EventId},
+
+		cqlc.ColumnBinding{Column: &AuditEventsRemoteAddrColumn{}, Value: &v.RemoteAddr},
+
+		cqlc.ColumnBinding{Column: &AuditEventsUserAgentColumn{}, Value: &v.UserAgent},
+
+		cqlc.ColumnBinding{Column: &AuditEventsUserEmailColumn{}, Value: &v.UserEmail},
 	}
-	return cqlc.TableBinding{Table: &CreateUsersInExerciseDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &AuditEventsDef{}, Columns: cols}
 }
 
-func (s *CreateUsersInExerciseDef) ColumnDefinitions() []cqlc.Column {
+func (s *AuditEventsDef) ColumnDefinitions() []cqlc.Column {
 	return []cqlc.Column{
 
-		&CreateUsersInExerciseEmailColumn{},
+		&AuditEventsDetailsColumn{},
 
-		&CreateUsersInExerciseExercisesColumn{},
+		&AuditEventsEventColumn{},
+
+		&AuditEventsEventIdColumn{},
+
+		&AuditEventsRemoteAddrColumn{},
+
+		&AuditEventsUserAgentColumn{},
+
+		&AuditEventsUserEmailColumn{},
 	}
 }
 
-func CreateUsersInExerciseTableDef() *CreateUsersInExerciseDef {
-	return &CreateUsersInExerciseDef{
+func AuditEventsTableDef() *AuditEventsDef {
+	return &AuditEventsDef{
 
-		EMAIL: &CreateUsersInExerciseEmailColumn{},
+		DETAILS: &AuditEventsDetailsColumn{},
 
-		EXERCISES: &CreateUsersInExerciseExercisesColumn{},
+		EVENT: &AuditEventsEventColumn{},
+
+		EVENT_ID: &AuditEventsEventIdColumn{},
+
+		REMOTE_ADDR: &AuditEventsRemoteAddrColumn{},
+
+		USER_AGENT: &AuditEventsUserAgentColumn{},
+
+		USER_EMAIL: &AuditEventsUserEmailColumn{},
 	}
 }
 
-func (s *CreateUsersInExerciseDef) EmailColumn() cqlc.LastPartitionedStringColumn {
-	return &CreateUsersInExerciseEmailColumn{}
+func (s *AuditEventsDef) DetailsColumn() cqlc.StringColumn {
+	return &AuditEventsDetailsColumn{}
 }
 
-func (s *CreateUsersInExerciseDef) ExercisesColumn() cqlc.StringStringMapColumn {
-	return &CreateUsersInExerciseExercisesColumn{}
+func (s *AuditEventsDef) EventColumn() cqlc.StringColumn {
+	return &AuditEventsEventColumn{}
 }
 
-type ExerciseByIdentifierActiveUntilColumn struct {
+func (s *AuditEventsDef) EventIdColumn() cqlc.LastClusteredTimeUUIDColumn {
+	return &AuditEventsEventIdColumn{}
 }
 
-func (b *ExerciseByIdentifierActiveUntilColumn) ColumnName() string {
-	return "active_until"
+func (s *AuditEventsDef) RemoteAddrColumn() cqlc.StringColumn {
+	return &AuditEventsRemoteAddrColumn{}
 }
 
-func (b *ExerciseByIdentifierActiveUntilColumn) To(value *time.Time) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *AuditEventsDef) UserAgentColumn() cqlc.StringColumn {
+	return &AuditEventsUserAgentColumn{}
 }
 
-type ExerciseByIdentifierExerciseDescriptionColumn struct {
+func (s *AuditEventsDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &AuditEventsUserEmailColumn{}
 }
 
-func (b *ExerciseByIdentifierExerciseDescriptionColumn) ColumnName() string {
-	return "exercise_description"
+type AuthorizationCodesClientIdColumn struct {
 }
 
-func (b *ExerciseByIdentifierExerciseDescriptionColumn) To(value *string) cqlc.ColumnBinding {
+func (b *AuthorizationCodesClientIdColumn) ColumnName() string {
+	return "client_id"
+}
+
+func (b *AuthorizationCodesClientIdColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExerciseByIdentifierExerciseNameColumn struct {
+type AuthorizationCodesCodeChallengeColumn struct {
 }
 
-func (b *ExerciseByIdentifierExerciseNameColumn) ColumnName() string {
-	return "exercise_name"
+func (b *AuthorizationCodesCodeChallengeColumn) ColumnName() string {
+	return "code_challenge"
 }
 
-func (b *ExerciseByIdentifierExerciseNameColumn) To(value *string) cqlc.ColumnBinding {
+func (b *AuthorizationCodesCodeChallengeColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExerciseByIdentifierHasActivationColumn struct {
+type AuthorizationCodesCodeChallengeMethodColumn struct {
 }
 
-func (b *ExerciseByIdentifierHasActivationColumn) ColumnName() string {
-	return "has_activation"
+func (b *AuthorizationCodesCodeChallengeMethodColumn) ColumnName() string {
+	return "code_challenge_method"
 }
 
-func (b *ExerciseByIdentifierHasActivationColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *AuthorizationCodesCodeChallengeMethodColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExerciseByIdentifierIdColumn struct {
+type AuthorizationCodesCodeHashColumn struct {
 }
 
-func (b *ExerciseByIdentifierIdColumn) ColumnName() string {
-	return "id"
+func (b *AuthorizationCodesCodeHashColumn) ColumnName() string {
+	return "code_hash"
 }
 
-func (b *ExerciseByIdentifierIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+func (b *AuthorizationCodesCodeHashColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *ExerciseByIdentifierIdColumn) Eq(value gocql.UUID) cqlc.Condition {
-	column := &ExerciseByIdentifierIdColumn{}
+func (b *AuthorizationCodesCodeHashColumn) Eq(value string) cqlc.Condition {
+	column := &AuthorizationCodesCodeHashColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *ExerciseByIdentifierIdColumn) PartitionBy() cqlc.Column {
+func (b *AuthorizationCodesCodeHashColumn) PartitionBy() cqlc.Column {
 	return b
 }
 
-func (b *ExerciseByIdentifierIdColumn) In(value ...gocql.UUID) cqlc.Condition {
-	column := &ExerciseByIdentifierIdColumn{}
+func (b *AuthorizationCodesCodeHashColumn) In(value ...string) cqlc.Condition {
+	column := &AuthorizationCodesCodeHashColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-type ExerciseByIdentifierIsActiveColumn struct {
+type AuthorizationCodesExpiresAtColumn struct {
 }
 
-func (b *ExerciseByIdentifierIsActiveColumn) ColumnName() string {
-	return "is_active"
+func (b *AuthorizationCodesExpiresAtColumn) ColumnName() string {
+	return "expires_at"
 }
 
-func (b *ExerciseByIdentifierIsActiveColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *AuthorizationCodesExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExerciseByIdentifier struct {
-	ActiveUntil time.Time
+type AuthorizationCodesNonceColumn struct {
+}
 
-	ExerciseDescription string
+func (b *AuthorizationCodesNonceColumn) ColumnName() string {
+	return "nonce"
+}
 
-	ExerciseName string
+func (b *AuthorizationCodesNonceColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-	HasActivation bool
+type AuthorizationCodesRedirectUriColumn struct {
+}
 
-	Id gocql.UUID
+func (b *AuthorizationCodesRedirectUriColumn) ColumnName() string {
+	return "redirect_uri"
+}
 
-	IsActive bool
+func (b *AuthorizationCodesRedirectUriColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *ExerciseByIdentifier) ActiveUntilValue() time.Time {
-	return s.ActiveUntil
+type AuthorizationCodesScopeColumn struct {
 }
 
-func (s *ExerciseByIdentifier) ExerciseDescriptionValue() string {
-	return s.ExerciseDescription
+func (b *AuthorizationCodesScopeColumn) ColumnName() string {
+	return "scope"
 }
 
-func (s *ExerciseByIdentifier) ExerciseNameValue() string {
-	return s.ExerciseName
+func (b *AuthorizationCodesScopeColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *ExerciseByIdentifier) HasActivationValue() bool {
-	return s.HasActivation
+type AuthorizationCodesUserEmailColumn struct {
 }
 
-func (s *ExerciseByIdentifier) IdValue() gocql.UUID {
-	return s.Id
+func (b *AuthorizationCodesUserEmailColumn) ColumnName() string {
+	return "user_email"
 }
 
-func (s *ExerciseByIdentifier) IsActiveValue() bool {
-	return s.IsActive
+func (b *AuthorizationCodesUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExerciseByIdentifierDef struct {
-	ACTIVE_UNTIL cqlc.TimestampColumn
+type AuthorizationCodes struct {
+	ClientId string
 
-	EXERCISE_DESCRIPTION cqlc.StringColumn
+	CodeChallenge string
 
-	EXERCISE_NAME cqlc.StringColumn
+	CodeChallengeMethod string
 
-	HAS_ACTIVATION cqlc.BooleanColumn
+	CodeHash string
 
-	ID cqlc.LastPartitionedUUIDColumn
+	ExpiresAt time.Time
 
-	IS_ACTIVE cqlc.BooleanColumn
+	Nonce string
+
+	RedirectUri string
+
+	Scope string
+
+	UserEmail string
 }
 
-func BindExerciseByIdentifier(iter *gocql.Iter) ([]ExerciseByIdentifier, error) {
-	array := make([]ExerciseByIdentifier, 0)
-	err := MapExerciseByIdentifier(iter, func(t ExerciseByIdentifier) (bool, error) {
-		array = append(array, t)
-		return true, nil
-	})
-	return array, err
+func (s *AuthorizationCodes) ClientIdValue() string {
+	return s.ClientId
 }
 
-func MapExerciseByIdentifier(iter *gocql.Iter, callback func(t ExerciseByIdentifier) (bool, error)) error {
-	columns := iter.Columns()
-	row := make([]interface{}, len(columns))
+func (s *AuthorizationCodes) CodeChallengeValue() string {
+	return s.CodeChallenge
+}
 
-	for {
-		t := ExerciseByIdentifier{}
+func (s *AuthorizationCodes) CodeChallengeMethodValue() string {
+	return s.CodeChallengeMethod
+}
 
-		for i := 0; i < len(columns); i++ {
-			switch columns[i].Name {
+func (s *AuthorizationCodes) CodeHashValue() string {
+	return s.CodeHash
+}
 
-			case "active_until":
-				row[i] = &t.ActiveUntil
+func (s *AuthorizationCodes) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
 
-			case "exercise_description":
-				row[i] = &t.ExerciseDescription
+func (s *AuthorizationCodes) NonceValue() string {
+	return s.Nonce
+}
 
-			case "exercise_name":
-				row[i] = &t.ExerciseName
+func (s *AuthorizationCodes) RedirectUriValue() string {
+	return s.RedirectUri
+}
 
-			case "has_activation":
-				row[i] = &t.HasActivation
+func (s *AuthorizationCodes) ScopeValue() string {
+	return s.Scope
+}
 
-			case "id":
-				row[i] = &t.Id
+func (s *AuthorizationCodes) UserEmailValue() string {
+	return s.UserEmail
+}
 
-			case "is_active":
-				row[i] = &t.IsActive
+type AuthorizationCodesDef struct {
+	CLIENT_ID cqlc.StringColumn
+
+	CODE_CHALLENGE cqlc.StringColumn
+
+	CODE_CHALLENGE_METHOD cqlc.StringColumn
+
+	CODE_HASH cqlc.LastPartitionedStringColumn
+
+	EXPIRES_AT cqlc.TimestampColumn
+
+	NONCE cqlc.StringColumn
+
+	REDIRECT_URI cqlc.StringColumn
+
+	SCOPE cqlc.StringColumn
+
+	USER_EMAIL cqlc.StringColumn
+}
+
+func BindAuthorizationCodes(iter *gocql.Iter) ([]AuthorizationCodes, error) {
+	array := make([]AuthorizationCodes, 0)
+	err := MapAuthorizationCodes(iter, func(t AuthorizationCodes) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapAuthorizationCodes(iter *gocql.Iter, callback func(t AuthorizationCodes) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := AuthorizationCodes{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "client_id":
+				row[i] = &t.ClientId
+
+			case "code_challenge":
+				row[i] = &t.CodeChallenge
+
+			case "code_challenge_method":
+				row[i] = &t.CodeChallengeMethod
+
+			case "code_hash":
+				row[i] = &t.CodeHash
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "nonce":
+				row[i] = &t.Nonce
+
+			case "redirect_uri":
+				row[i] = &t.RedirectUri
+
+			case "scope":
+				row[i] = &t.Scope
+
+			case "user_email":
+				row[i] = &t.UserEmail
 
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
@@ -372,305 +620,480 @@ func MapExerciseByIdentifier(iter *gocql.Iter, callback func(t ExerciseByIdentif
 	return nil
 }
 
-func (s *ExerciseByIdentifierDef) SupportsUpsert() bool {
+func (s *AuthorizationCodesDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *ExerciseByIdentifierDef) TableName() string {
-	return "exercise_by_identifier"
+func (s *AuthorizationCodesDef) TableName() string {
+	return "authorization_codes"
 }
 
-func (s *ExerciseByIdentifierDef) Keyspace() string {
+func (s *AuthorizationCodesDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *ExerciseByIdentifierDef) Bind(v ExerciseByIdentifier) cqlc.TableBinding {
+func (s *AuthorizationCodesDef) Bind(v AuthorizationCodes) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierActiveUntilColumn{}, Value: v.ActiveUntil},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesClientIdColumn{}, Value: v.ClientId},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseDescriptionColumn{}, Value: v.ExerciseDescription},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesCodeChallengeColumn{}, Value: v.CodeChallenge},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseNameColumn{}, Value: v.ExerciseName},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesCodeChallengeMethodColumn{}, Value: v.CodeChallengeMethod},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierHasActivationColumn{}, Value: v.HasActivation},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesCodeHashColumn{}, Value: v.CodeHash},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIdColumn{}, Value: v.Id},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesExpiresAtColumn{}, Value: v.ExpiresAt},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIsActiveColumn{}, Value: v.IsActive},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesNonceColumn{}, Value: v.Nonce},
+
+		cqlc.ColumnBinding{Column: &AuthorizationCodesRedirectUriColumn{}, Value: v.RedirectUri},
+
+		cqlc.ColumnBinding{Column: &AuthorizationCodesScopeColumn{}, Value: v.Scope},
+
+		cqlc.ColumnBinding{Column: &AuthorizationCodesUserEmailColumn{}, Value: v.UserEmail},
 	}
-	return cqlc.TableBinding{Table: &ExerciseByIdentifierDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &AuthorizationCodesDef{}, Columns: cols}
 }
 
-func (s *ExerciseByIdentifierDef) To(v *ExerciseByIdentifier) cqlc.TableBinding {
+func (s *AuthorizationCodesDef) To(v *AuthorizationCodes) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierActiveUntilColumn{}, Value: &v.ActiveUntil},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesClientIdColumn{}, Value: &v.ClientId},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseDescriptionColumn{}, Value: &v.ExerciseDescription},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesCodeChallengeColumn{}, Value: &v.CodeChallenge},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseNameColumn{}, Value: &v.ExerciseName},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesCodeChallengeMethodColumn{}, Value: &v.CodeChallengeMethod},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierHasActivationColumn{}, Value: &v.HasActivation},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesCodeHashColumn{}, Value: &v.CodeHash},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIdColumn{}, Value: &v.Id},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesExpiresAtColumn{}, Value: &v.ExpiresAt},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIsActiveColumn{}, Value: &v.IsActive},
+		cqlc.ColumnBinding{Column: &AuthorizationCodesNonceColumn{}, Value: &v.Nonce},
+
+		cqlc.ColumnBinding{Column: &AuthorizationCodesRedirectUriColumn{}, Value: &v.RedirectUri},
+
+		cqlc.ColumnBinding{Column: &AuthorizationCodesScopeColumn{}, Value: &v.Scope},
+
+		cqlc.ColumnBinding{Column: &AuthorizationCodesUserEmailColumn{}, Value: &v.UserEmail},
 	}
-	return cqlc.TableBinding{Table: &ExerciseByIdentifierDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &AuthorizationCodesDef{}, Columns: cols}
 }
 
-func (s *ExerciseByIdentifierDef) ColumnDefinitions() []cqlc.Column {
+func (s *AuthorizationCodesDef) ColumnDefinitions() []cqlc.Column {
 	return []cqlc.Column{
 
-		&ExerciseByIdentifierActiveUntilColumn{},
+		&AuthorizationCodesClientIdColumn{},
 
-		&ExerciseByIdentifierExerciseDescriptionColumn{},
+		&AuthorizationCodesCodeChallengeColumn{},
 
-		&ExerciseByIdentifierExerciseNameColumn{},
+		&AuthorizationCodesCodeChallengeMethodColumn{},
 
-		&ExerciseByIdentifierHasActivationColumn{},
+		&AuthorizationCodesCodeHashColumn{},
 
-		&ExerciseByIdentifierIdColumn{},
+		&AuthorizationCodesExpiresAtColumn{},
 
-		&ExerciseByIdentifierIsActiveColumn{},
+		&AuthorizationCodesNonceColumn{},
+
+		&AuthorizationCodesRedirectUriColumn{},
+
+		&AuthorizationCodesScopeColumn{},
+
+		&AuthorizationCodesUserEmailColumn{},
 	}
 }
 
-func ExerciseByIdentifierTableDef() *ExerciseByIdentifierDef {
-	return &ExerciseByIdentifierDef{
+func AuthorizationCodesTableDef() *AuthorizationCodesDef {
+	return &AuthorizationCodesDef{
 
-		ACTIVE_UNTIL: &ExerciseByIdentifierActiveUntilColumn{},
+		CLIENT_ID: &AuthorizationCodesClientIdColumn{},
 
-		EXERCISE_DESCRIPTION: &ExerciseByIdentifierExerciseDescriptionColumn{},
+		CODE_CHALLENGE: &AuthorizationCodesCodeChallengeColumn{},
 
-		EXERCISE_NAME: &ExerciseByIdentifierExerciseNameColumn{},
+		CODE_CHALLENGE_METHOD: &AuthorizationCodesCodeChallengeMethodColumn{},
 
-		HAS_ACTIVATION: &ExerciseByIdentifierHasActivationColumn{},
+		CODE_HASH: &AuthorizationCodesCodeHashColumn{},
 
-		ID: &ExerciseByIdentifierIdColumn{},
+		EXPIRES_AT: &AuthorizationCodesExpiresAtColumn{},
 
-		IS_ACTIVE: &ExerciseByIdentifierIsActiveColumn{},
-	}
-}
+		NONCE: &AuthorizationCodesNonceColumn{},
 
-func (s *ExerciseByIdentifierDef) ActiveUntilColumn() cqlc.TimestampColumn {
-	return &ExerciseByIdentifierActiveUntilColumn{}
-}
+		REDIRECT_URI: &AuthorizationCodesRedirectUriColumn{},
 
-func (s *ExerciseByIdentifierDef) ExerciseDescriptionColumn() cqlc.StringColumn {
-	return &ExerciseByIdentifierExerciseDescriptionColumn{}
-}
+		SCOPE: &AuthorizationCodesScopeColumn{},
 
-func (s *ExerciseByIdentifierDef) ExerciseNameColumn() cqlc.StringColumn {
-	return &ExerciseByIdentifierExerciseNameColumn{}
+		USER_EMAIL: &AuthorizationCodesUserEmailColumn{},
+	}
 }
 
-func (s *ExerciseByIdentifierDef) HasActivationColumn() cqlc.BooleanColumn {
-	return &ExerciseByIdentifierHasActivationColumn{}
+func (s *AuthorizationCodesDef) ClientIdColumn() cqlc.StringColumn {
+	return &AuthorizationCodesClientIdColumn{}
 }
 
-func (s *ExerciseByIdentifierDef) IdColumn() cqlc.LastPartitionedUUIDColumn {
-	return &ExerciseByIdentifierIdColumn{}
+func (s *AuthorizationCodesDef) CodeChallengeColumn() cqlc.StringColumn {
+	return &AuthorizationCodesCodeChallengeColumn{}
 }
 
-func (s *ExerciseByIdentifierDef) IsActiveColumn() cqlc.BooleanColumn {
-	return &ExerciseByIdentifierIsActiveColumn{}
+func (s *AuthorizationCodesDef) CodeChallengeMethodColumn() cqlc.StringColumn {
+	return &AuthorizationCodesCodeChallengeMethodColumn{}
 }
 
-type ExerciseByIdentifierAndEmailEmailColumn struct {
+func (s *AuthorizationCodesDef) CodeHashColumn() cqlc.LastPartitionedStringColumn {
+	return &AuthorizationCodesCodeHashColumn{}
 }
 
-func (b *ExerciseByIdentifierAndEmailEmailColumn) ColumnName() string {
-	return "email"
+func (s *AuthorizationCodesDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &AuthorizationCodesExpiresAtColumn{}
 }
 
-func (b *ExerciseByIdentifierAndEmailEmailColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *AuthorizationCodesDef) NonceColumn() cqlc.StringColumn {
+	return &AuthorizationCodesNonceColumn{}
 }
 
-type ExerciseByIdentifierAndEmailExerciseNameColumn struct {
+func (s *AuthorizationCodesDef) RedirectUriColumn() cqlc.StringColumn {
+	return &AuthorizationCodesRedirectUriColumn{}
 }
 
-func (b *ExerciseByIdentifierAndEmailExerciseNameColumn) ColumnName() string {
-	return "exercise_name"
+func (s *AuthorizationCodesDef) ScopeColumn() cqlc.StringColumn {
+	return &AuthorizationCodesScopeColumn{}
 }
 
-func (b *ExerciseByIdentifierAndEmailExerciseNameColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *AuthorizationCodesDef) UserEmailColumn() cqlc.StringColumn {
+	return &AuthorizationCodesUserEmailColumn{}
 }
 
-type ExerciseByIdentifierAndEmailIdColumn struct {
+type CreateUsersInExerciseEmailColumn struct {
 }
 
-func (b *ExerciseByIdentifierAndEmailIdColumn) ColumnName() string {
-	return "id"
+func (b *CreateUsersInExerciseEmailColumn) ColumnName() string {
+	return "email"
 }
 
-func (b *ExerciseByIdentifierAndEmailIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+func (b *CreateUsersInExerciseEmailColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *ExerciseByIdentifierAndEmailIdColumn) Eq(value gocql.UUID) cqlc.Condition {
-	column := &ExerciseByIdentifierAndEmailIdColumn{}
+func (b *CreateUsersInExerciseEmailColumn) Eq(value string) cqlc.Condition {
+	column := &CreateUsersInExerciseEmailColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *ExerciseByIdentifierAndEmailIdColumn) PartitionBy() cqlc.Column {
+func (b *CreateUsersInExerciseEmailColumn) PartitionBy() cqlc.Column {
 	return b
 }
 
-func (b *ExerciseByIdentifierAndEmailIdColumn) In(value ...gocql.UUID) cqlc.Condition {
-	column := &ExerciseByIdentifierAndEmailIdColumn{}
+func (b *CreateUsersInExerciseEmailColumn) In(value ...string) cqlc.Condition {
+	column := &CreateUsersInExerciseEmailColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-type ExerciseByIdentifierAndEmailIsActiveColumn struct {
+type CreateUsersInExerciseExercisesColumn struct {
 }
 
-func (b *ExerciseByIdentifierAndEmailIsActiveColumn) ColumnName() string {
-	return "is_active"
+func (b *CreateUsersInExerciseExercisesColumn) ColumnName() string {
+	return "exercises"
 }
 
-func (b *ExerciseByIdentifierAndEmailIsActiveColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *CreateUsersInExerciseExercisesColumn) To(value *map[string]string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExerciseByIdentifierAndEmailIsAuthorizedColumn struct {
-}
-
-func (b *ExerciseByIdentifierAndEmailIsAuthorizedColumn) ColumnName() string {
-	return "is_authorized"
-}
+type CreateUsersInExercise struct {
+	Email string
 
-func (b *ExerciseByIdentifierAndEmailIsAuthorizedColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+	Exercises map[string]string
 }
 
-type ExerciseByIdentifierAndEmailIsOperatorColumn struct {
+func (s *CreateUsersInExercise) EmailValue() string {
+	return s.Email
 }
 
-func (b *ExerciseByIdentifierAndEmailIsOperatorColumn) ColumnName() string {
-	return "is_operator"
+func (s *CreateUsersInExercise) ExercisesValue() map[string]string {
+	return s.Exercises
 }
 
-func (b *ExerciseByIdentifierAndEmailIsOperatorColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
+type CreateUsersInExerciseDef struct {
+	EMAIL cqlc.LastPartitionedStringColumn
 
-type ExerciseByIdentifierAndEmailUserNameColumn struct {
+	EXERCISES cqlc.StringStringMapColumn
 }
 
-func (b *ExerciseByIdentifierAndEmailUserNameColumn) ColumnName() string {
-	return "user_name"
+func BindCreateUsersInExercise(iter *gocql.Iter) ([]CreateUsersInExercise, error) {
+	array := make([]CreateUsersInExercise, 0)
+	err := MapCreateUsersInExercise(iter, func(t CreateUsersInExercise) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
 }
 
-func (b *ExerciseByIdentifierAndEmailUserNameColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
+func MapCreateUsersInExercise(iter *gocql.Iter, callback func(t CreateUsersInExercise) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
 
-type ExerciseByIdentifierAndEmail struct {
-	Email string
+	for {
+		t := CreateUsersInExercise{}
 
-	ExerciseName string
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
 
-	Id gocql.UUID
+			case "email":
+				row[i] = &t.Email
 
-	IsActive bool
+			case "exercises":
+				row[i] = &t.Exercises
 
-	IsAuthorized bool
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
 
-	IsOperator bool
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
 
-	UserName string
+	return nil
 }
 
-func (s *ExerciseByIdentifierAndEmail) EmailValue() string {
-	return s.Email
+func (s *CreateUsersInExerciseDef) SupportsUpsert() bool {
+	return true
 }
 
-func (s *ExerciseByIdentifierAndEmail) ExerciseNameValue() string {
-	return s.ExerciseName
+func (s *CreateUsersInExerciseDef) TableName() string {
+	return "create_users_in_exercise"
 }
 
-func (s *ExerciseByIdentifierAndEmail) IdValue() gocql.UUID {
-	return s.Id
+func (s *CreateUsersInExerciseDef) Keyspace() string {
+	return "sitrep"
 }
 
-func (s *ExerciseByIdentifierAndEmail) IsActiveValue() bool {
-	return s.IsActive
-}
+func (s *CreateUsersInExerciseDef) Bind(v CreateUsersInExercise) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
 
-func (s *ExerciseByIdentifierAndEmail) IsAuthorizedValue() bool {
-	return s.IsAuthorized
-}
+		cqlc.ColumnBinding{Column: &CreateUsersInExerciseEmailColumn{}, Value: v.Email},
 
-func (s *ExerciseByIdentifierAndEmail) IsOperatorValue() bool {
-	return s.IsOperator
+		cqlc.ColumnBinding{Column: &CreateUsersInExerciseExercisesColumn{}, Value: v.Exercises},
+	}
+	return cqlc.TableBinding{Table: &CreateUsersInExerciseDef{}, Columns: cols}
 }
 
-func (s *ExerciseByIdentifierAndEmail) UserNameValue() string {
-	return s.UserName
-}
+func (s *CreateUsersInExerciseDef) To(v *CreateUsersInExercise) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
 
-type ExerciseByIdentifierAndEmailDef struct {
-	EMAIL cqlc.StringColumn
+		cqlc.ColumnBinding{Column: &CreateUsersInExerciseEmailColumn{}, Value: &v.Email},
 
-	EXERCISE_NAME cqlc.StringColumn
+		cqlc.ColumnBinding{Column: &CreateUsersInExerciseExercisesColumn{}, Value: &v.Exercises},
+	}
+	return cqlc.TableBinding{Table: &CreateUsersInExerciseDef{}, Columns: cols}
+}
 
-	ID cqlc.LastPartitionedUUIDColumn
+func (s *CreateUsersInExerciseDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
 
-	IS_ACTIVE cqlc.BooleanColumn
+		&CreateUsersInExerciseEmailColumn{},
 
-	IS_AUTHORIZED cqlc.BooleanColumn
+		&CreateUsersInExerciseExercisesColumn{},
+	}
+}
 
-	IS_OPERATOR cqlc.BooleanColumn
+func CreateUsersInExerciseTableDef() *CreateUsersInExerciseDef {
+	return &CreateUsersInExerciseDef{
 
-	USER_NAME cqlc.StringColumn
+		EMAIL: &CreateUsersInExerciseEmailColumn{},
+
+		EXERCISES: &CreateUsersInExerciseExercisesColumn{},
+	}
 }
 
-func BindExerciseByIdentifierAndEmail(iter *gocql.Iter) ([]ExerciseByIdentifierAndEmail, error) {
-	array := make([]ExerciseByIdentifierAndEmail, 0)
-	err := MapExerciseByIdentifierAndEmail(iter, func(t ExerciseByIdentifierAndEmail) (bool, error) {
+func (s *CreateUsersInExerciseDef) EmailColumn() cqlc.LastPartitionedStringColumn {
+	return &CreateUsersInExerciseEmailColumn{}
+}
+
+func (s *CreateUsersInExerciseDef) ExercisesColumn() cqlc.StringStringMapColumn {
+	return &CreateUsersInExerciseExercisesColumn{}
+}
+
+type ExerciseByIdentifierActiveUntilColumn struct {
+}
+
+func (b *ExerciseByIdentifierActiveUntilColumn) ColumnName() string {
+	return "active_until"
+}
+
+func (b *ExerciseByIdentifierActiveUntilColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExerciseByIdentifierExerciseDescriptionColumn struct {
+}
+
+func (b *ExerciseByIdentifierExerciseDescriptionColumn) ColumnName() string {
+	return "exercise_description"
+}
+
+func (b *ExerciseByIdentifierExerciseDescriptionColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExerciseByIdentifierExerciseNameColumn struct {
+}
+
+func (b *ExerciseByIdentifierExerciseNameColumn) ColumnName() string {
+	return "exercise_name"
+}
+
+func (b *ExerciseByIdentifierExerciseNameColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExerciseByIdentifierHasActivationColumn struct {
+}
+
+func (b *ExerciseByIdentifierHasActivationColumn) ColumnName() string {
+	return "has_activation"
+}
+
+func (b *ExerciseByIdentifierHasActivationColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExerciseByIdentifierIdColumn struct {
+}
+
+func (b *ExerciseByIdentifierIdColumn) ColumnName() string {
+	return "id"
+}
+
+func (b *ExerciseByIdentifierIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *ExerciseByIdentifierIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &ExerciseByIdentifierIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *ExerciseByIdentifierIdColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *ExerciseByIdentifierIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &ExerciseByIdentifierIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type ExerciseByIdentifierIsActiveColumn struct {
+}
+
+func (b *ExerciseByIdentifierIsActiveColumn) ColumnName() string {
+	return "is_active"
+}
+
+func (b *ExerciseByIdentifierIsActiveColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExerciseByIdentifier struct {
+	ActiveUntil time.Time
+
+	ExerciseDescription string
+
+	ExerciseName string
+
+	HasActivation bool
+
+	Id gocql.UUID
+
+	IsActive bool
+}
+
+func (s *ExerciseByIdentifier) ActiveUntilValue() time.Time {
+	return s.ActiveUntil
+}
+
+func (s *ExerciseByIdentifier) ExerciseDescriptionValue() string {
+	return s.ExerciseDescription
+}
+
+func (s *ExerciseByIdentifier) ExerciseNameValue() string {
+	return s.ExerciseName
+}
+
+func (s *ExerciseByIdentifier) HasActivationValue() bool {
+	return s.HasActivation
+}
+
+func (s *ExerciseByIdentifier) IdValue() gocql.UUID {
+	return s.Id
+}
+
+func (s *ExerciseByIdentifier) IsActiveValue() bool {
+	return s.IsActive
+}
+
+type ExerciseByIdentifierDef struct {
+	ACTIVE_UNTIL cqlc.TimestampColumn
+
+	EXERCISE_DESCRIPTION cqlc.StringColumn
+
+	EXERCISE_NAME cqlc.StringColumn
+
+	HAS_ACTIVATION cqlc.BooleanColumn
+
+	ID cqlc.LastPartitionedUUIDColumn
+
+	IS_ACTIVE cqlc.BooleanColumn
+}
+
+func BindExerciseByIdentifier(iter *gocql.Iter) ([]ExerciseByIdentifier, error) {
+	array := make([]ExerciseByIdentifier, 0)
+	err := MapExerciseByIdentifier(iter, func(t ExerciseByIdentifier) (bool, error) {
 		array = append(array, t)
 		return true, nil
 	})
 	return array, err
 }
 
-func MapExerciseByIdentifierAndEmail(iter *gocql.Iter, callback func(t ExerciseByIdentifierAndEmail) (bool, error)) error {
+func MapExerciseByIdentifier(iter *gocql.Iter, callback func(t ExerciseByIdentifier) (bool, error)) error {
 	columns := iter.Columns()
 	row := make([]interface{}, len(columns))
 
 	for {
-		t := ExerciseByIdentifierAndEmail{}
+		t := ExerciseByIdentifier{}
 
 		for i := 0; i < len(columns); i++ {
 			switch columns[i].Name {
 
-			case "email":
-				row[i] = &t.Email
+			case "active_until":
+				row[i] = &t.ActiveUntil
+
+			case "exercise_description":
+				row[i] = &t.ExerciseDescription
 
 			case "exercise_name":
 				row[i] = &t.ExerciseName
 
+			case "has_activation":
+				row[i] = &t.HasActivation
+
 			case "id":
 				row[i] = &t.Id
 
 			case "is_active":
 				row[i] = &t.IsActive
 
-			case "is_authorized":
-				row[i] = &t.IsAuthorized
-
-			case "is_operator":
-				row[i] = &t.IsOperator
-
-			case "user_name":
-				row[i] = &t.UserName
-
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
 			}
@@ -691,384 +1114,304 @@ func MapExerciseByIdentifierAndEmail(iter *gocql.Iter, callback func(t ExerciseB
 	return nil
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) SupportsUpsert() bool {
+func (s *ExerciseByIdentifierDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) TableName() string {
-	return "exercise_by_identifier_and_email"
+func (s *ExerciseByIdentifierDef) TableName() string {
+	return "exercise_by_identifier"
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) Keyspace() string {
+func (s *ExerciseByIdentifierDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) Bind(v ExerciseByIdentifierAndEmail) cqlc.TableBinding {
+func (s *ExerciseByIdentifierDef) Bind(v ExerciseByIdentifier) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailEmailColumn{}, Value: v.Email},
-
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailExerciseNameColumn{}, Value: v.ExerciseName},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierActiveUntilColumn{}, Value: v.ActiveUntil},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIdColumn{}, Value: v.Id},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseDescriptionColumn{}, Value: v.ExerciseDescription},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsActiveColumn{}, Value: v.IsActive},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseNameColumn{}, Value: v.ExerciseName},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsAuthorizedColumn{}, Value: v.IsAuthorized},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierHasActivationColumn{}, Value: v.HasActivation},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsOperatorColumn{}, Value: v.IsOperator},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIdColumn{}, Value: v.Id},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailUserNameColumn{}, Value: v.UserName},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIsActiveColumn{}, Value: v.IsActive},
 	}
-	return cqlc.TableBinding{Table: &ExerciseByIdentifierAndEmailDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &ExerciseByIdentifierDef{}, Columns: cols}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) To(v *ExerciseByIdentifierAndEmail) cqlc.TableBinding {
+func (s *ExerciseByIdentifierDef) To(v *ExerciseByIdentifier) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailEmailColumn{}, Value: &v.Email},
-
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailExerciseNameColumn{}, Value: &v.ExerciseName},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierActiveUntilColumn{}, Value: &v.ActiveUntil},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIdColumn{}, Value: &v.Id},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseDescriptionColumn{}, Value: &v.ExerciseDescription},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsActiveColumn{}, Value: &v.IsActive},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierExerciseNameColumn{}, Value: &v.ExerciseName},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsAuthorizedColumn{}, Value: &v.IsAuthorized},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierHasActivationColumn{}, Value: &v.HasActivation},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsOperatorColumn{}, Value: &v.IsOperator},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIdColumn{}, Value: &v.Id},
 
-		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailUserNameColumn{}, Value: &v.UserName},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierIsActiveColumn{}, Value: &v.IsActive},
 	}
-	return cqlc.TableBinding{Table: &ExerciseByIdentifierAndEmailDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &ExerciseByIdentifierDef{}, Columns: cols}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) ColumnDefinitions() []cqlc.Column {
+func (s *ExerciseByIdentifierDef) ColumnDefinitions() []cqlc.Column {
 	return []cqlc.Column{
 
-		&ExerciseByIdentifierAndEmailEmailColumn{},
-
-		&ExerciseByIdentifierAndEmailExerciseNameColumn{},
+		&ExerciseByIdentifierActiveUntilColumn{},
 
-		&ExerciseByIdentifierAndEmailIdColumn{},
+		&ExerciseByIdentifierExerciseDescriptionColumn{},
 
-		&ExerciseByIdentifierAndEmailIsActiveColumn{},
+		&ExerciseByIdentifierExerciseNameColumn{},
 
-		&ExerciseByIdentifierAndEmailIsAuthorizedColumn{},
+		&ExerciseByIdentifierHasActivationColumn{},
 
-		&ExerciseByIdentifierAndEmailIsOperatorColumn{},
+		&ExerciseByIdentifierIdColumn{},
 
-		&ExerciseByIdentifierAndEmailUserNameColumn{},
+		&ExerciseByIdentifierIsActiveColumn{},
 	}
 }
 
-func ExerciseByIdentifierAndEmailTableDef() *ExerciseByIdentifierAndEmailDef {
-	return &ExerciseByIdentifierAndEmailDef{
+func ExerciseByIdentifierTableDef() *ExerciseByIdentifierDef {
+	return &ExerciseByIdentifierDef{
 
-		EMAIL: &ExerciseByIdentifierAndEmailEmailColumn{},
+		ACTIVE_UNTIL: &ExerciseByIdentifierActiveUntilColumn{},
 
-		EXERCISE_NAME: &ExerciseByIdentifierAndEmailExerciseNameColumn{},
+		EXERCISE_DESCRIPTION: &ExerciseByIdentifierExerciseDescriptionColumn{},
 
-		ID: &ExerciseByIdentifierAndEmailIdColumn{},
+		EXERCISE_NAME: &ExerciseByIdentifierExerciseNameColumn{},
 
-		IS_ACTIVE: &ExerciseByIdentifierAndEmailIsActiveColumn{},
+		HAS_ACTIVATION: &ExerciseByIdentifierHasActivationColumn{},
 
-		IS_AUTHORIZED: &ExerciseByIdentifierAndEmailIsAuthorizedColumn{},
-
-		IS_OPERATOR: &ExerciseByIdentifierAndEmailIsOperatorColumn{},
+		ID: &ExerciseByIdentifierIdColumn{},
 
-		USER_NAME: &ExerciseByIdentifierAndEmailUserNameColumn{},
+		IS_ACTIVE: &ExerciseByIdentifierIsActiveColumn{},
 	}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) EmailColumn() cqlc.StringColumn {
-	return &ExerciseByIdentifierAndEmailEmailColumn{}
-}
-
-func (s *ExerciseByIdentifierAndEmailDef) ExerciseNameColumn() cqlc.StringColumn {
-	return &ExerciseByIdentifierAndEmailExerciseNameColumn{}
+func (s *ExerciseByIdentifierDef) ActiveUntilColumn() cqlc.TimestampColumn {
+	return &ExerciseByIdentifierActiveUntilColumn{}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) IdColumn() cqlc.LastPartitionedUUIDColumn {
-	return &ExerciseByIdentifierAndEmailIdColumn{}
+func (s *ExerciseByIdentifierDef) ExerciseDescriptionColumn() cqlc.StringColumn {
+	return &ExerciseByIdentifierExerciseDescriptionColumn{}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) IsActiveColumn() cqlc.BooleanColumn {
-	return &ExerciseByIdentifierAndEmailIsActiveColumn{}
+func (s *ExerciseByIdentifierDef) ExerciseNameColumn() cqlc.StringColumn {
+	return &ExerciseByIdentifierExerciseNameColumn{}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) IsAuthorizedColumn() cqlc.BooleanColumn {
-	return &ExerciseByIdentifierAndEmailIsAuthorizedColumn{}
+func (s *ExerciseByIdentifierDef) HasActivationColumn() cqlc.BooleanColumn {
+	return &ExerciseByIdentifierHasActivationColumn{}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) IsOperatorColumn() cqlc.BooleanColumn {
-	return &ExerciseByIdentifierAndEmailIsOperatorColumn{}
+func (s *ExerciseByIdentifierDef) IdColumn() cqlc.LastPartitionedUUIDColumn {
+	return &ExerciseByIdentifierIdColumn{}
 }
 
-func (s *ExerciseByIdentifierAndEmailDef) UserNameColumn() cqlc.StringColumn {
-	return &ExerciseByIdentifierAndEmailUserNameColumn{}
+func (s *ExerciseByIdentifierDef) IsActiveColumn() cqlc.BooleanColumn {
+	return &ExerciseByIdentifierIsActiveColumn{}
 }
 
-type ExercisePermissionsLevelExerciseIdentifierColumn struct {
-	desc bool
+type ExerciseByIdentifierAndEmailEmailColumn struct {
 }
 
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) ColumnName() string {
-	return "exercise_identifier"
+func (b *ExerciseByIdentifierAndEmailEmailColumn) ColumnName() string {
+	return "email"
 }
 
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailEmailColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) ClusterWith() string {
-	return b.ColumnName()
-}
-
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Desc() cqlc.ClusteredColumn {
-	return &ExercisePermissionsLevelExerciseIdentifierColumn{desc: true}
-}
-
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) IsDescending() bool {
-	return b.desc
-}
-
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Eq(value gocql.UUID) cqlc.Condition {
-	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
-}
-
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) In(value ...gocql.UUID) cqlc.Condition {
-	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
-}
-
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Gt(value gocql.UUID) cqlc.Condition {
-	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
-}
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Ge(value gocql.UUID) cqlc.Condition {
-	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
-}
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Lt(value gocql.UUID) cqlc.Condition {
-	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
-}
-func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Le(value gocql.UUID) cqlc.Condition {
-	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
-}
-
-type ExercisePermissionsLevelIsAdminColumn struct {
+type ExerciseByIdentifierAndEmailExerciseNameColumn struct {
 }
 
-func (b *ExercisePermissionsLevelIsAdminColumn) ColumnName() string {
-	return "is_admin"
+func (b *ExerciseByIdentifierAndEmailExerciseNameColumn) ColumnName() string {
+	return "exercise_name"
 }
 
-func (b *ExercisePermissionsLevelIsAdminColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailExerciseNameColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExercisePermissionsLevelIsAuthorizedColumn struct {
+type ExerciseByIdentifierAndEmailIdColumn struct {
 }
 
-func (b *ExercisePermissionsLevelIsAuthorizedColumn) ColumnName() string {
-	return "is_authorized"
+func (b *ExerciseByIdentifierAndEmailIdColumn) ColumnName() string {
+	return "id"
 }
 
-func (b *ExercisePermissionsLevelIsAuthorizedColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExercisePermissionsLevelIsInvisibleColumn struct {
+func (b *ExerciseByIdentifierAndEmailIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &ExerciseByIdentifierAndEmailIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *ExercisePermissionsLevelIsInvisibleColumn) ColumnName() string {
-	return "is_invisible"
+func (b *ExerciseByIdentifierAndEmailIdColumn) PartitionBy() cqlc.Column {
+	return b
 }
 
-func (b *ExercisePermissionsLevelIsInvisibleColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (b *ExerciseByIdentifierAndEmailIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &ExerciseByIdentifierAndEmailIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-type ExercisePermissionsLevelIsOcColumn struct {
+type ExerciseByIdentifierAndEmailIsActiveColumn struct {
 }
 
-func (b *ExercisePermissionsLevelIsOcColumn) ColumnName() string {
-	return "is_oc"
+func (b *ExerciseByIdentifierAndEmailIsActiveColumn) ColumnName() string {
+	return "is_active"
 }
 
-func (b *ExercisePermissionsLevelIsOcColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailIsActiveColumn) To(value *bool) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExercisePermissionsLevelIsTraineeColumn struct {
+type ExerciseByIdentifierAndEmailIsAuthorizedColumn struct {
 }
 
-func (b *ExercisePermissionsLevelIsTraineeColumn) ColumnName() string {
-	return "is_trainee"
+func (b *ExerciseByIdentifierAndEmailIsAuthorizedColumn) ColumnName() string {
+	return "is_authorized"
 }
 
-func (b *ExercisePermissionsLevelIsTraineeColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailIsAuthorizedColumn) To(value *bool) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExercisePermissionsLevelRoleDescriptionColumn struct {
+type ExerciseByIdentifierAndEmailIsOperatorColumn struct {
 }
 
-func (b *ExercisePermissionsLevelRoleDescriptionColumn) ColumnName() string {
-	return "role_description"
+func (b *ExerciseByIdentifierAndEmailIsOperatorColumn) ColumnName() string {
+	return "is_operator"
 }
 
-func (b *ExercisePermissionsLevelRoleDescriptionColumn) To(value *string) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailIsOperatorColumn) To(value *bool) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type ExercisePermissionsLevelUserEmailColumn struct {
+type ExerciseByIdentifierAndEmailUserNameColumn struct {
 }
 
-func (b *ExercisePermissionsLevelUserEmailColumn) ColumnName() string {
-	return "user_email"
+func (b *ExerciseByIdentifierAndEmailUserNameColumn) ColumnName() string {
+	return "user_name"
 }
 
-func (b *ExercisePermissionsLevelUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+func (b *ExerciseByIdentifierAndEmailUserNameColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *ExercisePermissionsLevelUserEmailColumn) Eq(value string) cqlc.Condition {
-	column := &ExercisePermissionsLevelUserEmailColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
-}
-
-func (b *ExercisePermissionsLevelUserEmailColumn) PartitionBy() cqlc.Column {
-	return b
-}
+type ExerciseByIdentifierAndEmail struct {
+	Email string
 
-func (b *ExercisePermissionsLevelUserEmailColumn) In(value ...string) cqlc.Condition {
-	column := &ExercisePermissionsLevelUserEmailColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
-}
+	ExerciseName string
 
-type ExercisePermissionsLevel struct {
-	ExerciseIdentifier gocql.UUID
+	Id gocql.UUID
 
-	IsAdmin bool
+	IsActive bool
 
 	IsAuthorized bool
 
-	IsInvisible bool
-
-	IsOc bool
-
-	IsTrainee bool
-
-	RoleDescription string
-
-	UserEmail string
-}
+	IsOperator bool
 
-func (s *ExercisePermissionsLevel) ExerciseIdentifierValue() gocql.UUID {
-	return s.ExerciseIdentifier
+	UserName string
 }
 
-func (s *ExercisePermissionsLevel) IsAdminValue() bool {
-	return s.IsAdmin
+func (s *ExerciseByIdentifierAndEmail) EmailValue() string {
+	return s.Email
 }
 
-func (s *ExercisePermissionsLevel) IsAuthorizedValue() bool {
-	return s.IsAuthorized
+func (s *ExerciseByIdentifierAndEmail) ExerciseNameValue() string {
+	return s.ExerciseName
 }
 
-func (s *ExercisePermissionsLevel) IsInvisibleValue() bool {
-	return s.IsInvisible
+func (s *ExerciseByIdentifierAndEmail) IdValue() gocql.UUID {
+	return s.Id
 }
 
-func (s *ExercisePermissionsLevel) IsOcValue() bool {
-	return s.IsOc
+func (s *ExerciseByIdentifierAndEmail) IsActiveValue() bool {
+	return s.IsActive
 }
 
-func (s *ExercisePermissionsLevel) IsTraineeValue() bool {
-	return s.IsTrainee
+func (s *ExerciseByIdentifierAndEmail) IsAuthorizedValue() bool {
+	return s.IsAuthorized
 }
 
-func (s *ExercisePermissionsLevel) RoleDescriptionValue() string {
-	return s.RoleDescription
+func (s *ExerciseByIdentifierAndEmail) IsOperatorValue() bool {
+	return s.IsOperator
 }
 
-func (s *ExercisePermissionsLevel) UserEmailValue() string {
-	return s.UserEmail
+func (s *ExerciseByIdentifierAndEmail) UserNameValue() string {
+	return s.UserName
 }
 
-type ExercisePermissionsLevelDef struct {
-	EXERCISE_IDENTIFIER cqlc.LastClusteredUUIDColumn
-
-	IS_ADMIN cqlc.BooleanColumn
+type ExerciseByIdentifierAndEmailDef struct {
+	EMAIL cqlc.StringColumn
 
-	IS_AUTHORIZED cqlc.BooleanColumn
+	EXERCISE_NAME cqlc.StringColumn
 
-	IS_INVISIBLE cqlc.BooleanColumn
+	ID cqlc.LastPartitionedUUIDColumn
 
-	IS_OC cqlc.BooleanColumn
+	IS_ACTIVE cqlc.BooleanColumn
 
-	IS_TRAINEE cqlc.BooleanColumn
+	IS_AUTHORIZED cqlc.BooleanColumn
 
-	ROLE_DESCRIPTION cqlc.StringColumn
+	IS_OPERATOR cqlc.BooleanColumn
 
-	USER_EMAIL cqlc.LastPartitionedStringColumn
+	USER_NAME cqlc.StringColumn
 }
 
-func BindExercisePermissionsLevel(iter *gocql.Iter) ([]ExercisePermissionsLevel, error) {
-	array := make([]ExercisePermissionsLevel, 0)
-	err := MapExercisePermissionsLevel(iter, func(t ExercisePermissionsLevel) (bool, error) {
+func BindExerciseByIdentifierAndEmail(iter *gocql.Iter) ([]ExerciseByIdentifierAndEmail, error) {
+	array := make([]ExerciseByIdentifierAndEmail, 0)
+	err := MapExerciseByIdentifierAndEmail(iter, func(t ExerciseByIdentifierAndEmail) (bool, error) {
 		array = append(array, t)
 		return true, nil
 	})
 	return array, err
 }
 
-func MapExercisePermissionsLevel(iter *gocql.Iter, callback func(t ExercisePermissionsLevel) (bool, error)) error {
+func MapExerciseByIdentifierAndEmail(iter *gocql.Iter, callback func(t ExerciseByIdentifierAndEmail) (bool, error)) error {
 	columns := iter.Columns()
 	row := make([]interface{}, len(columns))
 
 	for {
-		t := ExercisePermissionsLevel{}
+		t := ExerciseByIdentifierAndEmail{}
 
 		for i := 0; i < len(columns); i++ {
 			switch columns[i].Name {
 
-			case "exercise_identifier":
-				row[i] = &t.ExerciseIdentifier
+			case "email":
+				row[i] = &t.Email
 
-			case "is_admin":
-				row[i] = &t.IsAdmin
+			case "exercise_name":
+				row[i] = &t.ExerciseName
+
+			case "id":
+				row[i] = &t.Id
+
+			case "is_active":
+				row[i] = &t.IsActive
 
 			case "is_authorized":
 				row[i] = &t.IsAuthorized
 
-			case "is_invisible":
-				row[i] = &t.IsInvisible
-
-			case "is_oc":
-				row[i] = &t.IsOc
-
-			case "is_trainee":
-				row[i] = &t.IsTrainee
-
-			case "role_description":
-				row[i] = &t.RoleDescription
+			case "is_operator":
+				row[i] = &t.IsOperator
 
-			case "user_email":
-				row[i] = &t.UserEmail
+			case "user_name":
+				row[i] = &t.UserName
 
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
@@ -1090,427 +1433,362 @@ func MapExercisePermissionsLevel(iter *gocql.Iter, callback func(t ExercisePermi
 	return nil
 }
 
-func (s *ExercisePermissionsLevelDef) SupportsUpsert() bool {
+func (s *ExerciseByIdentifierAndEmailDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *ExercisePermissionsLevelDef) TableName() string {
-	return "exercise_permissions_level"
+func (s *ExerciseByIdentifierAndEmailDef) TableName() string {
+	return "exercise_by_identifier_and_email"
 }
 
-func (s *ExercisePermissionsLevelDef) Keyspace() string {
+func (s *ExerciseByIdentifierAndEmailDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *ExercisePermissionsLevelDef) Bind(v ExercisePermissionsLevel) cqlc.TableBinding {
+func (s *ExerciseByIdentifierAndEmailDef) Bind(v ExerciseByIdentifierAndEmail) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelExerciseIdentifierColumn{}, Value: v.ExerciseIdentifier},
-
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAdminColumn{}, Value: v.IsAdmin},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailEmailColumn{}, Value: v.Email},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAuthorizedColumn{}, Value: v.IsAuthorized},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailExerciseNameColumn{}, Value: v.ExerciseName},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsInvisibleColumn{}, Value: v.IsInvisible},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIdColumn{}, Value: v.Id},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsOcColumn{}, Value: v.IsOc},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsActiveColumn{}, Value: v.IsActive},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsTraineeColumn{}, Value: v.IsTrainee},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsAuthorizedColumn{}, Value: v.IsAuthorized},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelRoleDescriptionColumn{}, Value: v.RoleDescription},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsOperatorColumn{}, Value: v.IsOperator},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelUserEmailColumn{}, Value: v.UserEmail},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailUserNameColumn{}, Value: v.UserName},
 	}
-	return cqlc.TableBinding{Table: &ExercisePermissionsLevelDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &ExerciseByIdentifierAndEmailDef{}, Columns: cols}
 }
 
-func (s *ExercisePermissionsLevelDef) To(v *ExercisePermissionsLevel) cqlc.TableBinding {
+func (s *ExerciseByIdentifierAndEmailDef) To(v *ExerciseByIdentifierAndEmail) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelExerciseIdentifierColumn{}, Value: &v.ExerciseIdentifier},
-
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAdminColumn{}, Value: &v.IsAdmin},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailEmailColumn{}, Value: &v.Email},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAuthorizedColumn{}, Value: &v.IsAuthorized},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailExerciseNameColumn{}, Value: &v.ExerciseName},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsInvisibleColumn{}, Value: &v.IsInvisible},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIdColumn{}, Value: &v.Id},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsOcColumn{}, Value: &v.IsOc},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsActiveColumn{}, Value: &v.IsActive},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsTraineeColumn{}, Value: &v.IsTrainee},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsAuthorizedColumn{}, Value: &v.IsAuthorized},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelRoleDescriptionColumn{}, Value: &v.RoleDescription},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailIsOperatorColumn{}, Value: &v.IsOperator},
 
-		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelUserEmailColumn{}, Value: &v.UserEmail},
+		cqlc.ColumnBinding{Column: &ExerciseByIdentifierAndEmailUserNameColumn{}, Value: &v.UserName},
 	}
-	return cqlc.TableBinding{Table: &ExercisePermissionsLevelDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &ExerciseByIdentifierAndEmailDef{}, Columns: cols}
 }
 
-func (s *ExercisePermissionsLevelDef) ColumnDefinitions() []cqlc.Column {
+func (s *ExerciseByIdentifierAndEmailDef) ColumnDefinitions() []cqlc.Column {
 	return []cqlc.Column{
 
-		&ExercisePermissionsLevelExerciseIdentifierColumn{},
-
-		&ExercisePermissionsLevelIsAdminColumn{},
+		&ExerciseByIdentifierAndEmailEmailColumn{},
 
-		&ExercisePermissionsLevelIsAuthorizedColumn{},
+		&ExerciseByIdentifierAndEmailExerciseNameColumn{},
 
-		&ExercisePermissionsLevelIsInvisibleColumn{},
+		&ExerciseByIdentifierAndEmailIdColumn{},
 
-		&ExercisePermissionsLevelIsOcColumn{},
+		&ExerciseByIdentifierAndEmailIsActiveColumn{},
 
-		&ExercisePermissionsLevelIsTraineeColumn{},
+		&ExerciseByIdentifierAndEmailIsAuthorizedColumn{},
 
-		&ExercisePermissionsLevelRoleDescriptionColumn{},
+		&ExerciseByIdentifierAndEmailIsOperatorColumn{},
 
-		&ExercisePermissionsLevelUserEmailColumn{},
+		&ExerciseByIdentifierAndEmailUserNameColumn{},
 	}
 }
 
-func ExercisePermissionsLevelTableDef() *ExercisePermissionsLevelDef {
-	return &ExercisePermissionsLevelDef{
-
-		EXERCISE_IDENTIFIER: &ExercisePermissionsLevelExerciseIdentifierColumn{},
+func ExerciseByIdentifierAndEmailTableDef() *ExerciseByIdentifierAndEmailDef {
+	return &ExerciseByIdentifierAndEmailDef{
 
-		IS_ADMIN: &ExercisePermissionsLevelIsAdminColumn{},
+		EMAIL: &ExerciseByIdentifierAndEmailEmailColumn{},
 
-		IS_AUTHORIZED: &ExercisePermissionsLevelIsAuthorizedColumn{},
+		EXERCISE_NAME: &ExerciseByIdentifierAndEmailExerciseNameColumn{},
 
-		IS_INVISIBLE: &ExercisePermissionsLevelIsInvisibleColumn{},
+		ID: &ExerciseByIdentifierAndEmailIdColumn{},
 
-		IS_OC: &ExercisePermissionsLevelIsOcColumn{},
+		IS_ACTIVE: &ExerciseByIdentifierAndEmailIsActiveColumn{},
 
-		IS_TRAINEE: &ExercisePermissionsLevelIsTraineeColumn{},
+		IS_AUTHORIZED: &ExerciseByIdentifierAndEmailIsAuthorizedColumn{},
 
-		ROLE_DESCRIPTION: &ExercisePermissionsLevelRoleDescriptionColumn{},
+		IS_OPERATOR: &ExerciseByIdentifierAndEmailIsOperatorColumn{},
 
-		USER_EMAIL: &ExercisePermissionsLevelUserEmailColumn{},
+		USER_NAME: &ExerciseByIdentifierAndEmailUserNameColumn{},
 	}
 }
 
-func (s *ExercisePermissionsLevelDef) ExerciseIdentifierColumn() cqlc.LastClusteredUUIDColumn {
-	return &ExercisePermissionsLevelExerciseIdentifierColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) EmailColumn() cqlc.StringColumn {
+	return &ExerciseByIdentifierAndEmailEmailColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) IsAdminColumn() cqlc.BooleanColumn {
-	return &ExercisePermissionsLevelIsAdminColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) ExerciseNameColumn() cqlc.StringColumn {
+	return &ExerciseByIdentifierAndEmailExerciseNameColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) IsAuthorizedColumn() cqlc.BooleanColumn {
-	return &ExercisePermissionsLevelIsAuthorizedColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) IdColumn() cqlc.LastPartitionedUUIDColumn {
+	return &ExerciseByIdentifierAndEmailIdColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) IsInvisibleColumn() cqlc.BooleanColumn {
-	return &ExercisePermissionsLevelIsInvisibleColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) IsActiveColumn() cqlc.BooleanColumn {
+	return &ExerciseByIdentifierAndEmailIsActiveColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) IsOcColumn() cqlc.BooleanColumn {
-	return &ExercisePermissionsLevelIsOcColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) IsAuthorizedColumn() cqlc.BooleanColumn {
+	return &ExerciseByIdentifierAndEmailIsAuthorizedColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) IsTraineeColumn() cqlc.BooleanColumn {
-	return &ExercisePermissionsLevelIsTraineeColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) IsOperatorColumn() cqlc.BooleanColumn {
+	return &ExerciseByIdentifierAndEmailIsOperatorColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) RoleDescriptionColumn() cqlc.StringColumn {
-	return &ExercisePermissionsLevelRoleDescriptionColumn{}
+func (s *ExerciseByIdentifierAndEmailDef) UserNameColumn() cqlc.StringColumn {
+	return &ExerciseByIdentifierAndEmailUserNameColumn{}
 }
 
-func (s *ExercisePermissionsLevelDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
-	return &ExercisePermissionsLevelUserEmailColumn{}
+type ExerciseInvitationsCreatedAtColumn struct {
 }
 
-type SchemaMigrationsVersionColumn struct {
+func (b *ExerciseInvitationsCreatedAtColumn) ColumnName() string {
+	return "created_at"
 }
 
-func (b *SchemaMigrationsVersionColumn) ColumnName() string {
-	return "version"
+func (b *ExerciseInvitationsCreatedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *SchemaMigrationsVersionColumn) To(value *int64) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+type ExerciseInvitationsEmailColumn struct {
 }
 
-func (b *SchemaMigrationsVersionColumn) CanIncrement() bool {
-	return true
+func (b *ExerciseInvitationsEmailColumn) ColumnName() string {
+	return "email"
 }
 
-type SchemaMigrationsVersionrowColumn struct {
+func (b *ExerciseInvitationsEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *SchemaMigrationsVersionrowColumn) ColumnName() string {
-	return "versionrow"
+type ExerciseInvitationsExerciseIdColumn struct {
 }
 
-func (b *SchemaMigrationsVersionrowColumn) To(value *int64) cqlc.ColumnBinding {
+func (b *ExerciseInvitationsExerciseIdColumn) ColumnName() string {
+	return "exercise_id"
+}
+
+func (b *ExerciseInvitationsExerciseIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *SchemaMigrationsVersionrowColumn) Eq(value int64) cqlc.Condition {
-	column := &SchemaMigrationsVersionrowColumn{}
+func (b *ExerciseInvitationsExerciseIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &ExerciseInvitationsExerciseIdColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *SchemaMigrationsVersionrowColumn) PartitionBy() cqlc.Column {
+func (b *ExerciseInvitationsExerciseIdColumn) PartitionBy() cqlc.Column {
 	return b
 }
 
-func (b *SchemaMigrationsVersionrowColumn) In(value ...int64) cqlc.Condition {
-	column := &SchemaMigrationsVersionrowColumn{}
+func (b *ExerciseInvitationsExerciseIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &ExerciseInvitationsExerciseIdColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-type SchemaMigrations struct {
-	Version int64
+type ExerciseInvitationsExpiresAtColumn struct {
+}
 
-	Versionrow int64
+func (b *ExerciseInvitationsExpiresAtColumn) ColumnName() string {
+	return "expires_at"
 }
 
-func (s *SchemaMigrations) VersionValue() int64 {
-	return s.Version
+func (b *ExerciseInvitationsExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *SchemaMigrations) VersionrowValue() int64 {
-	return s.Versionrow
+type ExerciseInvitationsInvitedByColumn struct {
 }
 
-type SchemaMigrationsDef struct {
-	VERSION cqlc.CounterColumn
+func (b *ExerciseInvitationsInvitedByColumn) ColumnName() string {
+	return "invited_by"
+}
 
-	VERSIONROW cqlc.LastPartitionedInt64Column
+func (b *ExerciseInvitationsInvitedByColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func BindSchemaMigrations(iter *gocql.Iter) ([]SchemaMigrations, error) {
-	array := make([]SchemaMigrations, 0)
-	err := MapSchemaMigrations(iter, func(t SchemaMigrations) (bool, error) {
-		array = append(array, t)
-		return true, nil
-	})
-	return array, err
+type ExerciseInvitationsRoleColumn struct {
 }
 
-func MapSchemaMigrations(iter *gocql.Iter, callback func(t SchemaMigrations) (bool, error)) error {
-	columns := iter.Columns()
-	row := make([]interface{}, len(columns))
+func (b *ExerciseInvitationsRoleColumn) ColumnName() string {
+	return "role"
+}
 
-	for {
-		t := SchemaMigrations{}
+func (b *ExerciseInvitationsRoleColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-		for i := 0; i < len(columns); i++ {
-			switch columns[i].Name {
+type ExerciseInvitationsTokenHashColumn struct {
+	desc bool
+}
 
-			case "version":
-				row[i] = &t.Version
+func (b *ExerciseInvitationsTokenHashColumn) ColumnName() string {
+	return "token_hash"
+}
 
-			case "versionrow":
-				row[i] = &t.Versionrow
+func (b *ExerciseInvitationsTokenHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-			default:
-				log.Fatal("unhandled column: ", columns[i].Name)
-			}
-		}
-		if !iter.Scan(row...) {
-			break
-		}
+func (b *ExerciseInvitationsTokenHashColumn) ClusterWith() string {
+	return b.ColumnName()
+}
 
-		readNext, err := callback(t)
-		if err != nil {
-			return err
-		}
-		if !readNext {
-			return nil
-		}
-	}
+func (b *ExerciseInvitationsTokenHashColumn) Desc() cqlc.ClusteredColumn {
+	return &ExerciseInvitationsTokenHashColumn{desc: true}
+}
 
-	return nil
+func (b *ExerciseInvitationsTokenHashColumn) IsDescending() bool {
+	return b.desc
 }
 
-func (s *SchemaMigrationsDef) IsCounterTable() bool {
-	return true
+func (b *ExerciseInvitationsTokenHashColumn) Eq(value string) cqlc.Condition {
+	column := &ExerciseInvitationsTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (s *SchemaMigrationsDef) TableName() string {
-	return "schema_migrations"
+func (b *ExerciseInvitationsTokenHashColumn) In(value ...string) cqlc.Condition {
+	column := &ExerciseInvitationsTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-func (s *SchemaMigrationsDef) Keyspace() string {
-	return "sitrep"
+func (b *ExerciseInvitationsTokenHashColumn) Gt(value string) cqlc.Condition {
+	column := &ExerciseInvitationsTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *ExerciseInvitationsTokenHashColumn) Ge(value string) cqlc.Condition {
+	column := &ExerciseInvitationsTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *ExerciseInvitationsTokenHashColumn) Lt(value string) cqlc.Condition {
+	column := &ExerciseInvitationsTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *ExerciseInvitationsTokenHashColumn) Le(value string) cqlc.Condition {
+	column := &ExerciseInvitationsTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
 }
 
-func (s *SchemaMigrationsDef) Bind(v SchemaMigrations) cqlc.TableBinding {
-	cols := []cqlc.ColumnBinding{
+type ExerciseInvitations struct {
+	CreatedAt time.Time
 
-		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionColumn{}, Value: v.Version},
+	Email string
 
-		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionrowColumn{}, Value: v.Versionrow},
-	}
-	return cqlc.TableBinding{Table: &SchemaMigrationsDef{}, Columns: cols}
-}
+	ExerciseId gocql.UUID
 
-func (s *SchemaMigrationsDef) To(v *SchemaMigrations) cqlc.TableBinding {
-	cols := []cqlc.ColumnBinding{
+	ExpiresAt time.Time
 
-		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionColumn{}, Value: &v.Version},
+	InvitedBy string
 
-		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionrowColumn{}, Value: &v.Versionrow},
-	}
-	return cqlc.TableBinding{Table: &SchemaMigrationsDef{}, Columns: cols}
+	Role string
+
+	TokenHash string
 }
 
-func (s *SchemaMigrationsDef) ColumnDefinitions() []cqlc.Column {
-	return []cqlc.Column{
+func (s *ExerciseInvitations) CreatedAtValue() time.Time {
+	return s.CreatedAt
+}
 
-		&SchemaMigrationsVersionColumn{},
+func (s *ExerciseInvitations) EmailValue() string {
+	return s.Email
+}
 
-		&SchemaMigrationsVersionrowColumn{},
-	}
-}
-
-func SchemaMigrationsTableDef() *SchemaMigrationsDef {
-	return &SchemaMigrationsDef{
-
-		VERSION: &SchemaMigrationsVersionColumn{},
-
-		VERSIONROW: &SchemaMigrationsVersionrowColumn{},
-	}
-}
-
-func (s *SchemaMigrationsDef) VersionColumn() cqlc.CounterColumn {
-	return &SchemaMigrationsVersionColumn{}
-}
-
-func (s *SchemaMigrationsDef) VersionrowColumn() cqlc.LastPartitionedInt64Column {
-	return &SchemaMigrationsVersionrowColumn{}
-}
-
-type SettingsByExerciseIdentifierIdColumn struct {
-}
-
-func (b *SettingsByExerciseIdentifierIdColumn) ColumnName() string {
-	return "id"
-}
-
-func (b *SettingsByExerciseIdentifierIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
-
-func (b *SettingsByExerciseIdentifierIdColumn) Eq(value gocql.UUID) cqlc.Condition {
-	column := &SettingsByExerciseIdentifierIdColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
-}
-
-func (b *SettingsByExerciseIdentifierIdColumn) PartitionBy() cqlc.Column {
-	return b
-}
-
-func (b *SettingsByExerciseIdentifierIdColumn) In(value ...gocql.UUID) cqlc.Condition {
-	column := &SettingsByExerciseIdentifierIdColumn{}
-	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
-}
-
-type SettingsByExerciseIdentifierIsDefaultColumn struct {
-}
-
-func (b *SettingsByExerciseIdentifierIsDefaultColumn) ColumnName() string {
-	return "is_default"
-}
-
-func (b *SettingsByExerciseIdentifierIsDefaultColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
-
-type SettingsByExerciseIdentifierSettingsColumn struct {
-}
-
-func (b *SettingsByExerciseIdentifierSettingsColumn) ColumnName() string {
-	return "settings"
+func (s *ExerciseInvitations) ExerciseIdValue() gocql.UUID {
+	return s.ExerciseId
 }
 
-func (b *SettingsByExerciseIdentifierSettingsColumn) To(value *map[string]string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
-
-type SettingsByExerciseIdentifierSettingsVersionColumn struct {
-}
-
-func (b *SettingsByExerciseIdentifierSettingsVersionColumn) ColumnName() string {
-	return "settings_version"
+func (s *ExerciseInvitations) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
 }
 
-func (b *SettingsByExerciseIdentifierSettingsVersionColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *ExerciseInvitations) InvitedByValue() string {
+	return s.InvitedBy
 }
 
-type SettingsByExerciseIdentifier struct {
-	Id gocql.UUID
-
-	IsDefault bool
-
-	Settings map[string]string
-
-	SettingsVersion string
+func (s *ExerciseInvitations) RoleValue() string {
+	return s.Role
 }
 
-func (s *SettingsByExerciseIdentifier) IdValue() gocql.UUID {
-	return s.Id
+func (s *ExerciseInvitations) TokenHashValue() string {
+	return s.TokenHash
 }
 
-func (s *SettingsByExerciseIdentifier) IsDefaultValue() bool {
-	return s.IsDefault
-}
+type ExerciseInvitationsDef struct {
+	CREATED_AT cqlc.TimestampColumn
 
-func (s *SettingsByExerciseIdentifier) SettingsValue() map[string]string {
-	return s.Settings
-}
+	EMAIL cqlc.StringColumn
 
-func (s *SettingsByExerciseIdentifier) SettingsVersionValue() string {
-	return s.SettingsVersion
-}
+	EXERCISE_ID cqlc.LastPartitionedUUIDColumn
 
-type SettingsByExerciseIdentifierDef struct {
-	ID cqlc.LastPartitionedUUIDColumn
+	EXPIRES_AT cqlc.TimestampColumn
 
-	IS_DEFAULT cqlc.BooleanColumn
+	INVITED_BY cqlc.StringColumn
 
-	SETTINGS cqlc.StringStringMapColumn
+	ROLE cqlc.StringColumn
 
-	SETTINGS_VERSION cqlc.StringColumn
+	TOKEN_HASH cqlc.LastClusteredStringColumn
 }
 
-func BindSettingsByExerciseIdentifier(iter *gocql.Iter) ([]SettingsByExerciseIdentifier, error) {
-	array := make([]SettingsByExerciseIdentifier, 0)
-	err := MapSettingsByExerciseIdentifier(iter, func(t SettingsByExerciseIdentifier) (bool, error) {
+func BindExerciseInvitations(iter *gocql.Iter) ([]ExerciseInvitations, error) {
+	array := make([]ExerciseInvitations, 0)
+	err := MapExerciseInvitations(iter, func(t ExerciseInvitations) (bool, error) {
 		array = append(array, t)
 		return true, nil
 	})
 	return array, err
 }
 
-func MapSettingsByExerciseIdentifier(iter *gocql.Iter, callback func(t SettingsByExerciseIdentifier) (bool, error)) error {
+func MapExerciseInvitations(iter *gocql.Iter, callback func(t ExerciseInvitations) (bool, error)) error {
 	columns := iter.Columns()
 	row := make([]interface{}, len(columns))
 
 	for {
-		t := SettingsByExerciseIdentifier{}
+		t := ExerciseInvitations{}
 
 		for i := 0; i < len(columns); i++ {
 			switch columns[i].Name {
 
-			case "id":
-				row[i] = &t.Id
+			case "created_at":
+				row[i] = &t.CreatedAt
 
-			case "is_default":
-				row[i] = &t.IsDefault
+			case "email":
+				row[i] = &t.Email
 
-			case "settings":
-				row[i] = &t.Settings
+			case "exercise_id":
+				row[i] = &t.ExerciseId
 
-			case "settings_version":
-				row[i] = &t.SettingsVersion
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "invited_by":
+				row[i] = &t.InvitedBy
+
+			case "role":
+				row[i] = &t.Role
+
+			case "token_hash":
+				row[i] = &t.TokenHash
 
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
@@ -1532,522 +1810,5251 @@ func MapSettingsByExerciseIdentifier(iter *gocql.Iter, callback func(t SettingsB
 	return nil
 }
 
-func (s *SettingsByExerciseIdentifierDef) SupportsUpsert() bool {
+func (s *ExerciseInvitationsDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *SettingsByExerciseIdentifierDef) TableName() string {
-	return "settings_by_exercise_identifier"
+func (s *ExerciseInvitationsDef) TableName() string {
+	return "exercise_invitations"
 }
 
-func (s *SettingsByExerciseIdentifierDef) Keyspace() string {
+func (s *ExerciseInvitationsDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *SettingsByExerciseIdentifierDef) Bind(v SettingsByExerciseIdentifier) cqlc.TableBinding {
+func (s *ExerciseInvitationsDef) Bind(v ExerciseInvitations) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIdColumn{}, Value: v.Id},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsCreatedAtColumn{}, Value: v.CreatedAt},
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIsDefaultColumn{}, Value: v.IsDefault},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsEmailColumn{}, Value: v.Email},
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsColumn{}, Value: v.Settings},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsExerciseIdColumn{}, Value: v.ExerciseId},
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsVersionColumn{}, Value: v.SettingsVersion},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsExpiresAtColumn{}, Value: v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsInvitedByColumn{}, Value: v.InvitedBy},
+
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsRoleColumn{}, Value: v.Role},
+
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsTokenHashColumn{}, Value: v.TokenHash},
 	}
-	return cqlc.TableBinding{Table: &SettingsByExerciseIdentifierDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &ExerciseInvitationsDef{}, Columns: cols}
 }
 
-func (s *SettingsByExerciseIdentifierDef) To(v *SettingsByExerciseIdentifier) cqlc.TableBinding {
+func (s *ExerciseInvitationsDef) To(v *ExerciseInvitations) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIdColumn{}, Value: &v.Id},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsCreatedAtColumn{}, Value: &v.CreatedAt},
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIsDefaultColumn{}, Value: &v.IsDefault},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsEmailColumn{}, Value: &v.Email},
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsColumn{}, Value: &v.Settings},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsExerciseIdColumn{}, Value: &v.ExerciseId},
 
-		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsVersionColumn{}, Value: &v.SettingsVersion},
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsExpiresAtColumn{}, Value: &v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsInvitedByColumn{}, Value: &v.InvitedBy},
+
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsRoleColumn{}, Value: &v.Role},
+
+		cqlc.ColumnBinding{Column: &ExerciseInvitationsTokenHashColumn{}, Value: &v.TokenHash},
 	}
-	return cqlc.TableBinding{Table: &SettingsByExerciseIdentifierDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &ExerciseInvitationsDef{}, Columns: cols}
 }
 
-func (s *SettingsByExerciseIdentifierDef) ColumnDefinitions() []cqlc.Column {
+func (s *ExerciseInvitationsDef) ColumnDefinitions() []cqlc.Column {
 	return []cqlc.Column{
 
-		&SettingsByExerciseIdentifierIdColumn{},
+		&ExerciseInvitationsCreatedAtColumn{},
 
-		&SettingsByExerciseIdentifierIsDefaultColumn{},
+		&ExerciseInvitationsEmailColumn{},
 
-		&SettingsByExerciseIdentifierSettingsColumn{},
+		&ExerciseInvitationsExerciseIdColumn{},
 
-		&SettingsByExerciseIdentifierSettingsVersionColumn{},
+		&ExerciseInvitationsExpiresAtColumn{},
+
+		&ExerciseInvitationsInvitedByColumn{},
+
+		&ExerciseInvitationsRoleColumn{},
+
+		&ExerciseInvitationsTokenHashColumn{},
 	}
 }
 
-func SettingsByExerciseIdentifierTableDef() *SettingsByExerciseIdentifierDef {
-	return &SettingsByExerciseIdentifierDef{
+func ExerciseInvitationsTableDef() *ExerciseInvitationsDef {
+	return &ExerciseInvitationsDef{
 
-		ID: &SettingsByExerciseIdentifierIdColumn{},
+		CREATED_AT: &ExerciseInvitationsCreatedAtColumn{},
 
-		IS_DEFAULT: &SettingsByExerciseIdentifierIsDefaultColumn{},
+		EMAIL: &ExerciseInvitationsEmailColumn{},
 
-		SETTINGS: &SettingsByExerciseIdentifierSettingsColumn{},
+		EXERCISE_ID: &ExerciseInvitationsExerciseIdColumn{},
 
-		SETTINGS_VERSION: &SettingsByExerciseIdentifierSettingsVersionColumn{},
+		EXPIRES_AT: &ExerciseInvitationsExpiresAtColumn{},
+
+		INVITED_BY: &ExerciseInvitationsInvitedByColumn{},
+
+		ROLE: &ExerciseInvitationsRoleColumn{},
+
+		TOKEN_HASH: &ExerciseInvitationsTokenHashColumn{},
 	}
 }
 
-func (s *SettingsByExerciseIdentifierDef) IdColumn() cqlc.LastPartitionedUUIDColumn {
-	return &SettingsByExerciseIdentifierIdColumn{}
+func (s *ExerciseInvitationsDef) CreatedAtColumn() cqlc.TimestampColumn {
+	return &ExerciseInvitationsCreatedAtColumn{}
 }
 
-func (s *SettingsByExerciseIdentifierDef) IsDefaultColumn() cqlc.BooleanColumn {
-	return &SettingsByExerciseIdentifierIsDefaultColumn{}
+func (s *ExerciseInvitationsDef) EmailColumn() cqlc.StringColumn {
+	return &ExerciseInvitationsEmailColumn{}
 }
 
-func (s *SettingsByExerciseIdentifierDef) SettingsColumn() cqlc.StringStringMapColumn {
-	return &SettingsByExerciseIdentifierSettingsColumn{}
+func (s *ExerciseInvitationsDef) ExerciseIdColumn() cqlc.LastPartitionedUUIDColumn {
+	return &ExerciseInvitationsExerciseIdColumn{}
 }
 
-func (s *SettingsByExerciseIdentifierDef) SettingsVersionColumn() cqlc.StringColumn {
-	return &SettingsByExerciseIdentifierSettingsVersionColumn{}
+func (s *ExerciseInvitationsDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &ExerciseInvitationsExpiresAtColumn{}
 }
 
-type UsersByEmailAccessValidTillColumn struct {
+func (s *ExerciseInvitationsDef) InvitedByColumn() cqlc.StringColumn {
+	return &ExerciseInvitationsInvitedByColumn{}
 }
 
-func (b *UsersByEmailAccessValidTillColumn) ColumnName() string {
-	return "access_valid_till"
+func (s *ExerciseInvitationsDef) RoleColumn() cqlc.StringColumn {
+	return &ExerciseInvitationsRoleColumn{}
 }
 
-func (b *UsersByEmailAccessValidTillColumn) To(value *time.Time) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *ExerciseInvitationsDef) TokenHashColumn() cqlc.LastClusteredStringColumn {
+	return &ExerciseInvitationsTokenHashColumn{}
 }
 
-type UsersByEmailAnalyticsUserTrackingTokenColumn struct {
+type ExercisePermissionsLevelExerciseIdentifierColumn struct {
+	desc bool
 }
 
-func (b *UsersByEmailAnalyticsUserTrackingTokenColumn) ColumnName() string {
-	return "analytics_user_tracking_token"
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) ColumnName() string {
+	return "exercise_identifier"
 }
 
-func (b *UsersByEmailAnalyticsUserTrackingTokenColumn) To(value *string) cqlc.ColumnBinding {
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByEmailEmailColumn struct {
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) ClusterWith() string {
+	return b.ColumnName()
 }
 
-func (b *UsersByEmailEmailColumn) ColumnName() string {
-	return "email"
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Desc() cqlc.ClusteredColumn {
+	return &ExercisePermissionsLevelExerciseIdentifierColumn{desc: true}
 }
 
-func (b *UsersByEmailEmailColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) IsDescending() bool {
+	return b.desc
 }
 
-func (b *UsersByEmailEmailColumn) Eq(value string) cqlc.Condition {
-	column := &UsersByEmailEmailColumn{}
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *UsersByEmailEmailColumn) PartitionBy() cqlc.Column {
-	return b
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-func (b *UsersByEmailEmailColumn) In(value ...string) cqlc.Condition {
-	column := &UsersByEmailEmailColumn{}
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
-	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *ExercisePermissionsLevelExerciseIdentifierColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &ExercisePermissionsLevelExerciseIdentifierColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
 }
 
-type UsersByEmailEncryptedPasswordColumn struct {
+type ExercisePermissionsLevelIsAdminColumn struct {
 }
 
-func (b *UsersByEmailEncryptedPasswordColumn) ColumnName() string {
-	return "encrypted_password"
+func (b *ExercisePermissionsLevelIsAdminColumn) ColumnName() string {
+	return "is_admin"
 }
 
-func (b *UsersByEmailEncryptedPasswordColumn) To(value *string) cqlc.ColumnBinding {
+func (b *ExercisePermissionsLevelIsAdminColumn) To(value *bool) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByEmailIsAdminColumn struct {
+type ExercisePermissionsLevelIsAuthorizedColumn struct {
 }
 
-func (b *UsersByEmailIsAdminColumn) ColumnName() string {
-	return "is_admin"
+func (b *ExercisePermissionsLevelIsAuthorizedColumn) ColumnName() string {
+	return "is_authorized"
 }
 
-func (b *UsersByEmailIsAdminColumn) To(value *bool) cqlc.ColumnBinding {
+func (b *ExercisePermissionsLevelIsAuthorizedColumn) To(value *bool) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByEmailIsAnalyzedColumn struct {
+type ExercisePermissionsLevelIsInvisibleColumn struct {
+}
+
+func (b *ExercisePermissionsLevelIsInvisibleColumn) ColumnName() string {
+	return "is_invisible"
+}
+
+func (b *ExercisePermissionsLevelIsInvisibleColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExercisePermissionsLevelIsOcColumn struct {
+}
+
+func (b *ExercisePermissionsLevelIsOcColumn) ColumnName() string {
+	return "is_oc"
 }
 
-func (b *UsersByEmailIsAnalyzedColumn) ColumnName() string {
-	return "is_analyzed"
-}
+func (b *ExercisePermissionsLevelIsOcColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExercisePermissionsLevelIsTraineeColumn struct {
+}
+
+func (b *ExercisePermissionsLevelIsTraineeColumn) ColumnName() string {
+	return "is_trainee"
+}
+
+func (b *ExercisePermissionsLevelIsTraineeColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExercisePermissionsLevelRoleDescriptionColumn struct {
+}
+
+func (b *ExercisePermissionsLevelRoleDescriptionColumn) ColumnName() string {
+	return "role_description"
+}
+
+func (b *ExercisePermissionsLevelRoleDescriptionColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type ExercisePermissionsLevelUserEmailColumn struct {
+}
+
+func (b *ExercisePermissionsLevelUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *ExercisePermissionsLevelUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *ExercisePermissionsLevelUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &ExercisePermissionsLevelUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *ExercisePermissionsLevelUserEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *ExercisePermissionsLevelUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &ExercisePermissionsLevelUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type ExercisePermissionsLevel struct {
+	ExerciseIdentifier gocql.UUID
+
+	IsAdmin bool
+
+	IsAuthorized bool
+
+	IsInvisible bool
+
+	IsOc bool
+
+	IsTrainee bool
+
+	RoleDescription string
+
+	UserEmail string
+}
+
+func (s *ExercisePermissionsLevel) ExerciseIdentifierValue() gocql.UUID {
+	return s.ExerciseIdentifier
+}
+
+func (s *ExercisePermissionsLevel) IsAdminValue() bool {
+	return s.IsAdmin
+}
+
+func (s *ExercisePermissionsLevel) IsAuthorizedValue() bool {
+	return s.IsAuthorized
+}
+
+func (s *ExercisePermissionsLevel) IsInvisibleValue() bool {
+	return s.IsInvisible
+}
+
+func (s *ExercisePermissionsLevel) IsOcValue() bool {
+	return s.IsOc
+}
+
+func (s *ExercisePermissionsLevel) IsTraineeValue() bool {
+	return s.IsTrainee
+}
+
+func (s *ExercisePermissionsLevel) RoleDescriptionValue() string {
+	return s.RoleDescription
+}
+
+func (s *ExercisePermissionsLevel) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type ExercisePermissionsLevelDef struct {
+	EXERCISE_IDENTIFIER cqlc.LastClusteredUUIDColumn
+
+	IS_ADMIN cqlc.BooleanColumn
+
+	IS_AUTHORIZED cqlc.BooleanColumn
+
+	IS_INVISIBLE cqlc.BooleanColumn
+
+	IS_OC cqlc.BooleanColumn
+
+	IS_TRAINEE cqlc.BooleanColumn
+
+	ROLE_DESCRIPTION cqlc.StringColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
+}
+
+func BindExercisePermissionsLevel(iter *gocql.Iter) ([]ExercisePermissionsLevel, error) {
+	array := make([]ExercisePermissionsLevel, 0)
+	err := MapExercisePermissionsLevel(iter, func(t ExercisePermissionsLevel) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapExercisePermissionsLevel(iter *gocql.Iter, callback func(t ExercisePermissionsLevel) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := ExercisePermissionsLevel{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "exercise_identifier":
+				row[i] = &t.ExerciseIdentifier
+
+			case "is_admin":
+				row[i] = &t.IsAdmin
+
+			case "is_authorized":
+				row[i] = &t.IsAuthorized
+
+			case "is_invisible":
+				row[i] = &t.IsInvisible
+
+			case "is_oc":
+				row[i] = &t.IsOc
+
+			case "is_trainee":
+				row[i] = &t.IsTrainee
+
+			case "role_description":
+				row[i] = &t.RoleDescription
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *ExercisePermissionsLevelDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *ExercisePermissionsLevelDef) TableName() string {
+	return "exercise_permissions_level"
+}
+
+func (s *ExercisePermissionsLevelDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *ExercisePermissionsLevelDef) Bind(v ExercisePermissionsLevel) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelExerciseIdentifierColumn{}, Value: v.ExerciseIdentifier},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAdminColumn{}, Value: v.IsAdmin},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAuthorizedColumn{}, Value: v.IsAuthorized},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsInvisibleColumn{}, Value: v.IsInvisible},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsOcColumn{}, Value: v.IsOc},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsTraineeColumn{}, Value: v.IsTrainee},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelRoleDescriptionColumn{}, Value: v.RoleDescription},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &ExercisePermissionsLevelDef{}, Columns: cols}
+}
+
+func (s *ExercisePermissionsLevelDef) To(v *ExercisePermissionsLevel) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelExerciseIdentifierColumn{}, Value: &v.ExerciseIdentifier},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAdminColumn{}, Value: &v.IsAdmin},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsAuthorizedColumn{}, Value: &v.IsAuthorized},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsInvisibleColumn{}, Value: &v.IsInvisible},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsOcColumn{}, Value: &v.IsOc},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelIsTraineeColumn{}, Value: &v.IsTrainee},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelRoleDescriptionColumn{}, Value: &v.RoleDescription},
+
+		cqlc.ColumnBinding{Column: &ExercisePermissionsLevelUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &ExercisePermissionsLevelDef{}, Columns: cols}
+}
+
+func (s *ExercisePermissionsLevelDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&ExercisePermissionsLevelExerciseIdentifierColumn{},
+
+		&ExercisePermissionsLevelIsAdminColumn{},
+
+		&ExercisePermissionsLevelIsAuthorizedColumn{},
+
+		&ExercisePermissionsLevelIsInvisibleColumn{},
+
+		&ExercisePermissionsLevelIsOcColumn{},
+
+		&ExercisePermissionsLevelIsTraineeColumn{},
+
+		&ExercisePermissionsLevelRoleDescriptionColumn{},
+
+		&ExercisePermissionsLevelUserEmailColumn{},
+	}
+}
+
+func ExercisePermissionsLevelTableDef() *ExercisePermissionsLevelDef {
+	return &ExercisePermissionsLevelDef{
+
+		EXERCISE_IDENTIFIER: &ExercisePermissionsLevelExerciseIdentifierColumn{},
+
+		IS_ADMIN: &ExercisePermissionsLevelIsAdminColumn{},
+
+		IS_AUTHORIZED: &ExercisePermissionsLevelIsAuthorizedColumn{},
+
+		IS_INVISIBLE: &ExercisePermissionsLevelIsInvisibleColumn{},
+
+		IS_OC: &ExercisePermissionsLevelIsOcColumn{},
+
+		IS_TRAINEE: &ExercisePermissionsLevelIsTraineeColumn{},
+
+		ROLE_DESCRIPTION: &ExercisePermissionsLevelRoleDescriptionColumn{},
+
+		USER_EMAIL: &ExercisePermissionsLevelUserEmailColumn{},
+	}
+}
+
+func (s *ExercisePermissionsLevelDef) ExerciseIdentifierColumn() cqlc.LastClusteredUUIDColumn {
+	return &ExercisePermissionsLevelExerciseIdentifierColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) IsAdminColumn() cqlc.BooleanColumn {
+	return &ExercisePermissionsLevelIsAdminColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) IsAuthorizedColumn() cqlc.BooleanColumn {
+	return &ExercisePermissionsLevelIsAuthorizedColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) IsInvisibleColumn() cqlc.BooleanColumn {
+	return &ExercisePermissionsLevelIsInvisibleColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) IsOcColumn() cqlc.BooleanColumn {
+	return &ExercisePermissionsLevelIsOcColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) IsTraineeColumn() cqlc.BooleanColumn {
+	return &ExercisePermissionsLevelIsTraineeColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) RoleDescriptionColumn() cqlc.StringColumn {
+	return &ExercisePermissionsLevelRoleDescriptionColumn{}
+}
+
+func (s *ExercisePermissionsLevelDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &ExercisePermissionsLevelUserEmailColumn{}
+}
+
+type JwtByUserEmailFamilyIdColumn struct {
+}
+
+func (b *JwtByUserEmailFamilyIdColumn) ColumnName() string {
+	return "family_id"
+}
+
+func (b *JwtByUserEmailFamilyIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type JwtByUserEmailIssuedAtColumn struct {
+}
+
+func (b *JwtByUserEmailIssuedAtColumn) ColumnName() string {
+	return "issued_at"
+}
+
+func (b *JwtByUserEmailIssuedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type JwtByUserEmailJwtColumn struct {
+	desc bool
+}
+
+func (b *JwtByUserEmailJwtColumn) ColumnName() string {
+	return "jwt"
+}
+
+func (b *JwtByUserEmailJwtColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *JwtByUserEmailJwtColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *JwtByUserEmailJwtColumn) Desc() cqlc.ClusteredColumn {
+	return &JwtByUserEmailJwtColumn{desc: true}
+}
+
+func (b *JwtByUserEmailJwtColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *JwtByUserEmailJwtColumn) Eq(value string) cqlc.Condition {
+	column := &JwtByUserEmailJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *JwtByUserEmailJwtColumn) In(value ...string) cqlc.Condition {
+	column := &JwtByUserEmailJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *JwtByUserEmailJwtColumn) Gt(value string) cqlc.Condition {
+	column := &JwtByUserEmailJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *JwtByUserEmailJwtColumn) Ge(value string) cqlc.Condition {
+	column := &JwtByUserEmailJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *JwtByUserEmailJwtColumn) Lt(value string) cqlc.Condition {
+	column := &JwtByUserEmailJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *JwtByUserEmailJwtColumn) Le(value string) cqlc.Condition {
+	column := &JwtByUserEmailJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type JwtByUserEmailUserEmailColumn struct {
+}
+
+func (b *JwtByUserEmailUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *JwtByUserEmailUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *JwtByUserEmailUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &JwtByUserEmailUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *JwtByUserEmailUserEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *JwtByUserEmailUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &JwtByUserEmailUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type JwtByUserEmail struct {
+	FamilyId gocql.UUID
+
+	IssuedAt time.Time
+
+	Jwt string
+
+	UserEmail string
+}
+
+func (s *JwtByUserEmail) FamilyIdValue() gocql.UUID {
+	return s.FamilyId
+}
+
+func (s *JwtByUserEmail) IssuedAtValue() time.Time {
+	return s.IssuedAt
+}
+
+func (s *JwtByUserEmail) JwtValue() string {
+	return s.Jwt
+}
+
+func (s *JwtByUserEmail) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type JwtByUserEmailDef struct {
+	FAMILY_ID cqlc.UUIDColumn
+
+	ISSUED_AT cqlc.TimestampColumn
+
+	JWT cqlc.LastClusteredStringColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
+}
+
+func BindJwtByUserEmail(iter *gocql.Iter) ([]JwtByUserEmail, error) {
+	array := make([]JwtByUserEmail, 0)
+	err := MapJwtByUserEmail(iter, func(t JwtByUserEmail) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapJwtByUserEmail(iter *gocql.Iter, callback func(t JwtByUserEmail) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := JwtByUserEmail{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "family_id":
+				row[i] = &t.FamilyId
+
+			case "issued_at":
+				row[i] = &t.IssuedAt
+
+			case "jwt":
+				row[i] = &t.Jwt
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *JwtByUserEmailDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *JwtByUserEmailDef) TableName() string {
+	return "jwt_by_user_email"
+}
+
+func (s *JwtByUserEmailDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *JwtByUserEmailDef) Bind(v JwtByUserEmail) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailFamilyIdColumn{}, Value: v.FamilyId},
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailIssuedAtColumn{}, Value: v.IssuedAt},
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailJwtColumn{}, Value: v.Jwt},
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &JwtByUserEmailDef{}, Columns: cols}
+}
+
+func (s *JwtByUserEmailDef) To(v *JwtByUserEmail) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailFamilyIdColumn{}, Value: &v.FamilyId},
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailIssuedAtColumn{}, Value: &v.IssuedAt},
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailJwtColumn{}, Value: &v.Jwt},
+
+		cqlc.ColumnBinding{Column: &JwtByUserEmailUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &JwtByUserEmailDef{}, Columns: cols}
+}
+
+func (s *JwtByUserEmailDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&JwtByUserEmailFamilyIdColumn{},
+
+		&JwtByUserEmailIssuedAtColumn{},
+
+		&JwtByUserEmailJwtColumn{},
+
+		&JwtByUserEmailUserEmailColumn{},
+	}
+}
+
+func JwtByUserEmailTableDef() *JwtByUserEmailDef {
+	return &JwtByUserEmailDef{
+
+		FAMILY_ID: &JwtByUserEmailFamilyIdColumn{},
+
+		ISSUED_AT: &JwtByUserEmailIssuedAtColumn{},
+
+		JWT: &JwtByUserEmailJwtColumn{},
+
+		USER_EMAIL: &JwtByUserEmailUserEmailColumn{},
+	}
+}
+
+func (s *JwtByUserEmailDef) FamilyIdColumn() cqlc.UUIDColumn {
+	return &JwtByUserEmailFamilyIdColumn{}
+}
+
+func (s *JwtByUserEmailDef) IssuedAtColumn() cqlc.TimestampColumn {
+	return &JwtByUserEmailIssuedAtColumn{}
+}
+
+func (s *JwtByUserEmailDef) JwtColumn() cqlc.LastClusteredStringColumn {
+	return &JwtByUserEmailJwtColumn{}
+}
+
+func (s *JwtByUserEmailDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &JwtByUserEmailUserEmailColumn{}
+}
+
+type LoginFailuresFailureIdColumn struct {
+	desc bool
+}
+
+func (b *LoginFailuresFailureIdColumn) ColumnName() string {
+	return "failure_id"
+}
+
+func (b *LoginFailuresFailureIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *LoginFailuresFailureIdColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *LoginFailuresFailureIdColumn) Desc() cqlc.ClusteredColumn {
+	return &LoginFailuresFailureIdColumn{desc: true}
+}
+
+func (b *LoginFailuresFailureIdColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *LoginFailuresFailureIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &LoginFailuresFailureIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *LoginFailuresFailureIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &LoginFailuresFailureIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *LoginFailuresFailureIdColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &LoginFailuresFailureIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *LoginFailuresFailureIdColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &LoginFailuresFailureIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *LoginFailuresFailureIdColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &LoginFailuresFailureIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *LoginFailuresFailureIdColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &LoginFailuresFailureIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type LoginFailuresSubjectColumn struct {
+}
+
+func (b *LoginFailuresSubjectColumn) ColumnName() string {
+	return "subject"
+}
+
+func (b *LoginFailuresSubjectColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *LoginFailuresSubjectColumn) Eq(value string) cqlc.Condition {
+	column := &LoginFailuresSubjectColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *LoginFailuresSubjectColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *LoginFailuresSubjectColumn) In(value ...string) cqlc.Condition {
+	column := &LoginFailuresSubjectColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type LoginFailures struct {
+	FailureId gocql.UUID
+
+	Subject string
+}
+
+func (s *LoginFailures) FailureIdValue() gocql.UUID {
+	return s.FailureId
+}
+
+func (s *LoginFailures) SubjectValue() string {
+	return s.Subject
+}
+
+type LoginFailuresDef struct {
+	FAILURE_ID cqlc.LastClusteredTimeUUIDColumn
+
+	SUBJECT cqlc.LastPartitionedStringColumn
+}
+
+func BindLoginFailures(iter *gocql.Iter) ([]LoginFailures, error) {
+	array := make([]LoginFailures, 0)
+	err := MapLoginFailures(iter, func(t LoginFailures) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapLoginFailures(iter *gocql.Iter, callback func(t LoginFailures) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := LoginFailures{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "failure_id":
+				row[i] = &t.FailureId
+
+			case "subject":
+				row[i] = &t.Subject
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *LoginFailuresDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *LoginFailuresDef) TableName() string {
+	return "login_failures"
+}
+
+func (s *LoginFailuresDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *LoginFailuresDef) Bind(v LoginFailures) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &LoginFailuresFailureIdColumn{}, Value: v.FailureId},
+
+		cqlc.ColumnBinding{Column: &LoginFailuresSubjectColumn{}, Value: v.Subject},
+	}
+	return cqlc.TableBinding{Table: &LoginFailuresDef{}, Columns: cols}
+}
+
+func (s *LoginFailuresDef) To(v *LoginFailures) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &LoginFailuresFailureIdColumn{}, Value: &v.FailureId},
+
+		cqlc.ColumnBinding{Column: &LoginFailuresSubjectColumn{}, Value: &v.Subject},
+	}
+	return cqlc.TableBinding{Table: &LoginFailuresDef{}, Columns: cols}
+}
+
+func (s *LoginFailuresDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&LoginFailuresFailureIdColumn{},
+
+		&LoginFailuresSubjectColumn{},
+	}
+}
+
+func LoginFailuresTableDef() *LoginFailuresDef {
+	return &LoginFailuresDef{
+
+		FAILURE_ID: &LoginFailuresFailureIdColumn{},
+
+		SUBJECT: &LoginFailuresSubjectColumn{},
+	}
+}
+
+func (s *LoginFailuresDef) FailureIdColumn() cqlc.LastClusteredTimeUUIDColumn {
+	return &LoginFailuresFailureIdColumn{}
+}
+
+func (s *LoginFailuresDef) SubjectColumn() cqlc.LastPartitionedStringColumn {
+	return &LoginFailuresSubjectColumn{}
+}
+
+type LoginHistoryFailureReasonColumn struct {
+}
+
+func (b *LoginHistoryFailureReasonColumn) ColumnName() string {
+	return "failure_reason"
+}
+
+func (b *LoginHistoryFailureReasonColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type LoginHistoryLoginIdColumn struct {
+	desc bool
+}
+
+func (b *LoginHistoryLoginIdColumn) ColumnName() string {
+	return "login_id"
+}
+
+func (b *LoginHistoryLoginIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *LoginHistoryLoginIdColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *LoginHistoryLoginIdColumn) Desc() cqlc.ClusteredColumn {
+	return &LoginHistoryLoginIdColumn{desc: true}
+}
+
+func (b *LoginHistoryLoginIdColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *LoginHistoryLoginIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &LoginHistoryLoginIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *LoginHistoryLoginIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &LoginHistoryLoginIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *LoginHistoryLoginIdColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &LoginHistoryLoginIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *LoginHistoryLoginIdColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &LoginHistoryLoginIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *LoginHistoryLoginIdColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &LoginHistoryLoginIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *LoginHistoryLoginIdColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &LoginHistoryLoginIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type LoginHistoryOutcomeColumn struct {
+}
+
+func (b *LoginHistoryOutcomeColumn) ColumnName() string {
+	return "outcome"
+}
+
+func (b *LoginHistoryOutcomeColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type LoginHistoryRemoteAddrColumn struct {
+}
+
+func (b *LoginHistoryRemoteAddrColumn) ColumnName() string {
+	return "remote_addr"
+}
+
+func (b *LoginHistoryRemoteAddrColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type LoginHistoryUserAgentColumn struct {
+}
+
+func (b *LoginHistoryUserAgentColumn) ColumnName() string {
+	return "user_agent"
+}
+
+func (b *LoginHistoryUserAgentColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type LoginHistoryUserEmailColumn struct {
+}
+
+func (b *LoginHistoryUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *LoginHistoryUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *LoginHistoryUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &LoginHistoryUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *LoginHistoryUserEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *LoginHistoryUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &LoginHistoryUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type LoginHistory struct {
+	FailureReason string
+
+	LoginId gocql.UUID
+
+	Outcome string
+
+	RemoteAddr string
+
+	UserAgent string
+
+	UserEmail string
+}
+
+func (s *LoginHistory) FailureReasonValue() string {
+	return s.FailureReason
+}
+
+func (s *LoginHistory) LoginIdValue() gocql.UUID {
+	return s.LoginId
+}
+
+func (s *LoginHistory) OutcomeValue() string {
+	return s.Outcome
+}
+
+func (s *LoginHistory) RemoteAddrValue() string {
+	return s.RemoteAddr
+}
+
+func (s *LoginHistory) UserAgentValue() string {
+	return s.UserAgent
+}
+
+func (s *LoginHistory) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type LoginHistoryDef struct {
+	FAILURE_REASON cqlc.StringColumn
+
+	LOGIN_ID cqlc.LastClusteredTimeUUIDColumn
+
+	OUTCOME cqlc.StringColumn
+
+	REMOTE_ADDR cqlc.StringColumn
+
+	USER_AGENT cqlc.StringColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
+}
+
+func BindLoginHistory(iter *gocql.Iter) ([]LoginHistory, error) {
+	array := make([]LoginHistory, 0)
+	err := MapLoginHistory(iter, func(t LoginHistory) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapLoginHistory(iter *gocql.Iter, callback func(t LoginHistory) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := LoginHistory{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "failure_reason":
+				row[i] = &t.FailureReason
+
+			case "login_id":
+				row[i] = &t.LoginId
+
+			case "outcome":
+				row[i] = &t.Outcome
+
+			case "remote_addr":
+				row[i] = &t.RemoteAddr
+
+			case "user_agent":
+				row[i] = &t.UserAgent
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *LoginHistoryDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *LoginHistoryDef) TableName() string {
+	return "login_history"
+}
+
+func (s *LoginHistoryDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *LoginHistoryDef) Bind(v LoginHistory) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &LoginHistoryFailureReasonColumn{}, Value: v.FailureReason},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryLoginIdColumn{}, Value: v.LoginId},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryOutcomeColumn{}, Value: v.Outcome},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryRemoteAddrColumn{}, Value: v.RemoteAddr},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryUserAgentColumn{}, Value: v.UserAgent},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &LoginHistoryDef{}, Columns: cols}
+}
+
+func (s *LoginHistoryDef) To(v *LoginHistory) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &LoginHistoryFailureReasonColumn{}, Value: &v.FailureReason},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryLoginIdColumn{}, Value: &v.LoginId},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryOutcomeColumn{}, Value: &v.Outcome},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryRemoteAddrColumn{}, Value: &v.RemoteAddr},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryUserAgentColumn{}, Value: &v.UserAgent},
+
+		cqlc.ColumnBinding{Column: &LoginHistoryUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &LoginHistoryDef{}, Columns: cols}
+}
+
+func (s *LoginHistoryDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&LoginHistoryFailureReasonColumn{},
+
+		&LoginHistoryLoginIdColumn{},
+
+		&LoginHistoryOutcomeColumn{},
+
+		&LoginHistoryRemoteAddrColumn{},
+
+		&LoginHistoryUserAgentColumn{},
+
+		&LoginHistoryUserEmailColumn{},
+	}
+}
+
+func LoginHistoryTableDef() *LoginHistoryDef {
+	return &LoginHistoryDef{
+
+		FAILURE_REASON: &LoginHistoryFailureReasonColumn{},
+
+		LOGIN_ID: &LoginHistoryLoginIdColumn{},
+
+		OUTCOME: &LoginHistoryOutcomeColumn{},
+
+		REMOTE_ADDR: &LoginHistoryRemoteAddrColumn{},
+
+		USER_AGENT: &LoginHistoryUserAgentColumn{},
+
+		USER_EMAIL: &LoginHistoryUserEmailColumn{},
+	}
+}
+
+func (s *LoginHistoryDef) FailureReasonColumn() cqlc.StringColumn {
+	return &LoginHistoryFailureReasonColumn{}
+}
+
+func (s *LoginHistoryDef) LoginIdColumn() cqlc.LastClusteredTimeUUIDColumn {
+	return &LoginHistoryLoginIdColumn{}
+}
+
+func (s *LoginHistoryDef) OutcomeColumn() cqlc.StringColumn {
+	return &LoginHistoryOutcomeColumn{}
+}
+
+func (s *LoginHistoryDef) RemoteAddrColumn() cqlc.StringColumn {
+	return &LoginHistoryRemoteAddrColumn{}
+}
+
+func (s *LoginHistoryDef) UserAgentColumn() cqlc.StringColumn {
+	return &LoginHistoryUserAgentColumn{}
+}
+
+func (s *LoginHistoryDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &LoginHistoryUserEmailColumn{}
+}
+
+type LoginLockoutsLockedUntilColumn struct {
+}
+
+func (b *LoginLockoutsLockedUntilColumn) ColumnName() string {
+	return "locked_until"
+}
+
+func (b *LoginLockoutsLockedUntilColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type LoginLockoutsSubjectColumn struct {
+}
+
+func (b *LoginLockoutsSubjectColumn) ColumnName() string {
+	return "subject"
+}
+
+func (b *LoginLockoutsSubjectColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *LoginLockoutsSubjectColumn) Eq(value string) cqlc.Condition {
+	column := &LoginLockoutsSubjectColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *LoginLockoutsSubjectColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *LoginLockoutsSubjectColumn) In(value ...string) cqlc.Condition {
+	column := &LoginLockoutsSubjectColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type LoginLockouts struct {
+	LockedUntil time.Time
+
+	Subject string
+}
+
+func (s *LoginLockouts) LockedUntilValue() time.Time {
+	return s.LockedUntil
+}
+
+func (s *LoginLockouts) SubjectValue() string {
+	return s.Subject
+}
+
+type LoginLockoutsDef struct {
+	LOCKED_UNTIL cqlc.TimestampColumn
+
+	SUBJECT cqlc.LastPartitionedStringColumn
+}
+
+func BindLoginLockouts(iter *gocql.Iter) ([]LoginLockouts, error) {
+	array := make([]LoginLockouts, 0)
+	err := MapLoginLockouts(iter, func(t LoginLockouts) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapLoginLockouts(iter *gocql.Iter, callback func(t LoginLockouts) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := LoginLockouts{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "locked_until":
+				row[i] = &t.LockedUntil
+
+			case "subject":
+				row[i] = &t.Subject
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *LoginLockoutsDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *LoginLockoutsDef) TableName() string {
+	return "login_lockouts"
+}
+
+func (s *LoginLockoutsDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *LoginLockoutsDef) Bind(v LoginLockouts) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &LoginLockoutsLockedUntilColumn{}, Value: v.LockedUntil},
+
+		cqlc.ColumnBinding{Column: &LoginLockoutsSubjectColumn{}, Value: v.Subject},
+	}
+	return cqlc.TableBinding{Table: &LoginLockoutsDef{}, Columns: cols}
+}
+
+func (s *LoginLockoutsDef) To(v *LoginLockouts) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &LoginLockoutsLockedUntilColumn{}, Value: &v.LockedUntil},
+
+		cqlc.ColumnBinding{Column: &LoginLockoutsSubjectColumn{}, Value: &v.Subject},
+	}
+	return cqlc.TableBinding{Table: &LoginLockoutsDef{}, Columns: cols}
+}
+
+func (s *LoginLockoutsDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&LoginLockoutsLockedUntilColumn{},
+
+		&LoginLockoutsSubjectColumn{},
+	}
+}
+
+func LoginLockoutsTableDef() *LoginLockoutsDef {
+	return &LoginLockoutsDef{
+
+		LOCKED_UNTIL: &LoginLockoutsLockedUntilColumn{},
+
+		SUBJECT: &LoginLockoutsSubjectColumn{},
+	}
+}
+
+func (s *LoginLockoutsDef) LockedUntilColumn() cqlc.TimestampColumn {
+	return &LoginLockoutsLockedUntilColumn{}
+}
+
+func (s *LoginLockoutsDef) SubjectColumn() cqlc.LastPartitionedStringColumn {
+	return &LoginLockoutsSubjectColumn{}
+}
+
+type MagicLinksExerciseIdColumn struct {
+}
+
+func (b *MagicLinksExerciseIdColumn) ColumnName() string {
+	return "exercise_id"
+}
+
+func (b *MagicLinksExerciseIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type MagicLinksExpiresAtColumn struct {
+}
+
+func (b *MagicLinksExpiresAtColumn) ColumnName() string {
+	return "expires_at"
+}
+
+func (b *MagicLinksExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type MagicLinksTokenHashColumn struct {
+}
+
+func (b *MagicLinksTokenHashColumn) ColumnName() string {
+	return "token_hash"
+}
+
+func (b *MagicLinksTokenHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *MagicLinksTokenHashColumn) Eq(value string) cqlc.Condition {
+	column := &MagicLinksTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *MagicLinksTokenHashColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *MagicLinksTokenHashColumn) In(value ...string) cqlc.Condition {
+	column := &MagicLinksTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type MagicLinksUserEmailColumn struct {
+}
+
+func (b *MagicLinksUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *MagicLinksUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type MagicLinks struct {
+	ExerciseId gocql.UUID
+
+	ExpiresAt time.Time
+
+	TokenHash string
+
+	UserEmail string
+}
+
+func (s *MagicLinks) ExerciseIdValue() gocql.UUID {
+	return s.ExerciseId
+}
+
+func (s *MagicLinks) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
+
+func (s *MagicLinks) TokenHashValue() string {
+	return s.TokenHash
+}
+
+func (s *MagicLinks) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type MagicLinksDef struct {
+	EXERCISE_ID cqlc.UUIDColumn
+
+	EXPIRES_AT cqlc.TimestampColumn
+
+	TOKEN_HASH cqlc.LastPartitionedStringColumn
+
+	USER_EMAIL cqlc.StringColumn
+}
+
+func BindMagicLinks(iter *gocql.Iter) ([]MagicLinks, error) {
+	array := make([]MagicLinks, 0)
+	err := MapMagicLinks(iter, func(t MagicLinks) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapMagicLinks(iter *gocql.Iter, callback func(t MagicLinks) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := MagicLinks{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "exercise_id":
+				row[i] = &t.ExerciseId
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "token_hash":
+				row[i] = &t.TokenHash
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *MagicLinksDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *MagicLinksDef) TableName() string {
+	return "magic_links"
+}
+
+func (s *MagicLinksDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *MagicLinksDef) Bind(v MagicLinks) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &MagicLinksExerciseIdColumn{}, Value: v.ExerciseId},
+
+		cqlc.ColumnBinding{Column: &MagicLinksExpiresAtColumn{}, Value: v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &MagicLinksTokenHashColumn{}, Value: v.TokenHash},
+
+		cqlc.ColumnBinding{Column: &MagicLinksUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &MagicLinksDef{}, Columns: cols}
+}
+
+func (s *MagicLinksDef) To(v *MagicLinks) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &MagicLinksExerciseIdColumn{}, Value: &v.ExerciseId},
+
+		cqlc.ColumnBinding{Column: &MagicLinksExpiresAtColumn{}, Value: &v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &MagicLinksTokenHashColumn{}, Value: &v.TokenHash},
+
+		cqlc.ColumnBinding{Column: &MagicLinksUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &MagicLinksDef{}, Columns: cols}
+}
+
+func (s *MagicLinksDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&MagicLinksExerciseIdColumn{},
+
+		&MagicLinksExpiresAtColumn{},
+
+		&MagicLinksTokenHashColumn{},
+
+		&MagicLinksUserEmailColumn{},
+	}
+}
+
+func MagicLinksTableDef() *MagicLinksDef {
+	return &MagicLinksDef{
+
+		EXERCISE_ID: &MagicLinksExerciseIdColumn{},
+
+		EXPIRES_AT: &MagicLinksExpiresAtColumn{},
+
+		TOKEN_HASH: &MagicLinksTokenHashColumn{},
+
+		USER_EMAIL: &MagicLinksUserEmailColumn{},
+	}
+}
+
+func (s *MagicLinksDef) ExerciseIdColumn() cqlc.UUIDColumn {
+	return &MagicLinksExerciseIdColumn{}
+}
+
+func (s *MagicLinksDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &MagicLinksExpiresAtColumn{}
+}
+
+func (s *MagicLinksDef) TokenHashColumn() cqlc.LastPartitionedStringColumn {
+	return &MagicLinksTokenHashColumn{}
+}
+
+func (s *MagicLinksDef) UserEmailColumn() cqlc.StringColumn {
+	return &MagicLinksUserEmailColumn{}
+}
+
+type MfaChallengesChallengeHashColumn struct {
+}
+
+func (b *MfaChallengesChallengeHashColumn) ColumnName() string {
+	return "challenge_hash"
+}
+
+func (b *MfaChallengesChallengeHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *MfaChallengesChallengeHashColumn) Eq(value string) cqlc.Condition {
+	column := &MfaChallengesChallengeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *MfaChallengesChallengeHashColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *MfaChallengesChallengeHashColumn) In(value ...string) cqlc.Condition {
+	column := &MfaChallengesChallengeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type MfaChallengesExpiresAtColumn struct {
+}
+
+func (b *MfaChallengesExpiresAtColumn) ColumnName() string {
+	return "expires_at"
+}
+
+func (b *MfaChallengesExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type MfaChallengesUserEmailColumn struct {
+}
+
+func (b *MfaChallengesUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *MfaChallengesUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type MfaChallenges struct {
+	ChallengeHash string
+
+	ExpiresAt time.Time
+
+	UserEmail string
+}
+
+func (s *MfaChallenges) ChallengeHashValue() string {
+	return s.ChallengeHash
+}
+
+func (s *MfaChallenges) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
+
+func (s *MfaChallenges) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type MfaChallengesDef struct {
+	CHALLENGE_HASH cqlc.LastPartitionedStringColumn
+
+	EXPIRES_AT cqlc.TimestampColumn
+
+	USER_EMAIL cqlc.StringColumn
+}
+
+func BindMfaChallenges(iter *gocql.Iter) ([]MfaChallenges, error) {
+	array := make([]MfaChallenges, 0)
+	err := MapMfaChallenges(iter, func(t MfaChallenges) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapMfaChallenges(iter *gocql.Iter, callback func(t MfaChallenges) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := MfaChallenges{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "challenge_hash":
+				row[i] = &t.ChallengeHash
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *MfaChallengesDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *MfaChallengesDef) TableName() string {
+	return "mfa_challenges"
+}
+
+func (s *MfaChallengesDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *MfaChallengesDef) Bind(v MfaChallenges) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &MfaChallengesChallengeHashColumn{}, Value: v.ChallengeHash},
+
+		cqlc.ColumnBinding{Column: &MfaChallengesExpiresAtColumn{}, Value: v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &MfaChallengesUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &MfaChallengesDef{}, Columns: cols}
+}
+
+func (s *MfaChallengesDef) To(v *MfaChallenges) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &MfaChallengesChallengeHashColumn{}, Value: &v.ChallengeHash},
+
+		cqlc.ColumnBinding{Column: &MfaChallengesExpiresAtColumn{}, Value: &v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &MfaChallengesUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &MfaChallengesDef{}, Columns: cols}
+}
+
+func (s *MfaChallengesDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&MfaChallengesChallengeHashColumn{},
+
+		&MfaChallengesExpiresAtColumn{},
+
+		&MfaChallengesUserEmailColumn{},
+	}
+}
+
+func MfaChallengesTableDef() *MfaChallengesDef {
+	return &MfaChallengesDef{
+
+		CHALLENGE_HASH: &MfaChallengesChallengeHashColumn{},
+
+		EXPIRES_AT: &MfaChallengesExpiresAtColumn{},
+
+		USER_EMAIL: &MfaChallengesUserEmailColumn{},
+	}
+}
+
+func (s *MfaChallengesDef) ChallengeHashColumn() cqlc.LastPartitionedStringColumn {
+	return &MfaChallengesChallengeHashColumn{}
+}
+
+func (s *MfaChallengesDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &MfaChallengesExpiresAtColumn{}
+}
+
+func (s *MfaChallengesDef) UserEmailColumn() cqlc.StringColumn {
+	return &MfaChallengesUserEmailColumn{}
+}
+
+type OauthClientsAllowedScopesColumn struct {
+}
+
+func (b *OauthClientsAllowedScopesColumn) ColumnName() string {
+	return "allowed_scopes"
+}
+
+func (b *OauthClientsAllowedScopesColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClientsClientIdColumn struct {
+}
+
+func (b *OauthClientsClientIdColumn) ColumnName() string {
+	return "client_id"
+}
+
+func (b *OauthClientsClientIdColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *OauthClientsClientIdColumn) Eq(value string) cqlc.Condition {
+	column := &OauthClientsClientIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *OauthClientsClientIdColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *OauthClientsClientIdColumn) In(value ...string) cqlc.Condition {
+	column := &OauthClientsClientIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type OauthClientsClientSecretHashColumn struct {
+}
+
+func (b *OauthClientsClientSecretHashColumn) ColumnName() string {
+	return "client_secret_hash"
+}
+
+func (b *OauthClientsClientSecretHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClientsCreatedAtColumn struct {
+}
+
+func (b *OauthClientsCreatedAtColumn) ColumnName() string {
+	return "created_at"
+}
+
+func (b *OauthClientsCreatedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClientsIsPublicColumn struct {
+}
+
+func (b *OauthClientsIsPublicColumn) ColumnName() string {
+	return "is_public"
+}
+
+func (b *OauthClientsIsPublicColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClientsJwtEncryptionKeyColumn struct {
+}
+
+func (b *OauthClientsJwtEncryptionKeyColumn) ColumnName() string {
+	return "jwt_encryption_key"
+}
+
+func (b *OauthClientsJwtEncryptionKeyColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClientsNameColumn struct {
+}
+
+func (b *OauthClientsNameColumn) ColumnName() string {
+	return "name"
+}
+
+func (b *OauthClientsNameColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClientsRedirectUrisColumn struct {
+}
+
+func (b *OauthClientsRedirectUrisColumn) ColumnName() string {
+	return "redirect_uris"
+}
+
+func (b *OauthClientsRedirectUrisColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type OauthClients struct {
+	AllowedScopes string
+
+	ClientId string
+
+	ClientSecretHash string
+
+	CreatedAt time.Time
+
+	IsPublic bool
+
+	JwtEncryptionKey string
+
+	Name string
+
+	RedirectUris string
+}
+
+func (s *OauthClients) AllowedScopesValue() string {
+	return s.AllowedScopes
+}
+
+func (s *OauthClients) ClientIdValue() string {
+	return s.ClientId
+}
+
+func (s *OauthClients) ClientSecretHashValue() string {
+	return s.ClientSecretHash
+}
+
+func (s *OauthClients) CreatedAtValue() time.Time {
+	return s.CreatedAt
+}
+
+func (s *OauthClients) IsPublicValue() bool {
+	return s.IsPublic
+}
+
+func (s *OauthClients) JwtEncryptionKeyValue() string {
+	return s.JwtEncryptionKey
+}
+
+func (s *OauthClients) NameValue() string {
+	return s.Name
+}
+
+func (s *OauthClients) RedirectUrisValue() string {
+	return s.RedirectUris
+}
+
+type OauthClientsDef struct {
+	ALLOWED_SCOPES cqlc.StringColumn
+
+	CLIENT_ID cqlc.LastPartitionedStringColumn
+
+	CLIENT_SECRET_HASH cqlc.StringColumn
+
+	CREATED_AT cqlc.TimestampColumn
+
+	IS_PUBLIC cqlc.BooleanColumn
+
+	JWT_ENCRYPTION_KEY cqlc.StringColumn
+
+	NAME cqlc.StringColumn
+
+	REDIRECT_URIS cqlc.StringColumn
+}
+
+func BindOauthClients(iter *gocql.Iter) ([]OauthClients, error) {
+	array := make([]OauthClients, 0)
+	err := MapOauthClients(iter, func(t OauthClients) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapOauthClients(iter *gocql.Iter, callback func(t OauthClients) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := OauthClients{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "allowed_scopes":
+				row[i] = &t.AllowedScopes
+
+			case "client_id":
+				row[i] = &t.ClientId
+
+			case "client_secret_hash":
+				row[i] = &t.ClientSecretHash
+
+			case "created_at":
+				row[i] = &t.CreatedAt
+
+			case "is_public":
+				row[i] = &t.IsPublic
+
+			case "jwt_encryption_key":
+				row[i] = &t.JwtEncryptionKey
+
+			case "name":
+				row[i] = &t.Name
+
+			case "redirect_uris":
+				row[i] = &t.RedirectUris
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *OauthClientsDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *OauthClientsDef) TableName() string {
+	return "oauth_clients"
+}
+
+func (s *OauthClientsDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *OauthClientsDef) Bind(v OauthClients) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &OauthClientsAllowedScopesColumn{}, Value: v.AllowedScopes},
+
+		cqlc.ColumnBinding{Column: &OauthClientsClientIdColumn{}, Value: v.ClientId},
+
+		cqlc.ColumnBinding{Column: &OauthClientsClientSecretHashColumn{}, Value: v.ClientSecretHash},
+
+		cqlc.ColumnBinding{Column: &OauthClientsCreatedAtColumn{}, Value: v.CreatedAt},
+
+		cqlc.ColumnBinding{Column: &OauthClientsIsPublicColumn{}, Value: v.IsPublic},
+
+		cqlc.ColumnBinding{Column: &OauthClientsJwtEncryptionKeyColumn{}, Value: v.JwtEncryptionKey},
+
+		cqlc.ColumnBinding{Column: &OauthClientsNameColumn{}, Value: v.Name},
+
+		cqlc.ColumnBinding{Column: &OauthClientsRedirectUrisColumn{}, Value: v.RedirectUris},
+	}
+	return cqlc.TableBinding{Table: &OauthClientsDef{}, Columns: cols}
+}
+
+func (s *OauthClientsDef) To(v *OauthClients) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &OauthClientsAllowedScopesColumn{}, Value: &v.AllowedScopes},
+
+		cqlc.ColumnBinding{Column: &OauthClientsClientIdColumn{}, Value: &v.ClientId},
+
+		cqlc.ColumnBinding{Column: &OauthClientsClientSecretHashColumn{}, Value: &v.ClientSecretHash},
+
+		cqlc.ColumnBinding{Column: &OauthClientsCreatedAtColumn{}, Value: &v.CreatedAt},
+
+		cqlc.ColumnBinding{Column: &OauthClientsIsPublicColumn{}, Value: &v.IsPublic},
+
+		cqlc.ColumnBinding{Column: &OauthClientsJwtEncryptionKeyColumn{}, Value: &v.JwtEncryptionKey},
+
+		cqlc.ColumnBinding{Column: &OauthClientsNameColumn{}, Value: &v.Name},
+
+		cqlc.ColumnBinding{Column: &OauthClientsRedirectUrisColumn{}, Value: &v.RedirectUris},
+	}
+	return cqlc.TableBinding{Table: &OauthClientsDef{}, Columns: cols}
+}
+
+func (s *OauthClientsDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&OauthClientsAllowedScopesColumn{},
+
+		&OauthClientsClientIdColumn{},
+
+		&OauthClientsClientSecretHashColumn{},
+
+		&OauthClientsCreatedAtColumn{},
+
+		&OauthClientsIsPublicColumn{},
+
+		&OauthClientsJwtEncryptionKeyColumn{},
+
+		&OauthClientsNameColumn{},
+
+		&OauthClientsRedirectUrisColumn{},
+	}
+}
+
+func OauthClientsTableDef() *OauthClientsDef {
+	return &OauthClientsDef{
+
+		ALLOWED_SCOPES: &OauthClientsAllowedScopesColumn{},
+
+		CLIENT_ID: &OauthClientsClientIdColumn{},
+
+		CLIENT_SECRET_HASH: &OauthClientsClientSecretHashColumn{},
+
+		CREATED_AT: &OauthClientsCreatedAtColumn{},
+
+		IS_PUBLIC: &OauthClientsIsPublicColumn{},
+
+		JWT_ENCRYPTION_KEY: &OauthClientsJwtEncryptionKeyColumn{},
+
+		NAME: &OauthClientsNameColumn{},
+
+		REDIRECT_URIS: &OauthClientsRedirectUrisColumn{},
+	}
+}
+
+func (s *OauthClientsDef) AllowedScopesColumn() cqlc.StringColumn {
+	return &OauthClientsAllowedScopesColumn{}
+}
+
+func (s *OauthClientsDef) ClientIdColumn() cqlc.LastPartitionedStringColumn {
+	return &OauthClientsClientIdColumn{}
+}
+
+func (s *OauthClientsDef) ClientSecretHashColumn() cqlc.StringColumn {
+	return &OauthClientsClientSecretHashColumn{}
+}
+
+func (s *OauthClientsDef) CreatedAtColumn() cqlc.TimestampColumn {
+	return &OauthClientsCreatedAtColumn{}
+}
+
+func (s *OauthClientsDef) IsPublicColumn() cqlc.BooleanColumn {
+	return &OauthClientsIsPublicColumn{}
+}
+
+func (s *OauthClientsDef) JwtEncryptionKeyColumn() cqlc.StringColumn {
+	return &OauthClientsJwtEncryptionKeyColumn{}
+}
+
+func (s *OauthClientsDef) NameColumn() cqlc.StringColumn {
+	return &OauthClientsNameColumn{}
+}
+
+func (s *OauthClientsDef) RedirectUrisColumn() cqlc.StringColumn {
+	return &OauthClientsRedirectUrisColumn{}
+}
+
+type PasswordHistoryChangedAtColumn struct {
+	desc bool
+}
+
+func (b *PasswordHistoryChangedAtColumn) ColumnName() string {
+	return "changed_at"
+}
+
+func (b *PasswordHistoryChangedAtColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *PasswordHistoryChangedAtColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *PasswordHistoryChangedAtColumn) Desc() cqlc.ClusteredColumn {
+	return &PasswordHistoryChangedAtColumn{desc: true}
+}
+
+func (b *PasswordHistoryChangedAtColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *PasswordHistoryChangedAtColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &PasswordHistoryChangedAtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *PasswordHistoryChangedAtColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &PasswordHistoryChangedAtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *PasswordHistoryChangedAtColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &PasswordHistoryChangedAtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *PasswordHistoryChangedAtColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &PasswordHistoryChangedAtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *PasswordHistoryChangedAtColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &PasswordHistoryChangedAtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *PasswordHistoryChangedAtColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &PasswordHistoryChangedAtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type PasswordHistoryEncryptedPasswordColumn struct {
+}
+
+func (b *PasswordHistoryEncryptedPasswordColumn) ColumnName() string {
+	return "encrypted_password"
+}
+
+func (b *PasswordHistoryEncryptedPasswordColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type PasswordHistoryUserEmailColumn struct {
+}
+
+func (b *PasswordHistoryUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *PasswordHistoryUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *PasswordHistoryUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &PasswordHistoryUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *PasswordHistoryUserEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *PasswordHistoryUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &PasswordHistoryUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type PasswordHistory struct {
+	ChangedAt gocql.UUID
+
+	EncryptedPassword string
+
+	UserEmail string
+}
+
+func (s *PasswordHistory) ChangedAtValue() gocql.UUID {
+	return s.ChangedAt
+}
+
+func (s *PasswordHistory) EncryptedPasswordValue() string {
+	return s.EncryptedPassword
+}
+
+func (s *PasswordHistory) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type PasswordHistoryDef struct {
+	CHANGED_AT cqlc.LastClusteredTimeUUIDColumn
+
+	ENCRYPTED_PASSWORD cqlc.StringColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
+}
+
+func BindPasswordHistory(iter *gocql.Iter) ([]PasswordHistory, error) {
+	array := make([]PasswordHistory, 0)
+	err := MapPasswordHistory(iter, func(t PasswordHistory) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapPasswordHistory(iter *gocql.Iter, callback func(t PasswordHistory) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := PasswordHistory{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "changed_at":
+				row[i] = &t.ChangedAt
+
+			case "encrypted_password":
+				row[i] = &t.EncryptedPassword
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *PasswordHistoryDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *PasswordHistoryDef) TableName() string {
+	return "password_history"
+}
+
+func (s *PasswordHistoryDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *PasswordHistoryDef) Bind(v PasswordHistory) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &PasswordHistoryChangedAtColumn{}, Value: v.ChangedAt},
+
+		cqlc.ColumnBinding{Column: &PasswordHistoryEncryptedPasswordColumn{}, Value: v.EncryptedPassword},
+
+		cqlc.ColumnBinding{Column: &PasswordHistoryUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &PasswordHistoryDef{}, Columns: cols}
+}
+
+func (s *PasswordHistoryDef) To(v *PasswordHistory) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &PasswordHistoryChangedAtColumn{}, Value: &v.ChangedAt},
+
+		cqlc.ColumnBinding{Column: &PasswordHistoryEncryptedPasswordColumn{}, Value: &v.EncryptedPassword},
+
+		cqlc.ColumnBinding{Column: &PasswordHistoryUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &PasswordHistoryDef{}, Columns: cols}
+}
+
+func (s *PasswordHistoryDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&PasswordHistoryChangedAtColumn{},
+
+		&PasswordHistoryEncryptedPasswordColumn{},
+
+		&PasswordHistoryUserEmailColumn{},
+	}
+}
+
+func PasswordHistoryTableDef() *PasswordHistoryDef {
+	return &PasswordHistoryDef{
+
+		CHANGED_AT: &PasswordHistoryChangedAtColumn{},
+
+		ENCRYPTED_PASSWORD: &PasswordHistoryEncryptedPasswordColumn{},
+
+		USER_EMAIL: &PasswordHistoryUserEmailColumn{},
+	}
+}
+
+func (s *PasswordHistoryDef) ChangedAtColumn() cqlc.LastClusteredTimeUUIDColumn {
+	return &PasswordHistoryChangedAtColumn{}
+}
+
+func (s *PasswordHistoryDef) EncryptedPasswordColumn() cqlc.StringColumn {
+	return &PasswordHistoryEncryptedPasswordColumn{}
+}
+
+func (s *PasswordHistoryDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &PasswordHistoryUserEmailColumn{}
+}
+
+type PasswordResetsExpiresAtColumn struct {
+}
+
+func (b *PasswordResetsExpiresAtColumn) ColumnName() string {
+	return "expires_at"
+}
+
+func (b *PasswordResetsExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type PasswordResetsTokenIdColumn struct {
+}
+
+func (b *PasswordResetsTokenIdColumn) ColumnName() string {
+	return "token_id"
+}
+
+func (b *PasswordResetsTokenIdColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *PasswordResetsTokenIdColumn) Eq(value string) cqlc.Condition {
+	column := &PasswordResetsTokenIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *PasswordResetsTokenIdColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *PasswordResetsTokenIdColumn) In(value ...string) cqlc.Condition {
+	column := &PasswordResetsTokenIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type PasswordResetsUserEmailColumn struct {
+}
+
+func (b *PasswordResetsUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *PasswordResetsUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type PasswordResets struct {
+	ExpiresAt time.Time
+
+	TokenId string
+
+	UserEmail string
+}
+
+func (s *PasswordResets) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
+
+func (s *PasswordResets) TokenIdValue() string {
+	return s.TokenId
+}
+
+func (s *PasswordResets) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type PasswordResetsDef struct {
+	EXPIRES_AT cqlc.TimestampColumn
+
+	TOKEN_ID cqlc.LastPartitionedStringColumn
+
+	USER_EMAIL cqlc.StringColumn
+}
+
+func BindPasswordResets(iter *gocql.Iter) ([]PasswordResets, error) {
+	array := make([]PasswordResets, 0)
+	err := MapPasswordResets(iter, func(t PasswordResets) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapPasswordResets(iter *gocql.Iter, callback func(t PasswordResets) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := PasswordResets{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "token_id":
+				row[i] = &t.TokenId
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *PasswordResetsDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *PasswordResetsDef) TableName() string {
+	return "password_resets"
+}
+
+func (s *PasswordResetsDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *PasswordResetsDef) Bind(v PasswordResets) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &PasswordResetsExpiresAtColumn{}, Value: v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &PasswordResetsTokenIdColumn{}, Value: v.TokenId},
+
+		cqlc.ColumnBinding{Column: &PasswordResetsUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &PasswordResetsDef{}, Columns: cols}
+}
+
+func (s *PasswordResetsDef) To(v *PasswordResets) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &PasswordResetsExpiresAtColumn{}, Value: &v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &PasswordResetsTokenIdColumn{}, Value: &v.TokenId},
+
+		cqlc.ColumnBinding{Column: &PasswordResetsUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &PasswordResetsDef{}, Columns: cols}
+}
+
+func (s *PasswordResetsDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&PasswordResetsExpiresAtColumn{},
+
+		&PasswordResetsTokenIdColumn{},
+
+		&PasswordResetsUserEmailColumn{},
+	}
+}
+
+func PasswordResetsTableDef() *PasswordResetsDef {
+	return &PasswordResetsDef{
+
+		EXPIRES_AT: &PasswordResetsExpiresAtColumn{},
+
+		TOKEN_ID: &PasswordResetsTokenIdColumn{},
+
+		USER_EMAIL: &PasswordResetsUserEmailColumn{},
+	}
+}
+
+func (s *PasswordResetsDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &PasswordResetsExpiresAtColumn{}
+}
+
+func (s *PasswordResetsDef) TokenIdColumn() cqlc.LastPartitionedStringColumn {
+	return &PasswordResetsTokenIdColumn{}
+}
+
+func (s *PasswordResetsDef) UserEmailColumn() cqlc.StringColumn {
+	return &PasswordResetsUserEmailColumn{}
+}
+
+type RateLimitsRequestIdColumn struct {
+	desc bool
+}
+
+func (b *RateLimitsRequestIdColumn) ColumnName() string {
+	return "request_id"
+}
+
+func (b *RateLimitsRequestIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RateLimitsRequestIdColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *RateLimitsRequestIdColumn) Desc() cqlc.ClusteredColumn {
+	return &RateLimitsRequestIdColumn{desc: true}
+}
+
+func (b *RateLimitsRequestIdColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *RateLimitsRequestIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &RateLimitsRequestIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RateLimitsRequestIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &RateLimitsRequestIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *RateLimitsRequestIdColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &RateLimitsRequestIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *RateLimitsRequestIdColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &RateLimitsRequestIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *RateLimitsRequestIdColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &RateLimitsRequestIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *RateLimitsRequestIdColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &RateLimitsRequestIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type RateLimitsSubjectColumn struct {
+}
+
+func (b *RateLimitsSubjectColumn) ColumnName() string {
+	return "subject"
+}
+
+func (b *RateLimitsSubjectColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RateLimitsSubjectColumn) Eq(value string) cqlc.Condition {
+	column := &RateLimitsSubjectColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RateLimitsSubjectColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *RateLimitsSubjectColumn) In(value ...string) cqlc.Condition {
+	column := &RateLimitsSubjectColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type RateLimits struct {
+	RequestId gocql.UUID
+
+	Subject string
+}
+
+func (s *RateLimits) RequestIdValue() gocql.UUID {
+	return s.RequestId
+}
+
+func (s *RateLimits) SubjectValue() string {
+	return s.Subject
+}
+
+type RateLimitsDef struct {
+	REQUEST_ID cqlc.LastClusteredTimeUUIDColumn
+
+	SUBJECT cqlc.LastPartitionedStringColumn
+}
+
+func BindRateLimits(iter *gocql.Iter) ([]RateLimits, error) {
+	array := make([]RateLimits, 0)
+	err := MapRateLimits(iter, func(t RateLimits) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapRateLimits(iter *gocql.Iter, callback func(t RateLimits) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := RateLimits{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "request_id":
+				row[i] = &t.RequestId
+
+			case "subject":
+				row[i] = &t.Subject
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *RateLimitsDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *RateLimitsDef) TableName() string {
+	return "rate_limits"
+}
+
+func (s *RateLimitsDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *RateLimitsDef) Bind(v RateLimits) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RateLimitsRequestIdColumn{}, Value: v.RequestId},
+
+		cqlc.ColumnBinding{Column: &RateLimitsSubjectColumn{}, Value: v.Subject},
+	}
+	return cqlc.TableBinding{Table: &RateLimitsDef{}, Columns: cols}
+}
+
+func (s *RateLimitsDef) To(v *RateLimits) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RateLimitsRequestIdColumn{}, Value: &v.RequestId},
+
+		cqlc.ColumnBinding{Column: &RateLimitsSubjectColumn{}, Value: &v.Subject},
+	}
+	return cqlc.TableBinding{Table: &RateLimitsDef{}, Columns: cols}
+}
+
+func (s *RateLimitsDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&RateLimitsRequestIdColumn{},
+
+		&RateLimitsSubjectColumn{},
+	}
+}
+
+func RateLimitsTableDef() *RateLimitsDef {
+	return &RateLimitsDef{
+
+		REQUEST_ID: &RateLimitsRequestIdColumn{},
+
+		SUBJECT: &RateLimitsSubjectColumn{},
+	}
+}
+
+func (s *RateLimitsDef) RequestIdColumn() cqlc.LastClusteredTimeUUIDColumn {
+	return &RateLimitsRequestIdColumn{}
+}
+
+func (s *RateLimitsDef) SubjectColumn() cqlc.LastPartitionedStringColumn {
+	return &RateLimitsSubjectColumn{}
+}
+
+type RecoveryCodesCodeHashColumn struct {
+	desc bool
+}
+
+func (b *RecoveryCodesCodeHashColumn) ColumnName() string {
+	return "code_hash"
+}
+
+func (b *RecoveryCodesCodeHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RecoveryCodesCodeHashColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *RecoveryCodesCodeHashColumn) Desc() cqlc.ClusteredColumn {
+	return &RecoveryCodesCodeHashColumn{desc: true}
+}
+
+func (b *RecoveryCodesCodeHashColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *RecoveryCodesCodeHashColumn) Eq(value string) cqlc.Condition {
+	column := &RecoveryCodesCodeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RecoveryCodesCodeHashColumn) In(value ...string) cqlc.Condition {
+	column := &RecoveryCodesCodeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *RecoveryCodesCodeHashColumn) Gt(value string) cqlc.Condition {
+	column := &RecoveryCodesCodeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *RecoveryCodesCodeHashColumn) Ge(value string) cqlc.Condition {
+	column := &RecoveryCodesCodeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *RecoveryCodesCodeHashColumn) Lt(value string) cqlc.Condition {
+	column := &RecoveryCodesCodeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *RecoveryCodesCodeHashColumn) Le(value string) cqlc.Condition {
+	column := &RecoveryCodesCodeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type RecoveryCodesCreatedAtColumn struct {
+}
+
+func (b *RecoveryCodesCreatedAtColumn) ColumnName() string {
+	return "created_at"
+}
+
+func (b *RecoveryCodesCreatedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RecoveryCodesUserEmailColumn struct {
+}
+
+func (b *RecoveryCodesUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *RecoveryCodesUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RecoveryCodesUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &RecoveryCodesUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RecoveryCodesUserEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *RecoveryCodesUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &RecoveryCodesUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type RecoveryCodes struct {
+	CodeHash string
+
+	CreatedAt time.Time
+
+	UserEmail string
+}
+
+func (s *RecoveryCodes) CodeHashValue() string {
+	return s.CodeHash
+}
+
+func (s *RecoveryCodes) CreatedAtValue() time.Time {
+	return s.CreatedAt
+}
+
+func (s *RecoveryCodes) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type RecoveryCodesDef struct {
+	CODE_HASH cqlc.LastClusteredStringColumn
+
+	CREATED_AT cqlc.TimestampColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
+}
+
+func BindRecoveryCodes(iter *gocql.Iter) ([]RecoveryCodes, error) {
+	array := make([]RecoveryCodes, 0)
+	err := MapRecoveryCodes(iter, func(t RecoveryCodes) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapRecoveryCodes(iter *gocql.Iter, callback func(t RecoveryCodes) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := RecoveryCodes{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "code_hash":
+				row[i] = &t.CodeHash
+
+			case "created_at":
+				row[i] = &t.CreatedAt
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *RecoveryCodesDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *RecoveryCodesDef) TableName() string {
+	return "recovery_codes"
+}
+
+func (s *RecoveryCodesDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *RecoveryCodesDef) Bind(v RecoveryCodes) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RecoveryCodesCodeHashColumn{}, Value: v.CodeHash},
+
+		cqlc.ColumnBinding{Column: &RecoveryCodesCreatedAtColumn{}, Value: v.CreatedAt},
+
+		cqlc.ColumnBinding{Column: &RecoveryCodesUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &RecoveryCodesDef{}, Columns: cols}
+}
+
+func (s *RecoveryCodesDef) To(v *RecoveryCodes) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RecoveryCodesCodeHashColumn{}, Value: &v.CodeHash},
+
+		cqlc.ColumnBinding{Column: &RecoveryCodesCreatedAtColumn{}, Value: &v.CreatedAt},
+
+		cqlc.ColumnBinding{Column: &RecoveryCodesUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &RecoveryCodesDef{}, Columns: cols}
+}
+
+func (s *RecoveryCodesDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&RecoveryCodesCodeHashColumn{},
+
+		&RecoveryCodesCreatedAtColumn{},
+
+		&RecoveryCodesUserEmailColumn{},
+	}
+}
+
+func RecoveryCodesTableDef() *RecoveryCodesDef {
+	return &RecoveryCodesDef{
+
+		CODE_HASH: &RecoveryCodesCodeHashColumn{},
+
+		CREATED_AT: &RecoveryCodesCreatedAtColumn{},
+
+		USER_EMAIL: &RecoveryCodesUserEmailColumn{},
+	}
+}
+
+func (s *RecoveryCodesDef) CodeHashColumn() cqlc.LastClusteredStringColumn {
+	return &RecoveryCodesCodeHashColumn{}
+}
+
+func (s *RecoveryCodesDef) CreatedAtColumn() cqlc.TimestampColumn {
+	return &RecoveryCodesCreatedAtColumn{}
+}
+
+func (s *RecoveryCodesDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &RecoveryCodesUserEmailColumn{}
+}
+
+type RefreshTokenFamiliesCreatedAtColumn struct {
+}
+
+func (b *RefreshTokenFamiliesCreatedAtColumn) ColumnName() string {
+	return "created_at"
+}
+
+func (b *RefreshTokenFamiliesCreatedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokenFamiliesExpiresAtColumn struct {
+}
+
+func (b *RefreshTokenFamiliesExpiresAtColumn) ColumnName() string {
+	return "expires_at"
+}
+
+func (b *RefreshTokenFamiliesExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokenFamiliesFamilyIdColumn struct {
+	desc bool
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) ColumnName() string {
+	return "family_id"
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) ClusterWith() string {
+	return b.ColumnName()
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) Desc() cqlc.ClusteredColumn {
+	return &RefreshTokenFamiliesFamilyIdColumn{desc: true}
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) IsDescending() bool {
+	return b.desc
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &RefreshTokenFamiliesFamilyIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &RefreshTokenFamiliesFamilyIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+func (b *RefreshTokenFamiliesFamilyIdColumn) Gt(value gocql.UUID) cqlc.Condition {
+	column := &RefreshTokenFamiliesFamilyIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *RefreshTokenFamiliesFamilyIdColumn) Ge(value gocql.UUID) cqlc.Condition {
+	column := &RefreshTokenFamiliesFamilyIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *RefreshTokenFamiliesFamilyIdColumn) Lt(value gocql.UUID) cqlc.Condition {
+	column := &RefreshTokenFamiliesFamilyIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *RefreshTokenFamiliesFamilyIdColumn) Le(value gocql.UUID) cqlc.Condition {
+	column := &RefreshTokenFamiliesFamilyIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
+}
+
+type RefreshTokenFamiliesIsRevokedColumn struct {
+}
+
+func (b *RefreshTokenFamiliesIsRevokedColumn) ColumnName() string {
+	return "is_revoked"
+}
+
+func (b *RefreshTokenFamiliesIsRevokedColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokenFamiliesLastSeenAtColumn struct {
+}
+
+func (b *RefreshTokenFamiliesLastSeenAtColumn) ColumnName() string {
+	return "last_seen_at"
+}
+
+func (b *RefreshTokenFamiliesLastSeenAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokenFamiliesRemoteAddrColumn struct {
+}
+
+func (b *RefreshTokenFamiliesRemoteAddrColumn) ColumnName() string {
+	return "remote_addr"
+}
+
+func (b *RefreshTokenFamiliesRemoteAddrColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokenFamiliesUserAgentColumn struct {
+}
+
+func (b *RefreshTokenFamiliesUserAgentColumn) ColumnName() string {
+	return "user_agent"
+}
+
+func (b *RefreshTokenFamiliesUserAgentColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokenFamiliesUserEmailColumn struct {
+}
+
+func (b *RefreshTokenFamiliesUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *RefreshTokenFamiliesUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RefreshTokenFamiliesUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &RefreshTokenFamiliesUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RefreshTokenFamiliesUserEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *RefreshTokenFamiliesUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &RefreshTokenFamiliesUserEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type RefreshTokenFamilies struct {
+	CreatedAt time.Time
+
+	ExpiresAt time.Time
+
+	FamilyId gocql.UUID
+
+	IsRevoked bool
+
+	LastSeenAt time.Time
+
+	RemoteAddr string
+
+	UserAgent string
+
+	UserEmail string
+}
+
+func (s *RefreshTokenFamilies) CreatedAtValue() time.Time {
+	return s.CreatedAt
+}
+
+func (s *RefreshTokenFamilies) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
+
+func (s *RefreshTokenFamilies) FamilyIdValue() gocql.UUID {
+	return s.FamilyId
+}
+
+func (s *RefreshTokenFamilies) IsRevokedValue() bool {
+	return s.IsRevoked
+}
+
+func (s *RefreshTokenFamilies) LastSeenAtValue() time.Time {
+	return s.LastSeenAt
+}
+
+func (s *RefreshTokenFamilies) RemoteAddrValue() string {
+	return s.RemoteAddr
+}
+
+func (s *RefreshTokenFamilies) UserAgentValue() string {
+	return s.UserAgent
+}
+
+func (s *RefreshTokenFamilies) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type RefreshTokenFamiliesDef struct {
+	CREATED_AT cqlc.TimestampColumn
+
+	EXPIRES_AT cqlc.TimestampColumn
+
+	FAMILY_ID cqlc.LastClusteredUUIDColumn
+
+	IS_REVOKED cqlc.BooleanColumn
+
+	LAST_SEEN_AT cqlc.TimestampColumn
+
+	REMOTE_ADDR cqlc.StringColumn
+
+	USER_AGENT cqlc.StringColumn
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
+}
+
+func BindRefreshTokenFamilies(iter *gocql.Iter) ([]RefreshTokenFamilies, error) {
+	array := make([]RefreshTokenFamilies, 0)
+	err := MapRefreshTokenFamilies(iter, func(t RefreshTokenFamilies) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapRefreshTokenFamilies(iter *gocql.Iter, callback func(t RefreshTokenFamilies) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := RefreshTokenFamilies{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "created_at":
+				row[i] = &t.CreatedAt
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "family_id":
+				row[i] = &t.FamilyId
+
+			case "is_revoked":
+				row[i] = &t.IsRevoked
+
+			case "last_seen_at":
+				row[i] = &t.LastSeenAt
+
+			case "remote_addr":
+				row[i] = &t.RemoteAddr
+
+			case "user_agent":
+				row[i] = &t.UserAgent
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *RefreshTokenFamiliesDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *RefreshTokenFamiliesDef) TableName() string {
+	return "refresh_token_families"
+}
+
+func (s *RefreshTokenFamiliesDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *RefreshTokenFamiliesDef) Bind(v RefreshTokenFamilies) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesCreatedAtColumn{}, Value: v.CreatedAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesExpiresAtColumn{}, Value: v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesFamilyIdColumn{}, Value: v.FamilyId},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesIsRevokedColumn{}, Value: v.IsRevoked},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesLastSeenAtColumn{}, Value: v.LastSeenAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesRemoteAddrColumn{}, Value: v.RemoteAddr},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesUserAgentColumn{}, Value: v.UserAgent},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &RefreshTokenFamiliesDef{}, Columns: cols}
+}
+
+func (s *RefreshTokenFamiliesDef) To(v *RefreshTokenFamilies) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesCreatedAtColumn{}, Value: &v.CreatedAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesExpiresAtColumn{}, Value: &v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesFamilyIdColumn{}, Value: &v.FamilyId},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesIsRevokedColumn{}, Value: &v.IsRevoked},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesLastSeenAtColumn{}, Value: &v.LastSeenAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesRemoteAddrColumn{}, Value: &v.RemoteAddr},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesUserAgentColumn{}, Value: &v.UserAgent},
+
+		cqlc.ColumnBinding{Column: &RefreshTokenFamiliesUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &RefreshTokenFamiliesDef{}, Columns: cols}
+}
+
+func (s *RefreshTokenFamiliesDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&RefreshTokenFamiliesCreatedAtColumn{},
+
+		&RefreshTokenFamiliesExpiresAtColumn{},
+
+		&RefreshTokenFamiliesFamilyIdColumn{},
+
+		&RefreshTokenFamiliesIsRevokedColumn{},
+
+		&RefreshTokenFamiliesLastSeenAtColumn{},
+
+		&RefreshTokenFamiliesRemoteAddrColumn{},
+
+		&RefreshTokenFamiliesUserAgentColumn{},
+
+		&RefreshTokenFamiliesUserEmailColumn{},
+	}
+}
+
+func RefreshTokenFamiliesTableDef() *RefreshTokenFamiliesDef {
+	return &RefreshTokenFamiliesDef{
+
+		CREATED_AT: &RefreshTokenFamiliesCreatedAtColumn{},
+
+		EXPIRES_AT: &RefreshTokenFamiliesExpiresAtColumn{},
+
+		FAMILY_ID: &RefreshTokenFamiliesFamilyIdColumn{},
+
+		IS_REVOKED: &RefreshTokenFamiliesIsRevokedColumn{},
+
+		LAST_SEEN_AT: &RefreshTokenFamiliesLastSeenAtColumn{},
+
+		REMOTE_ADDR: &RefreshTokenFamiliesRemoteAddrColumn{},
+
+		USER_AGENT: &RefreshTokenFamiliesUserAgentColumn{},
+
+		USER_EMAIL: &RefreshTokenFamiliesUserEmailColumn{},
+	}
+}
+
+func (s *RefreshTokenFamiliesDef) CreatedAtColumn() cqlc.TimestampColumn {
+	return &RefreshTokenFamiliesCreatedAtColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &RefreshTokenFamiliesExpiresAtColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) FamilyIdColumn() cqlc.LastClusteredUUIDColumn {
+	return &RefreshTokenFamiliesFamilyIdColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) IsRevokedColumn() cqlc.BooleanColumn {
+	return &RefreshTokenFamiliesIsRevokedColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) LastSeenAtColumn() cqlc.TimestampColumn {
+	return &RefreshTokenFamiliesLastSeenAtColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) RemoteAddrColumn() cqlc.StringColumn {
+	return &RefreshTokenFamiliesRemoteAddrColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) UserAgentColumn() cqlc.StringColumn {
+	return &RefreshTokenFamiliesUserAgentColumn{}
+}
+
+func (s *RefreshTokenFamiliesDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &RefreshTokenFamiliesUserEmailColumn{}
+}
+
+type RefreshTokensExpiresAtColumn struct {
+}
+
+func (b *RefreshTokensExpiresAtColumn) ColumnName() string {
+	return "expires_at"
+}
+
+func (b *RefreshTokensExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokensFamilyIdColumn struct {
+}
+
+func (b *RefreshTokensFamilyIdColumn) ColumnName() string {
+	return "family_id"
+}
+
+func (b *RefreshTokensFamilyIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokensIsUsedColumn struct {
+}
+
+func (b *RefreshTokensIsUsedColumn) ColumnName() string {
+	return "is_used"
+}
+
+func (b *RefreshTokensIsUsedColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokensIssuedAtColumn struct {
+}
+
+func (b *RefreshTokensIssuedAtColumn) ColumnName() string {
+	return "issued_at"
+}
+
+func (b *RefreshTokensIssuedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokensTokenHashColumn struct {
+}
+
+func (b *RefreshTokensTokenHashColumn) ColumnName() string {
+	return "token_hash"
+}
+
+func (b *RefreshTokensTokenHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *RefreshTokensTokenHashColumn) Eq(value string) cqlc.Condition {
+	column := &RefreshTokensTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *RefreshTokensTokenHashColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *RefreshTokensTokenHashColumn) In(value ...string) cqlc.Condition {
+	column := &RefreshTokensTokenHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type RefreshTokensUserEmailColumn struct {
+}
+
+func (b *RefreshTokensUserEmailColumn) ColumnName() string {
+	return "user_email"
+}
+
+func (b *RefreshTokensUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type RefreshTokens struct {
+	ExpiresAt time.Time
+
+	FamilyId gocql.UUID
+
+	IsUsed bool
+
+	IssuedAt time.Time
+
+	TokenHash string
+
+	UserEmail string
+}
+
+func (s *RefreshTokens) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
+
+func (s *RefreshTokens) FamilyIdValue() gocql.UUID {
+	return s.FamilyId
+}
+
+func (s *RefreshTokens) IsUsedValue() bool {
+	return s.IsUsed
+}
+
+func (s *RefreshTokens) IssuedAtValue() time.Time {
+	return s.IssuedAt
+}
+
+func (s *RefreshTokens) TokenHashValue() string {
+	return s.TokenHash
+}
+
+func (s *RefreshTokens) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type RefreshTokensDef struct {
+	EXPIRES_AT cqlc.TimestampColumn
+
+	FAMILY_ID cqlc.UUIDColumn
+
+	IS_USED cqlc.BooleanColumn
+
+	ISSUED_AT cqlc.TimestampColumn
+
+	TOKEN_HASH cqlc.LastPartitionedStringColumn
+
+	USER_EMAIL cqlc.StringColumn
+}
+
+func BindRefreshTokens(iter *gocql.Iter) ([]RefreshTokens, error) {
+	array := make([]RefreshTokens, 0)
+	err := MapRefreshTokens(iter, func(t RefreshTokens) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapRefreshTokens(iter *gocql.Iter, callback func(t RefreshTokens) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := RefreshTokens{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "family_id":
+				row[i] = &t.FamilyId
+
+			case "is_used":
+				row[i] = &t.IsUsed
+
+			case "issued_at":
+				row[i] = &t.IssuedAt
+
+			case "token_hash":
+				row[i] = &t.TokenHash
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *RefreshTokensDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *RefreshTokensDef) TableName() string {
+	return "refresh_tokens"
+}
+
+func (s *RefreshTokensDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *RefreshTokensDef) Bind(v RefreshTokens) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RefreshTokensExpiresAtColumn{}, Value: v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensFamilyIdColumn{}, Value: v.FamilyId},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensIsUsedColumn{}, Value: v.IsUsed},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensIssuedAtColumn{}, Value: v.IssuedAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensTokenHashColumn{}, Value: v.TokenHash},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &RefreshTokensDef{}, Columns: cols}
+}
+
+func (s *RefreshTokensDef) To(v *RefreshTokens) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &RefreshTokensExpiresAtColumn{}, Value: &v.ExpiresAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensFamilyIdColumn{}, Value: &v.FamilyId},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensIsUsedColumn{}, Value: &v.IsUsed},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensIssuedAtColumn{}, Value: &v.IssuedAt},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensTokenHashColumn{}, Value: &v.TokenHash},
+
+		cqlc.ColumnBinding{Column: &RefreshTokensUserEmailColumn{}, Value: &v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &RefreshTokensDef{}, Columns: cols}
+}
+
+func (s *RefreshTokensDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&RefreshTokensExpiresAtColumn{},
+
+		&RefreshTokensFamilyIdColumn{},
+
+		&RefreshTokensIsUsedColumn{},
+
+		&RefreshTokensIssuedAtColumn{},
+
+		&RefreshTokensTokenHashColumn{},
+
+		&RefreshTokensUserEmailColumn{},
+	}
+}
+
+func RefreshTokensTableDef() *RefreshTokensDef {
+	return &RefreshTokensDef{
+
+		EXPIRES_AT: &RefreshTokensExpiresAtColumn{},
+
+		FAMILY_ID: &RefreshTokensFamilyIdColumn{},
+
+		IS_USED: &RefreshTokensIsUsedColumn{},
+
+		ISSUED_AT: &RefreshTokensIssuedAtColumn{},
+
+		TOKEN_HASH: &RefreshTokensTokenHashColumn{},
+
+		USER_EMAIL: &RefreshTokensUserEmailColumn{},
+	}
+}
+
+func (s *RefreshTokensDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &RefreshTokensExpiresAtColumn{}
+}
+
+func (s *RefreshTokensDef) FamilyIdColumn() cqlc.UUIDColumn {
+	return &RefreshTokensFamilyIdColumn{}
+}
+
+func (s *RefreshTokensDef) IsUsedColumn() cqlc.BooleanColumn {
+	return &RefreshTokensIsUsedColumn{}
+}
+
+func (s *RefreshTokensDef) IssuedAtColumn() cqlc.TimestampColumn {
+	return &RefreshTokensIssuedAtColumn{}
+}
+
+func (s *RefreshTokensDef) TokenHashColumn() cqlc.LastPartitionedStringColumn {
+	return &RefreshTokensTokenHashColumn{}
+}
+
+func (s *RefreshTokensDef) UserEmailColumn() cqlc.StringColumn {
+	return &RefreshTokensUserEmailColumn{}
+}
+
+type SchemaMigrationsVersionColumn struct {
+}
+
+func (b *SchemaMigrationsVersionColumn) ColumnName() string {
+	return "version"
+}
+
+func (b *SchemaMigrationsVersionColumn) To(value *int64) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *SchemaMigrationsVersionColumn) CanIncrement() bool {
+	return true
+}
+
+type SchemaMigrationsVersionrowColumn struct {
+}
+
+func (b *SchemaMigrationsVersionrowColumn) ColumnName() string {
+	return "versionrow"
+}
+
+func (b *SchemaMigrationsVersionrowColumn) To(value *int64) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *SchemaMigrationsVersionrowColumn) Eq(value int64) cqlc.Condition {
+	column := &SchemaMigrationsVersionrowColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *SchemaMigrationsVersionrowColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *SchemaMigrationsVersionrowColumn) In(value ...int64) cqlc.Condition {
+	column := &SchemaMigrationsVersionrowColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type SchemaMigrations struct {
+	Version int64
+
+	Versionrow int64
+}
+
+func (s *SchemaMigrations) VersionValue() int64 {
+	return s.Version
+}
+
+func (s *SchemaMigrations) VersionrowValue() int64 {
+	return s.Versionrow
+}
+
+type SchemaMigrationsDef struct {
+	VERSION cqlc.CounterColumn
+
+	VERSIONROW cqlc.LastPartitionedInt64Column
+}
+
+func BindSchemaMigrations(iter *gocql.Iter) ([]SchemaMigrations, error) {
+	array := make([]SchemaMigrations, 0)
+	err := MapSchemaMigrations(iter, func(t SchemaMigrations) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapSchemaMigrations(iter *gocql.Iter, callback func(t SchemaMigrations) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := SchemaMigrations{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "version":
+				row[i] = &t.Version
+
+			case "versionrow":
+				row[i] = &t.Versionrow
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *SchemaMigrationsDef) IsCounterTable() bool {
+	return true
+}
+
+func (s *SchemaMigrationsDef) TableName() string {
+	return "schema_migrations"
+}
+
+func (s *SchemaMigrationsDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *SchemaMigrationsDef) Bind(v SchemaMigrations) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionColumn{}, Value: v.Version},
+
+		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionrowColumn{}, Value: v.Versionrow},
+	}
+	return cqlc.TableBinding{Table: &SchemaMigrationsDef{}, Columns: cols}
+}
+
+func (s *SchemaMigrationsDef) To(v *SchemaMigrations) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionColumn{}, Value: &v.Version},
+
+		cqlc.ColumnBinding{Column: &SchemaMigrationsVersionrowColumn{}, Value: &v.Versionrow},
+	}
+	return cqlc.TableBinding{Table: &SchemaMigrationsDef{}, Columns: cols}
+}
+
+func (s *SchemaMigrationsDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&SchemaMigrationsVersionColumn{},
+
+		&SchemaMigrationsVersionrowColumn{},
+	}
+}
+
+func SchemaMigrationsTableDef() *SchemaMigrationsDef {
+	return &SchemaMigrationsDef{
+
+		VERSION: &SchemaMigrationsVersionColumn{},
+
+		VERSIONROW: &SchemaMigrationsVersionrowColumn{},
+	}
+}
+
+func (s *SchemaMigrationsDef) VersionColumn() cqlc.CounterColumn {
+	return &SchemaMigrationsVersionColumn{}
+}
+
+func (s *SchemaMigrationsDef) VersionrowColumn() cqlc.LastPartitionedInt64Column {
+	return &SchemaMigrationsVersionrowColumn{}
+}
+
+type SettingsByExerciseIdentifierIdColumn struct {
+}
+
+func (b *SettingsByExerciseIdentifierIdColumn) ColumnName() string {
+	return "id"
+}
+
+func (b *SettingsByExerciseIdentifierIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *SettingsByExerciseIdentifierIdColumn) Eq(value gocql.UUID) cqlc.Condition {
+	column := &SettingsByExerciseIdentifierIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *SettingsByExerciseIdentifierIdColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *SettingsByExerciseIdentifierIdColumn) In(value ...gocql.UUID) cqlc.Condition {
+	column := &SettingsByExerciseIdentifierIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type SettingsByExerciseIdentifierIsDefaultColumn struct {
+}
+
+func (b *SettingsByExerciseIdentifierIsDefaultColumn) ColumnName() string {
+	return "is_default"
+}
+
+func (b *SettingsByExerciseIdentifierIsDefaultColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type SettingsByExerciseIdentifierSettingsColumn struct {
+}
+
+func (b *SettingsByExerciseIdentifierSettingsColumn) ColumnName() string {
+	return "settings"
+}
+
+func (b *SettingsByExerciseIdentifierSettingsColumn) To(value *map[string]string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type SettingsByExerciseIdentifierSettingsVersionColumn struct {
+}
+
+func (b *SettingsByExerciseIdentifierSettingsVersionColumn) ColumnName() string {
+	return "settings_version"
+}
+
+func (b *SettingsByExerciseIdentifierSettingsVersionColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type SettingsByExerciseIdentifier struct {
+	Id gocql.UUID
+
+	IsDefault bool
+
+	Settings map[string]string
+
+	SettingsVersion string
+}
+
+func (s *SettingsByExerciseIdentifier) IdValue() gocql.UUID {
+	return s.Id
+}
+
+func (s *SettingsByExerciseIdentifier) IsDefaultValue() bool {
+	return s.IsDefault
+}
+
+func (s *SettingsByExerciseIdentifier) SettingsValue() map[string]string {
+	return s.Settings
+}
+
+func (s *SettingsByExerciseIdentifier) SettingsVersionValue() string {
+	return s.SettingsVersion
+}
+
+type SettingsByExerciseIdentifierDef struct {
+	ID cqlc.LastPartitionedUUIDColumn
+
+	IS_DEFAULT cqlc.BooleanColumn
+
+	SETTINGS cqlc.StringStringMapColumn
+
+	SETTINGS_VERSION cqlc.StringColumn
+}
+
+func BindSettingsByExerciseIdentifier(iter *gocql.Iter) ([]SettingsByExerciseIdentifier, error) {
+	array := make([]SettingsByExerciseIdentifier, 0)
+	err := MapSettingsByExerciseIdentifier(iter, func(t SettingsByExerciseIdentifier) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapSettingsByExerciseIdentifier(iter *gocql.Iter, callback func(t SettingsByExerciseIdentifier) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := SettingsByExerciseIdentifier{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "id":
+				row[i] = &t.Id
+
+			case "is_default":
+				row[i] = &t.IsDefault
+
+			case "settings":
+				row[i] = &t.Settings
+
+			case "settings_version":
+				row[i] = &t.SettingsVersion
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *SettingsByExerciseIdentifierDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *SettingsByExerciseIdentifierDef) TableName() string {
+	return "settings_by_exercise_identifier"
+}
+
+func (s *SettingsByExerciseIdentifierDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *SettingsByExerciseIdentifierDef) Bind(v SettingsByExerciseIdentifier) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIdColumn{}, Value: v.Id},
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIsDefaultColumn{}, Value: v.IsDefault},
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsColumn{}, Value: v.Settings},
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsVersionColumn{}, Value: v.SettingsVersion},
+	}
+	return cqlc.TableBinding{Table: &SettingsByExerciseIdentifierDef{}, Columns: cols}
+}
+
+func (s *SettingsByExerciseIdentifierDef) To(v *SettingsByExerciseIdentifier) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIdColumn{}, Value: &v.Id},
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierIsDefaultColumn{}, Value: &v.IsDefault},
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsColumn{}, Value: &v.Settings},
+
+		cqlc.ColumnBinding{Column: &SettingsByExerciseIdentifierSettingsVersionColumn{}, Value: &v.SettingsVersion},
+	}
+	return cqlc.TableBinding{Table: &SettingsByExerciseIdentifierDef{}, Columns: cols}
+}
+
+func (s *SettingsByExerciseIdentifierDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&SettingsByExerciseIdentifierIdColumn{},
+
+		&SettingsByExerciseIdentifierIsDefaultColumn{},
+
+		&SettingsByExerciseIdentifierSettingsColumn{},
+
+		&SettingsByExerciseIdentifierSettingsVersionColumn{},
+	}
+}
+
+func SettingsByExerciseIdentifierTableDef() *SettingsByExerciseIdentifierDef {
+	return &SettingsByExerciseIdentifierDef{
+
+		ID: &SettingsByExerciseIdentifierIdColumn{},
+
+		IS_DEFAULT: &SettingsByExerciseIdentifierIsDefaultColumn{},
+
+		SETTINGS: &SettingsByExerciseIdentifierSettingsColumn{},
+
+		SETTINGS_VERSION: &SettingsByExerciseIdentifierSettingsVersionColumn{},
+	}
+}
+
+func (s *SettingsByExerciseIdentifierDef) IdColumn() cqlc.LastPartitionedUUIDColumn {
+	return &SettingsByExerciseIdentifierIdColumn{}
+}
+
+func (s *SettingsByExerciseIdentifierDef) IsDefaultColumn() cqlc.BooleanColumn {
+	return &SettingsByExerciseIdentifierIsDefaultColumn{}
+}
+
+func (s *SettingsByExerciseIdentifierDef) SettingsColumn() cqlc.StringStringMapColumn {
+	return &SettingsByExerciseIdentifierSettingsColumn{}
+}
+
+func (s *SettingsByExerciseIdentifierDef) SettingsVersionColumn() cqlc.StringColumn {
+	return &SettingsByExerciseIdentifierSettingsVersionColumn{}
+}
+
+type UsersByEmailAccessValidTillColumn struct {
+}
+
+func (b *UsersByEmailAccessValidTillColumn) ColumnName() string {
+	return "access_valid_till"
+}
+
+func (b *UsersByEmailAccessValidTillColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailAnalyticsUserTrackingTokenColumn struct {
+}
+
+func (b *UsersByEmailAnalyticsUserTrackingTokenColumn) ColumnName() string {
+	return "analytics_user_tracking_token"
+}
+
+func (b *UsersByEmailAnalyticsUserTrackingTokenColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailEmailColumn struct {
+}
+
+func (b *UsersByEmailEmailColumn) ColumnName() string {
+	return "email"
+}
+
+func (b *UsersByEmailEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+func (b *UsersByEmailEmailColumn) Eq(value string) cqlc.Condition {
+	column := &UsersByEmailEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
+
+func (b *UsersByEmailEmailColumn) PartitionBy() cqlc.Column {
+	return b
+}
+
+func (b *UsersByEmailEmailColumn) In(value ...string) cqlc.Condition {
+	column := &UsersByEmailEmailColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
+
+type UsersByEmailEncryptedPasswordColumn struct {
+}
+
+func (b *UsersByEmailEncryptedPasswordColumn) ColumnName() string {
+	return "encrypted_password"
+}
+
+func (b *UsersByEmailEncryptedPasswordColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailIsAdminColumn struct {
+}
+
+func (b *UsersByEmailIsAdminColumn) ColumnName() string {
+	return "is_admin"
+}
+
+func (b *UsersByEmailIsAdminColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailIsAnalyzedColumn struct {
+}
+
+func (b *UsersByEmailIsAnalyzedColumn) ColumnName() string {
+	return "is_analyzed"
+}
+
+func (b *UsersByEmailIsAnalyzedColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailIsBannedColumn struct {
+}
+
+func (b *UsersByEmailIsBannedColumn) ColumnName() string {
+	return "is_banned"
+}
+
+func (b *UsersByEmailIsBannedColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailIsConfirmedColumn struct {
+}
+
+func (b *UsersByEmailIsConfirmedColumn) ColumnName() string {
+	return "is_confirmed"
+}
+
+func (b *UsersByEmailIsConfirmedColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailIsEmployeeColumn struct {
+}
+
+func (b *UsersByEmailIsEmployeeColumn) ColumnName() string {
+	return "is_employee"
+}
+
+func (b *UsersByEmailIsEmployeeColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailIsExpiringColumn struct {
+}
+
+func (b *UsersByEmailIsExpiringColumn) ColumnName() string {
+	return "is_expiring"
+}
+
+func (b *UsersByEmailIsExpiringColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailJwtEncryptionKeyColumn struct {
+}
+
+func (b *UsersByEmailJwtEncryptionKeyColumn) ColumnName() string {
+	return "jwt_encryption_key"
+}
+
+func (b *UsersByEmailJwtEncryptionKeyColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailLastLoggedInColumn struct {
+}
+
+func (b *UsersByEmailLastLoggedInColumn) ColumnName() string {
+	return "last_logged_in"
+}
+
+func (b *UsersByEmailLastLoggedInColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailRealNameColumn struct {
+}
+
+func (b *UsersByEmailRealNameColumn) ColumnName() string {
+	return "real_name"
+}
+
+func (b *UsersByEmailRealNameColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailTotpEnabledColumn struct {
+}
+
+func (b *UsersByEmailTotpEnabledColumn) ColumnName() string {
+	return "totp_enabled"
+}
+
+func (b *UsersByEmailTotpEnabledColumn) To(value *bool) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailTotpLastStepColumn struct {
+}
+
+func (b *UsersByEmailTotpLastStepColumn) ColumnName() string {
+	return "totp_last_step"
+}
+
+func (b *UsersByEmailTotpLastStepColumn) To(value *int64) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailTotpSecretColumn struct {
+}
+
+func (b *UsersByEmailTotpSecretColumn) ColumnName() string {
+	return "totp_secret"
+}
+
+func (b *UsersByEmailTotpSecretColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailTwitterNameColumn struct {
+}
+
+func (b *UsersByEmailTwitterNameColumn) ColumnName() string {
+	return "twitter_name"
+}
+
+func (b *UsersByEmailTwitterNameColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailUserRankColumn struct {
+}
+
+func (b *UsersByEmailUserRankColumn) ColumnName() string {
+	return "user_rank"
+}
+
+func (b *UsersByEmailUserRankColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailUserSelfDescriptionColumn struct {
+}
+
+func (b *UsersByEmailUserSelfDescriptionColumn) ColumnName() string {
+	return "user_self_description"
+}
+
+func (b *UsersByEmailUserSelfDescriptionColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailUserTitleColumn struct {
+}
+
+func (b *UsersByEmailUserTitleColumn) ColumnName() string {
+	return "user_title"
+}
+
+func (b *UsersByEmailUserTitleColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmailUserUnitColumn struct {
+}
+
+func (b *UsersByEmailUserUnitColumn) ColumnName() string {
+	return "user_unit"
+}
+
+func (b *UsersByEmailUserUnitColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
+
+type UsersByEmail struct {
+	AccessValidTill time.Time
+
+	AnalyticsUserTrackingToken string
+
+	Email string
+
+	EncryptedPassword string
+
+	IsAdmin bool
+
+	IsAnalyzed bool
+
+	IsBanned bool
+
+	IsConfirmed bool
+
+	IsEmployee bool
+
+	IsExpiring bool
+
+	JwtEncryptionKey string
+
+	LastLoggedIn time.Time
+
+	RealName string
+
+	TotpEnabled bool
+
+	TotpLastStep int64
+
+	TotpSecret string
+
+	TwitterName string
+
+	UserRank string
+
+	UserSelfDescription string
+
+	UserTitle string
+
+	UserUnit string
+}
+
+func (s *UsersByEmail) AccessValidTillValue() time.Time {
+	return s.AccessValidTill
+}
+
+func (s *UsersByEmail) AnalyticsUserTrackingTokenValue() string {
+	return s.AnalyticsUserTrackingToken
+}
+
+func (s *UsersByEmail) EmailValue() string {
+	return s.Email
+}
+
+func (s *UsersByEmail) EncryptedPasswordValue() string {
+	return s.EncryptedPassword
+}
+
+func (s *UsersByEmail) IsAdminValue() bool {
+	return s.IsAdmin
+}
+
+func (s *UsersByEmail) IsAnalyzedValue() bool {
+	return s.IsAnalyzed
+}
+
+func (s *UsersByEmail) IsBannedValue() bool {
+	return s.IsBanned
+}
+
+func (s *UsersByEmail) IsConfirmedValue() bool {
+	return s.IsConfirmed
+}
+
+func (s *UsersByEmail) IsEmployeeValue() bool {
+	return s.IsEmployee
+}
+
+func (s *UsersByEmail) IsExpiringValue() bool {
+	return s.IsExpiring
+}
+
+func (s *UsersByEmail) JwtEncryptionKeyValue() string {
+	return s.JwtEncryptionKey
+}
+
+func (s *UsersByEmail) LastLoggedInValue() time.Time {
+	return s.LastLoggedIn
+}
+
+func (s *UsersByEmail) RealNameValue() string {
+	return s.RealName
+}
+
+func (s *UsersByEmail) TotpEnabledValue() bool {
+	return s.TotpEnabled
+}
+
+func (s *UsersByEmail) TotpLastStepValue() int64 {
+	return s.TotpLastStep
+}
+
+func (s *UsersByEmail) TotpSecretValue() string {
+	return s.TotpSecret
+}
+
+func (s *UsersByEmail) TwitterNameValue() string {
+	return s.TwitterName
+}
+
+func (s *UsersByEmail) UserRankValue() string {
+	return s.UserRank
+}
+
+func (s *UsersByEmail) UserSelfDescriptionValue() string {
+	return s.UserSelfDescription
+}
+
+func (s *UsersByEmail) UserTitleValue() string {
+	return s.UserTitle
+}
+
+func (s *UsersByEmail) UserUnitValue() string {
+	return s.UserUnit
+}
+
+type UsersByEmailDef struct {
+	ACCESS_VALID_TILL cqlc.TimestampColumn
+
+	ANALYTICS_USER_TRACKING_TOKEN cqlc.StringColumn
+
+	EMAIL cqlc.LastPartitionedStringColumn
+
+	ENCRYPTED_PASSWORD cqlc.StringColumn
+
+	IS_ADMIN cqlc.BooleanColumn
+
+	IS_ANALYZED cqlc.BooleanColumn
+
+	IS_BANNED cqlc.BooleanColumn
+
+	IS_CONFIRMED cqlc.BooleanColumn
+
+	IS_EMPLOYEE cqlc.BooleanColumn
+
+	IS_EXPIRING cqlc.BooleanColumn
+
+	JWT_ENCRYPTION_KEY cqlc.StringColumn
+
+	LAST_LOGGED_IN cqlc.TimestampColumn
+
+	REAL_NAME cqlc.StringColumn
+
+	TOTP_ENABLED cqlc.BooleanColumn
+
+	TOTP_LAST_STEP cqlc.Int64Column
+
+	TOTP_SECRET cqlc.StringColumn
+
+	TWITTER_NAME cqlc.StringColumn
+
+	USER_RANK cqlc.StringColumn
+
+	USER_SELF_DESCRIPTION cqlc.StringColumn
+
+	USER_TITLE cqlc.StringColumn
+
+	USER_UNIT cqlc.StringColumn
+}
+
+func BindUsersByEmail(iter *gocql.Iter) ([]UsersByEmail, error) {
+	array := make([]UsersByEmail, 0)
+	err := MapUsersByEmail(iter, func(t UsersByEmail) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapUsersByEmail(iter *gocql.Iter, callback func(t UsersByEmail) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := UsersByEmail{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "access_valid_till":
+				row[i] = &t.AccessValidTill
+
+			case "analytics_user_tracking_token":
+				row[i] = &t.AnalyticsUserTrackingToken
+
+			case "email":
+				row[i] = &t.Email
+
+			case "encrypted_password":
+				row[i] = &t.EncryptedPassword
+
+			case "is_admin":
+				row[i] = &t.IsAdmin
+
+			case "is_analyzed":
+				row[i] = &t.IsAnalyzed
+
+			case "is_banned":
+				row[i] = &t.IsBanned
+
+			case "is_confirmed":
+				row[i] = &t.IsConfirmed
+
+			case "is_employee":
+				row[i] = &t.IsEmployee
+
+			case "is_expiring":
+				row[i] = &t.IsExpiring
+
+			case "jwt_encryption_key":
+				row[i] = &t.JwtEncryptionKey
+
+			case "last_logged_in":
+				row[i] = &t.LastLoggedIn
+
+			case "real_name":
+				row[i] = &t.RealName
+
+			case "totp_enabled":
+				row[i] = &t.TotpEnabled
+
+			case "totp_last_step":
+				row[i] = &t.TotpLastStep
+
+			case "totp_secret":
+				row[i] = &t.TotpSecret
+
+			case "twitter_name":
+				row[i] = &t.TwitterName
+
+			case "user_rank":
+				row[i] = &t.UserRank
+
+			case "user_self_description":
+				row[i] = &t.UserSelfDescription
+
+			case "user_title":
+				row[i] = &t.UserTitle
+
+			case "user_unit":
+				row[i] = &t.UserUnit
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *UsersByEmailDef) SupportsUpsert() bool {
+	return true
+}
+
+func (s *UsersByEmailDef) TableName() string {
+	return "users_by_email"
+}
+
+func (s *UsersByEmailDef) Keyspace() string {
+	return "sitrep"
+}
+
+func (s *UsersByEmailDef) Bind(v UsersByEmail) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &UsersByEmailAccessValidTillColumn{}, Value: v.AccessValidTill},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailAnalyticsUserTrackingTokenColumn{}, Value: v.AnalyticsUserTrackingToken},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailEmailColumn{}, Value: v.Email},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailEncryptedPasswordColumn{}, Value: v.EncryptedPassword},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsAdminColumn{}, Value: v.IsAdmin},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsAnalyzedColumn{}, Value: v.IsAnalyzed},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsBannedColumn{}, Value: v.IsBanned},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsConfirmedColumn{}, Value: v.IsConfirmed},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsEmployeeColumn{}, Value: v.IsEmployee},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsExpiringColumn{}, Value: v.IsExpiring},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailJwtEncryptionKeyColumn{}, Value: v.JwtEncryptionKey},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailLastLoggedInColumn{}, Value: v.LastLoggedIn},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailRealNameColumn{}, Value: v.RealName},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTotpEnabledColumn{}, Value: v.TotpEnabled},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTotpLastStepColumn{}, Value: v.TotpLastStep},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTotpSecretColumn{}, Value: v.TotpSecret},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTwitterNameColumn{}, Value: v.TwitterName},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserRankColumn{}, Value: v.UserRank},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserSelfDescriptionColumn{}, Value: v.UserSelfDescription},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserTitleColumn{}, Value: v.UserTitle},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserUnitColumn{}, Value: v.UserUnit},
+	}
+	return cqlc.TableBinding{Table: &UsersByEmailDef{}, Columns: cols}
+}
+
+func (s *UsersByEmailDef) To(v *UsersByEmail) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
+
+		cqlc.ColumnBinding{Column: &UsersByEmailAccessValidTillColumn{}, Value: &v.AccessValidTill},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailAnalyticsUserTrackingTokenColumn{}, Value: &v.AnalyticsUserTrackingToken},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailEmailColumn{}, Value: &v.Email},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailEncryptedPasswordColumn{}, Value: &v.EncryptedPassword},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsAdminColumn{}, Value: &v.IsAdmin},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsAnalyzedColumn{}, Value: &v.IsAnalyzed},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsBannedColumn{}, Value: &v.IsBanned},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsConfirmedColumn{}, Value: &v.IsConfirmed},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsEmployeeColumn{}, Value: &v.IsEmployee},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailIsExpiringColumn{}, Value: &v.IsExpiring},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailJwtEncryptionKeyColumn{}, Value: &v.JwtEncryptionKey},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailLastLoggedInColumn{}, Value: &v.LastLoggedIn},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailRealNameColumn{}, Value: &v.RealName},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTotpEnabledColumn{}, Value: &v.TotpEnabled},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTotpLastStepColumn{}, Value: &v.TotpLastStep},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTotpSecretColumn{}, Value: &v.TotpSecret},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailTwitterNameColumn{}, Value: &v.TwitterName},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserRankColumn{}, Value: &v.UserRank},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserSelfDescriptionColumn{}, Value: &v.UserSelfDescription},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserTitleColumn{}, Value: &v.UserTitle},
+
+		cqlc.ColumnBinding{Column: &UsersByEmailUserUnitColumn{}, Value: &v.UserUnit},
+	}
+	return cqlc.TableBinding{Table: &UsersByEmailDef{}, Columns: cols}
+}
+
+func (s *UsersByEmailDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
+
+		&UsersByEmailAccessValidTillColumn{},
+
+		&UsersByEmailAnalyticsUserTrackingTokenColumn{},
+
+		&UsersByEmailEmailColumn{},
+
+		&UsersByEmailEncryptedPasswordColumn{},
+
+		&UsersByEmailIsAdminColumn{},
+
+		&UsersByEmailIsAnalyzedColumn{},
+
+		&UsersByEmailIsBannedColumn{},
+
+		&UsersByEmailIsConfirmedColumn{},
+
+		&UsersByEmailIsEmployeeColumn{},
+
+		&UsersByEmailIsExpiringColumn{},
+
+		&UsersByEmailJwtEncryptionKeyColumn{},
+
+		&UsersByEmailLastLoggedInColumn{},
+
+		&UsersByEmailRealNameColumn{},
+
+		&UsersByEmailTotpEnabledColumn{},
+
+		&UsersByEmailTotpLastStepColumn{},
+
+		&UsersByEmailTotpSecretColumn{},
+
+		&UsersByEmailTwitterNameColumn{},
+
+		&UsersByEmailUserRankColumn{},
+
+		&UsersByEmailUserSelfDescriptionColumn{},
+
+		&UsersByEmailUserTitleColumn{},
+
+		&UsersByEmailUserUnitColumn{},
+	}
+}
+
+func UsersByEmailTableDef() *UsersByEmailDef {
+	return &UsersByEmailDef{
+
+		ACCESS_VALID_TILL: &UsersByEmailAccessValidTillColumn{},
+
+		ANALYTICS_USER_TRACKING_TOKEN: &UsersByEmailAnalyticsUserTrackingTokenColumn{},
+
+		EMAIL: &UsersByEmailEmailColumn{},
+
+		ENCRYPTED_PASSWORD: &UsersByEmailEncryptedPasswordColumn{},
+
+		IS_ADMIN: &UsersByEmailIsAdminColumn{},
+
+		IS_ANALYZED: &UsersByEmailIsAnalyzedColumn{},
+
+		IS_BANNED: &UsersByEmailIsBannedColumn{},
+
+		IS_CONFIRMED: &UsersByEmailIsConfirmedColumn{},
+
+		IS_EMPLOYEE: &UsersByEmailIsEmployeeColumn{},
+
+		IS_EXPIRING: &UsersByEmailIsExpiringColumn{},
+
+		JWT_ENCRYPTION_KEY: &UsersByEmailJwtEncryptionKeyColumn{},
+
+		LAST_LOGGED_IN: &UsersByEmailLastLoggedInColumn{},
+
+		REAL_NAME: &UsersByEmailRealNameColumn{},
+
+		TOTP_ENABLED: &UsersByEmailTotpEnabledColumn{},
 
-func (b *UsersByEmailIsAnalyzedColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
+		TOTP_LAST_STEP: &UsersByEmailTotpLastStepColumn{},
 
-type UsersByEmailIsBannedColumn struct {
-}
+		TOTP_SECRET: &UsersByEmailTotpSecretColumn{},
 
-func (b *UsersByEmailIsBannedColumn) ColumnName() string {
-	return "is_banned"
-}
+		TWITTER_NAME: &UsersByEmailTwitterNameColumn{},
 
-func (b *UsersByEmailIsBannedColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
+		USER_RANK: &UsersByEmailUserRankColumn{},
 
-type UsersByEmailIsConfirmedColumn struct {
-}
+		USER_SELF_DESCRIPTION: &UsersByEmailUserSelfDescriptionColumn{},
 
-func (b *UsersByEmailIsConfirmedColumn) ColumnName() string {
-	return "is_confirmed"
-}
+		USER_TITLE: &UsersByEmailUserTitleColumn{},
 
-func (b *UsersByEmailIsConfirmedColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+		USER_UNIT: &UsersByEmailUserUnitColumn{},
+	}
 }
 
-type UsersByEmailIsEmployeeColumn struct {
+func (s *UsersByEmailDef) AccessValidTillColumn() cqlc.TimestampColumn {
+	return &UsersByEmailAccessValidTillColumn{}
 }
 
-func (b *UsersByEmailIsEmployeeColumn) ColumnName() string {
-	return "is_employee"
+func (s *UsersByEmailDef) AnalyticsUserTrackingTokenColumn() cqlc.StringColumn {
+	return &UsersByEmailAnalyticsUserTrackingTokenColumn{}
 }
 
-func (b *UsersByEmailIsEmployeeColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) EmailColumn() cqlc.LastPartitionedStringColumn {
+	return &UsersByEmailEmailColumn{}
 }
 
-type UsersByEmailIsExpiringColumn struct {
+func (s *UsersByEmailDef) EncryptedPasswordColumn() cqlc.StringColumn {
+	return &UsersByEmailEncryptedPasswordColumn{}
 }
 
-func (b *UsersByEmailIsExpiringColumn) ColumnName() string {
-	return "is_expiring"
+func (s *UsersByEmailDef) IsAdminColumn() cqlc.BooleanColumn {
+	return &UsersByEmailIsAdminColumn{}
 }
 
-func (b *UsersByEmailIsExpiringColumn) To(value *bool) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) IsAnalyzedColumn() cqlc.BooleanColumn {
+	return &UsersByEmailIsAnalyzedColumn{}
 }
 
-type UsersByEmailJwtEncryptionKeyColumn struct {
+func (s *UsersByEmailDef) IsBannedColumn() cqlc.BooleanColumn {
+	return &UsersByEmailIsBannedColumn{}
 }
 
-func (b *UsersByEmailJwtEncryptionKeyColumn) ColumnName() string {
-	return "jwt_encryption_key"
+func (s *UsersByEmailDef) IsConfirmedColumn() cqlc.BooleanColumn {
+	return &UsersByEmailIsConfirmedColumn{}
 }
 
-func (b *UsersByEmailJwtEncryptionKeyColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) IsEmployeeColumn() cqlc.BooleanColumn {
+	return &UsersByEmailIsEmployeeColumn{}
 }
 
-type UsersByEmailLastLoggedInColumn struct {
+func (s *UsersByEmailDef) IsExpiringColumn() cqlc.BooleanColumn {
+	return &UsersByEmailIsExpiringColumn{}
 }
 
-func (b *UsersByEmailLastLoggedInColumn) ColumnName() string {
-	return "last_logged_in"
+func (s *UsersByEmailDef) JwtEncryptionKeyColumn() cqlc.StringColumn {
+	return &UsersByEmailJwtEncryptionKeyColumn{}
 }
 
-func (b *UsersByEmailLastLoggedInColumn) To(value *time.Time) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) LastLoggedInColumn() cqlc.TimestampColumn {
+	return &UsersByEmailLastLoggedInColumn{}
 }
 
-type UsersByEmailRealNameColumn struct {
+func (s *UsersByEmailDef) RealNameColumn() cqlc.StringColumn {
+	return &UsersByEmailRealNameColumn{}
 }
 
-func (b *UsersByEmailRealNameColumn) ColumnName() string {
-	return "real_name"
+func (s *UsersByEmailDef) TotpEnabledColumn() cqlc.BooleanColumn {
+	return &UsersByEmailTotpEnabledColumn{}
 }
 
-func (b *UsersByEmailRealNameColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) TotpLastStepColumn() cqlc.Int64Column {
+	return &UsersByEmailTotpLastStepColumn{}
 }
 
-type UsersByEmailTwitterNameColumn struct {
+func (s *UsersByEmailDef) TotpSecretColumn() cqlc.StringColumn {
+	return &UsersByEmailTotpSecretColumn{}
 }
 
-func (b *UsersByEmailTwitterNameColumn) ColumnName() string {
-	return "twitter_name"
+func (s *UsersByEmailDef) TwitterNameColumn() cqlc.StringColumn {
+	return &UsersByEmailTwitterNameColumn{}
 }
 
-func (b *UsersByEmailTwitterNameColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) UserRankColumn() cqlc.StringColumn {
+	return &UsersByEmailUserRankColumn{}
 }
 
-type UsersByEmailUserRankColumn struct {
+func (s *UsersByEmailDef) UserSelfDescriptionColumn() cqlc.StringColumn {
+	return &UsersByEmailUserSelfDescriptionColumn{}
 }
 
-func (b *UsersByEmailUserRankColumn) ColumnName() string {
-	return "user_rank"
+func (s *UsersByEmailDef) UserTitleColumn() cqlc.StringColumn {
+	return &UsersByEmailUserTitleColumn{}
 }
 
-func (b *UsersByEmailUserRankColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *UsersByEmailDef) UserUnitColumn() cqlc.StringColumn {
+	return &UsersByEmailUserUnitColumn{}
 }
 
-type UsersByEmailUserSelfDescriptionColumn struct {
+type UsersByJwtEncryptionKeyColumn struct {
 }
 
-func (b *UsersByEmailUserSelfDescriptionColumn) ColumnName() string {
-	return "user_self_description"
+func (b *UsersByJwtEncryptionKeyColumn) ColumnName() string {
+	return "encryption_key"
 }
 
-func (b *UsersByEmailUserSelfDescriptionColumn) To(value *string) cqlc.ColumnBinding {
+func (b *UsersByJwtEncryptionKeyColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByEmailUserTitleColumn struct {
+type UsersByJwtFamilyIdColumn struct {
 }
 
-func (b *UsersByEmailUserTitleColumn) ColumnName() string {
-	return "user_title"
+func (b *UsersByJwtFamilyIdColumn) ColumnName() string {
+	return "family_id"
 }
 
-func (b *UsersByEmailUserTitleColumn) To(value *string) cqlc.ColumnBinding {
+func (b *UsersByJwtFamilyIdColumn) To(value *gocql.UUID) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByEmailUserUnitColumn struct {
+type UsersByJwtJwtColumn struct {
 }
 
-func (b *UsersByEmailUserUnitColumn) ColumnName() string {
-	return "user_unit"
+func (b *UsersByJwtJwtColumn) ColumnName() string {
+	return "jwt"
 }
 
-func (b *UsersByEmailUserUnitColumn) To(value *string) cqlc.ColumnBinding {
+func (b *UsersByJwtJwtColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByEmail struct {
-	AccessValidTill time.Time
-
-	AnalyticsUserTrackingToken string
-
-	Email string
-
-	EncryptedPassword string
-
-	IsAdmin bool
-
-	IsAnalyzed bool
-
-	IsBanned bool
-
-	IsConfirmed bool
-
-	IsEmployee bool
-
-	IsExpiring bool
-
-	JwtEncryptionKey string
-
-	LastLoggedIn time.Time
-
-	RealName string
-
-	TwitterName string
-
-	UserRank string
-
-	UserSelfDescription string
-
-	UserTitle string
-
-	UserUnit string
+func (b *UsersByJwtJwtColumn) Eq(value string) cqlc.Condition {
+	column := &UsersByJwtJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (s *UsersByEmail) AccessValidTillValue() time.Time {
-	return s.AccessValidTill
+func (b *UsersByJwtJwtColumn) PartitionBy() cqlc.Column {
+	return b
 }
 
-func (s *UsersByEmail) AnalyticsUserTrackingTokenValue() string {
-	return s.AnalyticsUserTrackingToken
+func (b *UsersByJwtJwtColumn) In(value ...string) cqlc.Condition {
+	column := &UsersByJwtJwtColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-func (s *UsersByEmail) EmailValue() string {
-	return s.Email
+type UsersByJwtUserEmailColumn struct {
 }
 
-func (s *UsersByEmail) EncryptedPasswordValue() string {
-	return s.EncryptedPassword
+func (b *UsersByJwtUserEmailColumn) ColumnName() string {
+	return "user_email"
 }
 
-func (s *UsersByEmail) IsAdminValue() bool {
-	return s.IsAdmin
+func (b *UsersByJwtUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *UsersByEmail) IsAnalyzedValue() bool {
-	return s.IsAnalyzed
+type UsersByJwtUserNameColumn struct {
 }
 
-func (s *UsersByEmail) IsBannedValue() bool {
-	return s.IsBanned
+func (b *UsersByJwtUserNameColumn) ColumnName() string {
+	return "user_name"
 }
 
-func (s *UsersByEmail) IsConfirmedValue() bool {
-	return s.IsConfirmed
+func (b *UsersByJwtUserNameColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *UsersByEmail) IsEmployeeValue() bool {
-	return s.IsEmployee
-}
+type UsersByJwt struct {
+	EncryptionKey string
 
-func (s *UsersByEmail) IsExpiringValue() bool {
-	return s.IsExpiring
-}
+	FamilyId gocql.UUID
 
-func (s *UsersByEmail) JwtEncryptionKeyValue() string {
-	return s.JwtEncryptionKey
-}
+	Jwt string
 
-func (s *UsersByEmail) LastLoggedInValue() time.Time {
-	return s.LastLoggedIn
-}
+	UserEmail string
 
-func (s *UsersByEmail) RealNameValue() string {
-	return s.RealName
+	UserName string
 }
 
-func (s *UsersByEmail) TwitterNameValue() string {
-	return s.TwitterName
+func (s *UsersByJwt) EncryptionKeyValue() string {
+	return s.EncryptionKey
 }
 
-func (s *UsersByEmail) UserRankValue() string {
-	return s.UserRank
+func (s *UsersByJwt) FamilyIdValue() gocql.UUID {
+	return s.FamilyId
 }
 
-func (s *UsersByEmail) UserSelfDescriptionValue() string {
-	return s.UserSelfDescription
+func (s *UsersByJwt) JwtValue() string {
+	return s.Jwt
 }
 
-func (s *UsersByEmail) UserTitleValue() string {
-	return s.UserTitle
+func (s *UsersByJwt) UserEmailValue() string {
+	return s.UserEmail
 }
 
-func (s *UsersByEmail) UserUnitValue() string {
-	return s.UserUnit
+func (s *UsersByJwt) UserNameValue() string {
+	return s.UserName
 }
 
-type UsersByEmailDef struct {
-	ACCESS_VALID_TILL cqlc.TimestampColumn
-
-	ANALYTICS_USER_TRACKING_TOKEN cqlc.StringColumn
-
-	EMAIL cqlc.LastPartitionedStringColumn
-
-	ENCRYPTED_PASSWORD cqlc.StringColumn
-
-	IS_ADMIN cqlc.BooleanColumn
-
-	IS_ANALYZED cqlc.BooleanColumn
-
-	IS_BANNED cqlc.BooleanColumn
-
-	IS_CONFIRMED cqlc.BooleanColumn
-
-	IS_EMPLOYEE cqlc.BooleanColumn
-
-	IS_EXPIRING cqlc.BooleanColumn
-
-	JWT_ENCRYPTION_KEY cqlc.StringColumn
-
-	LAST_LOGGED_IN cqlc.TimestampColumn
-
-	REAL_NAME cqlc.StringColumn
-
-	TWITTER_NAME cqlc.StringColumn
+type UsersByJwtDef struct {
+	ENCRYPTION_KEY cqlc.StringColumn
 
-	USER_RANK cqlc.StringColumn
+	FAMILY_ID cqlc.UUIDColumn
 
-	USER_SELF_DESCRIPTION cqlc.StringColumn
+	JWT cqlc.LastPartitionedStringColumn
 
-	USER_TITLE cqlc.StringColumn
+	USER_EMAIL cqlc.StringColumn
 
-	USER_UNIT cqlc.StringColumn
+	USER_NAME cqlc.StringColumn
 }
 
-func BindUsersByEmail(iter *gocql.Iter) ([]UsersByEmail, error) {
-	array := make([]UsersByEmail, 0)
-	err := MapUsersByEmail(iter, func(t UsersByEmail) (bool, error) {
+func BindUsersByJwt(iter *gocql.Iter) ([]UsersByJwt, error) {
+	array := make([]UsersByJwt, 0)
+	err := MapUsersByJwt(iter, func(t UsersByJwt) (bool, error) {
 		array = append(array, t)
 		return true, nil
 	})
 	return array, err
 }
 
-func MapUsersByEmail(iter *gocql.Iter, callback func(t UsersByEmail) (bool, error)) error {
+func MapUsersByJwt(iter *gocql.Iter, callback func(t UsersByJwt) (bool, error)) error {
 	columns := iter.Columns()
 	row := make([]interface{}, len(columns))
 
 	for {
-		t := UsersByEmail{}
+		t := UsersByJwt{}
 
 		for i := 0; i < len(columns); i++ {
 			switch columns[i].Name {
 
-			case "access_valid_till":
-				row[i] = &t.AccessValidTill
-
-			case "analytics_user_tracking_token":
-				row[i] = &t.AnalyticsUserTrackingToken
-
-			case "email":
-				row[i] = &t.Email
-
-			case "encrypted_password":
-				row[i] = &t.EncryptedPassword
-
-			case "is_admin":
-				row[i] = &t.IsAdmin
-
-			case "is_analyzed":
-				row[i] = &t.IsAnalyzed
-
-			case "is_banned":
-				row[i] = &t.IsBanned
-
-			case "is_confirmed":
-				row[i] = &t.IsConfirmed
-
-			case "is_employee":
-				row[i] = &t.IsEmployee
-
-			case "is_expiring":
-				row[i] = &t.IsExpiring
-
-			case "jwt_encryption_key":
-				row[i] = &t.JwtEncryptionKey
-
-			case "last_logged_in":
-				row[i] = &t.LastLoggedIn
-
-			case "real_name":
-				row[i] = &t.RealName
-
-			case "twitter_name":
-				row[i] = &t.TwitterName
+			case "encryption_key":
+				row[i] = &t.EncryptionKey
 
-			case "user_rank":
-				row[i] = &t.UserRank
+			case "family_id":
+				row[i] = &t.FamilyId
 
-			case "user_self_description":
-				row[i] = &t.UserSelfDescription
+			case "jwt":
+				row[i] = &t.Jwt
 
-			case "user_title":
-				row[i] = &t.UserTitle
+			case "user_email":
+				row[i] = &t.UserEmail
 
-			case "user_unit":
-				row[i] = &t.UserUnit
+			case "user_name":
+				row[i] = &t.UserName
 
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
@@ -2069,383 +7076,590 @@ func MapUsersByEmail(iter *gocql.Iter, callback func(t UsersByEmail) (bool, erro
 	return nil
 }
 
-func (s *UsersByEmailDef) SupportsUpsert() bool {
+func (s *UsersByJwtDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *UsersByEmailDef) TableName() string {
-	return "users_by_email"
+func (s *UsersByJwtDef) TableName() string {
+	return "users_by_jwt"
 }
 
-func (s *UsersByEmailDef) Keyspace() string {
+func (s *UsersByJwtDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *UsersByEmailDef) Bind(v UsersByEmail) cqlc.TableBinding {
+func (s *UsersByJwtDef) Bind(v UsersByJwt) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &UsersByEmailAccessValidTillColumn{}, Value: v.AccessValidTill},
+		cqlc.ColumnBinding{Column: &UsersByJwtEncryptionKeyColumn{}, Value: v.EncryptionKey},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailAnalyticsUserTrackingTokenColumn{}, Value: v.AnalyticsUserTrackingToken},
+		cqlc.ColumnBinding{Column: &UsersByJwtFamilyIdColumn{}, Value: v.FamilyId},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailEmailColumn{}, Value: v.Email},
+		cqlc.ColumnBinding{Column: &UsersByJwtJwtColumn{}, Value: v.Jwt},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailEncryptedPasswordColumn{}, Value: v.EncryptedPassword},
+		cqlc.ColumnBinding{Column: &UsersByJwtUserEmailColumn{}, Value: v.UserEmail},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsAdminColumn{}, Value: v.IsAdmin},
+		cqlc.ColumnBinding{Column: &UsersByJwtUserNameColumn{}, Value: v.UserName},
+	}
+	return cqlc.TableBinding{Table: &UsersByJwtDef{}, Columns: cols}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsAnalyzedColumn{}, Value: v.IsAnalyzed},
+func (s *UsersByJwtDef) To(v *UsersByJwt) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsBannedColumn{}, Value: v.IsBanned},
+		cqlc.ColumnBinding{Column: &UsersByJwtEncryptionKeyColumn{}, Value: &v.EncryptionKey},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsConfirmedColumn{}, Value: v.IsConfirmed},
+		cqlc.ColumnBinding{Column: &UsersByJwtFamilyIdColumn{}, Value: &v.FamilyId},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsEmployeeColumn{}, Value: v.IsEmployee},
+		cqlc.ColumnBinding{Column: &UsersByJwtJwtColumn{}, Value: &v.Jwt},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsExpiringColumn{}, Value: v.IsExpiring},
+		cqlc.ColumnBinding{Column: &UsersByJwtUserEmailColumn{}, Value: &v.UserEmail},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailJwtEncryptionKeyColumn{}, Value: v.JwtEncryptionKey},
+		cqlc.ColumnBinding{Column: &UsersByJwtUserNameColumn{}, Value: &v.UserName},
+	}
+	return cqlc.TableBinding{Table: &UsersByJwtDef{}, Columns: cols}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailLastLoggedInColumn{}, Value: v.LastLoggedIn},
+func (s *UsersByJwtDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
 
-		cqlc.ColumnBinding{Column: &UsersByEmailRealNameColumn{}, Value: v.RealName},
+		&UsersByJwtEncryptionKeyColumn{},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailTwitterNameColumn{}, Value: v.TwitterName},
+		&UsersByJwtFamilyIdColumn{},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserRankColumn{}, Value: v.UserRank},
+		&UsersByJwtJwtColumn{},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserSelfDescriptionColumn{}, Value: v.UserSelfDescription},
+		&UsersByJwtUserEmailColumn{},
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserTitleColumn{}, Value: v.UserTitle},
+		&UsersByJwtUserNameColumn{},
+	}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserUnitColumn{}, Value: v.UserUnit},
+func UsersByJwtTableDef() *UsersByJwtDef {
+	return &UsersByJwtDef{
+
+		ENCRYPTION_KEY: &UsersByJwtEncryptionKeyColumn{},
+
+		FAMILY_ID: &UsersByJwtFamilyIdColumn{},
+
+		JWT: &UsersByJwtJwtColumn{},
+
+		USER_EMAIL: &UsersByJwtUserEmailColumn{},
+
+		USER_NAME: &UsersByJwtUserNameColumn{},
 	}
-	return cqlc.TableBinding{Table: &UsersByEmailDef{}, Columns: cols}
 }
 
-func (s *UsersByEmailDef) To(v *UsersByEmail) cqlc.TableBinding {
-	cols := []cqlc.ColumnBinding{
+func (s *UsersByJwtDef) EncryptionKeyColumn() cqlc.StringColumn {
+	return &UsersByJwtEncryptionKeyColumn{}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailAccessValidTillColumn{}, Value: &v.AccessValidTill},
+func (s *UsersByJwtDef) FamilyIdColumn() cqlc.UUIDColumn {
+	return &UsersByJwtFamilyIdColumn{}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailAnalyticsUserTrackingTokenColumn{}, Value: &v.AnalyticsUserTrackingToken},
+func (s *UsersByJwtDef) JwtColumn() cqlc.LastPartitionedStringColumn {
+	return &UsersByJwtJwtColumn{}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailEmailColumn{}, Value: &v.Email},
+func (s *UsersByJwtDef) UserEmailColumn() cqlc.StringColumn {
+	return &UsersByJwtUserEmailColumn{}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailEncryptedPasswordColumn{}, Value: &v.EncryptedPassword},
+func (s *UsersByJwtDef) UserNameColumn() cqlc.StringColumn {
+	return &UsersByJwtUserNameColumn{}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsAdminColumn{}, Value: &v.IsAdmin},
+type WebauthnChallengesCeremonyColumn struct {
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsAnalyzedColumn{}, Value: &v.IsAnalyzed},
+func (b *WebauthnChallengesCeremonyColumn) ColumnName() string {
+	return "ceremony"
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsBannedColumn{}, Value: &v.IsBanned},
+func (b *WebauthnChallengesCeremonyColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsConfirmedColumn{}, Value: &v.IsConfirmed},
+type WebauthnChallengesChallengeHashColumn struct {
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsEmployeeColumn{}, Value: &v.IsEmployee},
+func (b *WebauthnChallengesChallengeHashColumn) ColumnName() string {
+	return "challenge_hash"
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailIsExpiringColumn{}, Value: &v.IsExpiring},
+func (b *WebauthnChallengesChallengeHashColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailJwtEncryptionKeyColumn{}, Value: &v.JwtEncryptionKey},
+func (b *WebauthnChallengesChallengeHashColumn) Eq(value string) cqlc.Condition {
+	column := &WebauthnChallengesChallengeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailLastLoggedInColumn{}, Value: &v.LastLoggedIn},
+func (b *WebauthnChallengesChallengeHashColumn) PartitionBy() cqlc.Column {
+	return b
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailRealNameColumn{}, Value: &v.RealName},
+func (b *WebauthnChallengesChallengeHashColumn) In(value ...string) cqlc.Condition {
+	column := &WebauthnChallengesChallengeHashColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailTwitterNameColumn{}, Value: &v.TwitterName},
+type WebauthnChallengesExpiresAtColumn struct {
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserRankColumn{}, Value: &v.UserRank},
+func (b *WebauthnChallengesExpiresAtColumn) ColumnName() string {
+	return "expires_at"
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserSelfDescriptionColumn{}, Value: &v.UserSelfDescription},
+func (b *WebauthnChallengesExpiresAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserTitleColumn{}, Value: &v.UserTitle},
+type WebauthnChallengesUserEmailColumn struct {
+}
 
-		cqlc.ColumnBinding{Column: &UsersByEmailUserUnitColumn{}, Value: &v.UserUnit},
-	}
-	return cqlc.TableBinding{Table: &UsersByEmailDef{}, Columns: cols}
+func (b *WebauthnChallengesUserEmailColumn) ColumnName() string {
+	return "user_email"
 }
 
-func (s *UsersByEmailDef) ColumnDefinitions() []cqlc.Column {
-	return []cqlc.Column{
+func (b *WebauthnChallengesUserEmailColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-		&UsersByEmailAccessValidTillColumn{},
+type WebauthnChallenges struct {
+	Ceremony string
 
-		&UsersByEmailAnalyticsUserTrackingTokenColumn{},
+	ChallengeHash string
 
-		&UsersByEmailEmailColumn{},
+	ExpiresAt time.Time
 
-		&UsersByEmailEncryptedPasswordColumn{},
+	UserEmail string
+}
+
+func (s *WebauthnChallenges) CeremonyValue() string {
+	return s.Ceremony
+}
+
+func (s *WebauthnChallenges) ChallengeHashValue() string {
+	return s.ChallengeHash
+}
+
+func (s *WebauthnChallenges) ExpiresAtValue() time.Time {
+	return s.ExpiresAt
+}
+
+func (s *WebauthnChallenges) UserEmailValue() string {
+	return s.UserEmail
+}
+
+type WebauthnChallengesDef struct {
+	CEREMONY cqlc.StringColumn
+
+	CHALLENGE_HASH cqlc.LastPartitionedStringColumn
+
+	EXPIRES_AT cqlc.TimestampColumn
+
+	USER_EMAIL cqlc.StringColumn
+}
+
+func BindWebauthnChallenges(iter *gocql.Iter) ([]WebauthnChallenges, error) {
+	array := make([]WebauthnChallenges, 0)
+	err := MapWebauthnChallenges(iter, func(t WebauthnChallenges) (bool, error) {
+		array = append(array, t)
+		return true, nil
+	})
+	return array, err
+}
+
+func MapWebauthnChallenges(iter *gocql.Iter, callback func(t WebauthnChallenges) (bool, error)) error {
+	columns := iter.Columns()
+	row := make([]interface{}, len(columns))
+
+	for {
+		t := WebauthnChallenges{}
+
+		for i := 0; i < len(columns); i++ {
+			switch columns[i].Name {
+
+			case "ceremony":
+				row[i] = &t.Ceremony
+
+			case "challenge_hash":
+				row[i] = &t.ChallengeHash
+
+			case "expires_at":
+				row[i] = &t.ExpiresAt
+
+			case "user_email":
+				row[i] = &t.UserEmail
+
+			default:
+				log.Fatal("unhandled column: ", columns[i].Name)
+			}
+		}
+		if !iter.Scan(row...) {
+			break
+		}
+
+		readNext, err := callback(t)
+		if err != nil {
+			return err
+		}
+		if !readNext {
+			return nil
+		}
+	}
 
-		&UsersByEmailIsAdminColumn{},
+	return nil
+}
 
-		&UsersByEmailIsAnalyzedColumn{},
+func (s *WebauthnChallengesDef) SupportsUpsert() bool {
+	return true
+}
 
-		&UsersByEmailIsBannedColumn{},
+func (s *WebauthnChallengesDef) TableName() string {
+	return "webauthn_challenges"
+}
 
-		&UsersByEmailIsConfirmedColumn{},
+func (s *WebauthnChallengesDef) Keyspace() string {
+	return "sitrep"
+}
 
-		&UsersByEmailIsEmployeeColumn{},
+func (s *WebauthnChallengesDef) Bind(v WebauthnChallenges) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
 
-		&UsersByEmailIsExpiringColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesCeremonyColumn{}, Value: v.Ceremony},
 
-		&UsersByEmailJwtEncryptionKeyColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesChallengeHashColumn{}, Value: v.ChallengeHash},
 
-		&UsersByEmailLastLoggedInColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesExpiresAtColumn{}, Value: v.ExpiresAt},
 
-		&UsersByEmailRealNameColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesUserEmailColumn{}, Value: v.UserEmail},
+	}
+	return cqlc.TableBinding{Table: &WebauthnChallengesDef{}, Columns: cols}
+}
 
-		&UsersByEmailTwitterNameColumn{},
+func (s *WebauthnChallengesDef) To(v *WebauthnChallenges) cqlc.TableBinding {
+	cols := []cqlc.ColumnBinding{
 
-		&UsersByEmailUserRankColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesCeremonyColumn{}, Value: &v.Ceremony},
 
-		&UsersByEmailUserSelfDescriptionColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesChallengeHashColumn{}, Value: &v.ChallengeHash},
 
-		&UsersByEmailUserTitleColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesExpiresAtColumn{}, Value: &v.ExpiresAt},
 
-		&UsersByEmailUserUnitColumn{},
+		cqlc.ColumnBinding{Column: &WebauthnChallengesUserEmailColumn{}, Value: &v.UserEmail},
 	}
+	return cqlc.TableBinding{Table: &WebauthnChallengesDef{}, Columns: cols}
 }
 
-func UsersByEmailTableDef() *UsersByEmailDef {
-	return &UsersByEmailDef{
+func (s *WebauthnChallengesDef) ColumnDefinitions() []cqlc.Column {
+	return []cqlc.Column{
 
-		ACCESS_VALID_TILL: &UsersByEmailAccessValidTillColumn{},
+		&WebauthnChallengesCeremonyColumn{},
 
-		ANALYTICS_USER_TRACKING_TOKEN: &UsersByEmailAnalyticsUserTrackingTokenColumn{},
+		&WebauthnChallengesChallengeHashColumn{},
 
-		EMAIL: &UsersByEmailEmailColumn{},
+		&WebauthnChallengesExpiresAtColumn{},
 
-		ENCRYPTED_PASSWORD: &UsersByEmailEncryptedPasswordColumn{},
+		&WebauthnChallengesUserEmailColumn{},
+	}
+}
 
-		IS_ADMIN: &UsersByEmailIsAdminColumn{},
+func WebauthnChallengesTableDef() *WebauthnChallengesDef {
+	return &WebauthnChallengesDef{
 
-		IS_ANALYZED: &UsersByEmailIsAnalyzedColumn{},
+		CEREMONY: &WebauthnChallengesCeremonyColumn{},
 
-		IS_BANNED: &UsersByEmailIsBannedColumn{},
+		CHALLENGE_HASH: &WebauthnChallengesChallengeHashColumn{},
 
-		IS_CONFIRMED: &UsersByEmailIsConfirmedColumn{},
+		EXPIRES_AT: &WebauthnChallengesExpiresAtColumn{},
 
-		IS_EMPLOYEE: &UsersByEmailIsEmployeeColumn{},
+		USER_EMAIL: &WebauthnChallengesUserEmailColumn{},
+	}
+}
 
-		IS_EXPIRING: &UsersByEmailIsExpiringColumn{},
+func (s *WebauthnChallengesDef) CeremonyColumn() cqlc.StringColumn {
+	return &WebauthnChallengesCeremonyColumn{}
+}
 
-		JWT_ENCRYPTION_KEY: &UsersByEmailJwtEncryptionKeyColumn{},
+func (s *WebauthnChallengesDef) ChallengeHashColumn() cqlc.LastPartitionedStringColumn {
+	return &WebauthnChallengesChallengeHashColumn{}
+}
 
-		LAST_LOGGED_IN: &UsersByEmailLastLoggedInColumn{},
+func (s *WebauthnChallengesDef) ExpiresAtColumn() cqlc.TimestampColumn {
+	return &WebauthnChallengesExpiresAtColumn{}
+}
 
-		REAL_NAME: &UsersByEmailRealNameColumn{},
+func (s *WebauthnChallengesDef) UserEmailColumn() cqlc.StringColumn {
+	return &WebauthnChallengesUserEmailColumn{}
+}
 
-		TWITTER_NAME: &UsersByEmailTwitterNameColumn{},
+type WebauthnCredentialsAlgorithmColumn struct {
+}
 
-		USER_RANK: &UsersByEmailUserRankColumn{},
+func (b *WebauthnCredentialsAlgorithmColumn) ColumnName() string {
+	return "algorithm"
+}
 
-		USER_SELF_DESCRIPTION: &UsersByEmailUserSelfDescriptionColumn{},
+func (b *WebauthnCredentialsAlgorithmColumn) To(value *int64) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
+}
 
-		USER_TITLE: &UsersByEmailUserTitleColumn{},
+type WebauthnCredentialsCreatedAtColumn struct {
+}
 
-		USER_UNIT: &UsersByEmailUserUnitColumn{},
-	}
+func (b *WebauthnCredentialsCreatedAtColumn) ColumnName() string {
+	return "created_at"
 }
 
-func (s *UsersByEmailDef) AccessValidTillColumn() cqlc.TimestampColumn {
-	return &UsersByEmailAccessValidTillColumn{}
+func (b *WebauthnCredentialsCreatedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *UsersByEmailDef) AnalyticsUserTrackingTokenColumn() cqlc.StringColumn {
-	return &UsersByEmailAnalyticsUserTrackingTokenColumn{}
+type WebauthnCredentialsCredentialIdColumn struct {
+	desc bool
 }
 
-func (s *UsersByEmailDef) EmailColumn() cqlc.LastPartitionedStringColumn {
-	return &UsersByEmailEmailColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) ColumnName() string {
+	return "credential_id"
 }
 
-func (s *UsersByEmailDef) EncryptedPasswordColumn() cqlc.StringColumn {
-	return &UsersByEmailEncryptedPasswordColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *UsersByEmailDef) IsAdminColumn() cqlc.BooleanColumn {
-	return &UsersByEmailIsAdminColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) ClusterWith() string {
+	return b.ColumnName()
 }
 
-func (s *UsersByEmailDef) IsAnalyzedColumn() cqlc.BooleanColumn {
-	return &UsersByEmailIsAnalyzedColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) Desc() cqlc.ClusteredColumn {
+	return &WebauthnCredentialsCredentialIdColumn{desc: true}
 }
 
-func (s *UsersByEmailDef) IsBannedColumn() cqlc.BooleanColumn {
-	return &UsersByEmailIsBannedColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) IsDescending() bool {
+	return b.desc
 }
 
-func (s *UsersByEmailDef) IsConfirmedColumn() cqlc.BooleanColumn {
-	return &UsersByEmailIsConfirmedColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) Eq(value string) cqlc.Condition {
+	column := &WebauthnCredentialsCredentialIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (s *UsersByEmailDef) IsEmployeeColumn() cqlc.BooleanColumn {
-	return &UsersByEmailIsEmployeeColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) In(value ...string) cqlc.Condition {
+	column := &WebauthnCredentialsCredentialIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-func (s *UsersByEmailDef) IsExpiringColumn() cqlc.BooleanColumn {
-	return &UsersByEmailIsExpiringColumn{}
+func (b *WebauthnCredentialsCredentialIdColumn) Gt(value string) cqlc.Condition {
+	column := &WebauthnCredentialsCredentialIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GtPredicate}
+}
+func (b *WebauthnCredentialsCredentialIdColumn) Ge(value string) cqlc.Condition {
+	column := &WebauthnCredentialsCredentialIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.GePredicate}
+}
+func (b *WebauthnCredentialsCredentialIdColumn) Lt(value string) cqlc.Condition {
+	column := &WebauthnCredentialsCredentialIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LtPredicate}
+}
+func (b *WebauthnCredentialsCredentialIdColumn) Le(value string) cqlc.Condition {
+	column := &WebauthnCredentialsCredentialIdColumn{}
+	binding := cqlc.ColumnBinding{Column: column, Value: value}
+	return cqlc.Condition{Binding: binding, Predicate: cqlc.LePredicate}
 }
 
-func (s *UsersByEmailDef) JwtEncryptionKeyColumn() cqlc.StringColumn {
-	return &UsersByEmailJwtEncryptionKeyColumn{}
+type WebauthnCredentialsLastUsedAtColumn struct {
 }
 
-func (s *UsersByEmailDef) LastLoggedInColumn() cqlc.TimestampColumn {
-	return &UsersByEmailLastLoggedInColumn{}
+func (b *WebauthnCredentialsLastUsedAtColumn) ColumnName() string {
+	return "last_used_at"
 }
 
-func (s *UsersByEmailDef) RealNameColumn() cqlc.StringColumn {
-	return &UsersByEmailRealNameColumn{}
+func (b *WebauthnCredentialsLastUsedAtColumn) To(value *time.Time) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *UsersByEmailDef) TwitterNameColumn() cqlc.StringColumn {
-	return &UsersByEmailTwitterNameColumn{}
+type WebauthnCredentialsNameColumn struct {
 }
 
-func (s *UsersByEmailDef) UserRankColumn() cqlc.StringColumn {
-	return &UsersByEmailUserRankColumn{}
+func (b *WebauthnCredentialsNameColumn) ColumnName() string {
+	return "name"
 }
 
-func (s *UsersByEmailDef) UserSelfDescriptionColumn() cqlc.StringColumn {
-	return &UsersByEmailUserSelfDescriptionColumn{}
+func (b *WebauthnCredentialsNameColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (s *UsersByEmailDef) UserTitleColumn() cqlc.StringColumn {
-	return &UsersByEmailUserTitleColumn{}
+type WebauthnCredentialsPublicKeyColumn struct {
 }
 
-func (s *UsersByEmailDef) UserUnitColumn() cqlc.StringColumn {
-	return &UsersByEmailUserUnitColumn{}
+func (b *WebauthnCredentialsPublicKeyColumn) ColumnName() string {
+	return "public_key"
 }
 
-type UsersByJwtEncryptionKeyColumn struct {
+func (b *WebauthnCredentialsPublicKeyColumn) To(value *string) cqlc.ColumnBinding {
+	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *UsersByJwtEncryptionKeyColumn) ColumnName() string {
-	return "encryption_key"
+type WebauthnCredentialsSignCountColumn struct {
 }
 
-func (b *UsersByJwtEncryptionKeyColumn) To(value *string) cqlc.ColumnBinding {
+func (b *WebauthnCredentialsSignCountColumn) ColumnName() string {
+	return "sign_count"
+}
+
+func (b *WebauthnCredentialsSignCountColumn) To(value *int64) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-type UsersByJwtJwtColumn struct {
+type WebauthnCredentialsUserEmailColumn struct {
 }
 
-func (b *UsersByJwtJwtColumn) ColumnName() string {
-	return "jwt"
+func (b *WebauthnCredentialsUserEmailColumn) ColumnName() string {
+	return "user_email"
 }
 
-func (b *UsersByJwtJwtColumn) To(value *string) cqlc.ColumnBinding {
+func (b *WebauthnCredentialsUserEmailColumn) To(value *string) cqlc.ColumnBinding {
 	return cqlc.ColumnBinding{Column: b, Value: value}
 }
 
-func (b *UsersByJwtJwtColumn) Eq(value string) cqlc.Condition {
-	column := &UsersByJwtJwtColumn{}
+func (b *WebauthnCredentialsUserEmailColumn) Eq(value string) cqlc.Condition {
+	column := &WebauthnCredentialsUserEmailColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.EqPredicate}
 }
 
-func (b *UsersByJwtJwtColumn) PartitionBy() cqlc.Column {
+func (b *WebauthnCredentialsUserEmailColumn) PartitionBy() cqlc.Column {
 	return b
 }
 
-func (b *UsersByJwtJwtColumn) In(value ...string) cqlc.Condition {
-	column := &UsersByJwtJwtColumn{}
+func (b *WebauthnCredentialsUserEmailColumn) In(value ...string) cqlc.Condition {
+	column := &WebauthnCredentialsUserEmailColumn{}
 	binding := cqlc.ColumnBinding{Column: column, Value: value}
 	return cqlc.Condition{Binding: binding, Predicate: cqlc.InPredicate}
 }
 
-type UsersByJwtUserEmailColumn struct {
-}
+type WebauthnCredentials struct {
+	Algorithm int64
 
-func (b *UsersByJwtUserEmailColumn) ColumnName() string {
-	return "user_email"
-}
+	CreatedAt time.Time
 
-func (b *UsersByJwtUserEmailColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
-}
+	CredentialId string
 
-type UsersByJwtUserNameColumn struct {
-}
+	LastUsedAt time.Time
 
-func (b *UsersByJwtUserNameColumn) ColumnName() string {
-	return "user_name"
+	Name string
+
+	PublicKey string
+
+	SignCount int64
+
+	UserEmail string
 }
 
-func (b *UsersByJwtUserNameColumn) To(value *string) cqlc.ColumnBinding {
-	return cqlc.ColumnBinding{Column: b, Value: value}
+func (s *WebauthnCredentials) AlgorithmValue() int64 {
+	return s.Algorithm
 }
 
-type UsersByJwt struct {
-	EncryptionKey string
+func (s *WebauthnCredentials) CreatedAtValue() time.Time {
+	return s.CreatedAt
+}
 
-	Jwt string
+func (s *WebauthnCredentials) CredentialIdValue() string {
+	return s.CredentialId
+}
 
-	UserEmail string
+func (s *WebauthnCredentials) LastUsedAtValue() time.Time {
+	return s.LastUsedAt
+}
 
-	UserName string
+func (s *WebauthnCredentials) NameValue() string {
+	return s.Name
 }
 
-func (s *UsersByJwt) EncryptionKeyValue() string {
-	return s.EncryptionKey
+func (s *WebauthnCredentials) PublicKeyValue() string {
+	return s.PublicKey
 }
 
-func (s *UsersByJwt) JwtValue() string {
-	return s.Jwt
+func (s *WebauthnCredentials) SignCountValue() int64 {
+	return s.SignCount
 }
 
-func (s *UsersByJwt) UserEmailValue() string {
+func (s *WebauthnCredentials) UserEmailValue() string {
 	return s.UserEmail
 }
 
-func (s *UsersByJwt) UserNameValue() string {
-	return s.UserName
-}
+type WebauthnCredentialsDef struct {
+	ALGORITHM cqlc.Int64Column
 
-type UsersByJwtDef struct {
-	ENCRYPTION_KEY cqlc.StringColumn
+	CREATED_AT cqlc.TimestampColumn
 
-	JWT cqlc.LastPartitionedStringColumn
+	CREDENTIAL_ID cqlc.LastClusteredStringColumn
 
-	USER_EMAIL cqlc.StringColumn
+	LAST_USED_AT cqlc.TimestampColumn
 
-	USER_NAME cqlc.StringColumn
+	NAME cqlc.StringColumn
+
+	PUBLIC_KEY cqlc.StringColumn
+
+	SIGN_COUNT cqlc.Int64Column
+
+	USER_EMAIL cqlc.LastPartitionedStringColumn
 }
 
-func BindUsersByJwt(iter *gocql.Iter) ([]UsersByJwt, error) {
-	array := make([]UsersByJwt, 0)
-	err := MapUsersByJwt(iter, func(t UsersByJwt) (bool, error) {
+func BindWebauthnCredentials(iter *gocql.Iter) ([]WebauthnCredentials, error) {
+	array := make([]WebauthnCredentials, 0)
+	err := MapWebauthnCredentials(iter, func(t WebauthnCredentials) (bool, error) {
 		array = append(array, t)
 		return true, nil
 	})
 	return array, err
 }
 
-func MapUsersByJwt(iter *gocql.Iter, callback func(t UsersByJwt) (bool, error)) error {
+func MapWebauthnCredentials(iter *gocql.Iter, callback func(t WebauthnCredentials) (bool, error)) error {
 	columns := iter.Columns()
 	row := make([]interface{}, len(columns))
 
 	for {
-		t := UsersByJwt{}
+		t := WebauthnCredentials{}
 
 		for i := 0; i < len(columns); i++ {
 			switch columns[i].Name {
 
-			case "encryption_key":
-				row[i] = &t.EncryptionKey
+			case "algorithm":
+				row[i] = &t.Algorithm
 
-			case "jwt":
-				row[i] = &t.Jwt
+			case "created_at":
+				row[i] = &t.CreatedAt
+
+			case "credential_id":
+				row[i] = &t.CredentialId
+
+			case "last_used_at":
+				row[i] = &t.LastUsedAt
+
+			case "name":
+				row[i] = &t.Name
+
+			case "public_key":
+				row[i] = &t.PublicKey
+
+			case "sign_count":
+				row[i] = &t.SignCount
 
 			case "user_email":
 				row[i] = &t.UserEmail
 
-			case "user_name":
-				row[i] = &t.UserName
-
 			default:
 				log.Fatal("unhandled column: ", columns[i].Name)
 			}
@@ -2466,84 +7680,132 @@ func MapUsersByJwt(iter *gocql.Iter, callback func(t UsersByJwt) (bool, error))
 	return nil
 }
 
-func (s *UsersByJwtDef) SupportsUpsert() bool {
+func (s *WebauthnCredentialsDef) SupportsUpsert() bool {
 	return true
 }
 
-func (s *UsersByJwtDef) TableName() string {
-	return "users_by_jwt"
+func (s *WebauthnCredentialsDef) TableName() string {
+	return "webauthn_credentials"
 }
 
-func (s *UsersByJwtDef) Keyspace() string {
+func (s *WebauthnCredentialsDef) Keyspace() string {
 	return "sitrep"
 }
 
-func (s *UsersByJwtDef) Bind(v UsersByJwt) cqlc.TableBinding {
+func (s *WebauthnCredentialsDef) Bind(v WebauthnCredentials) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &UsersByJwtEncryptionKeyColumn{}, Value: v.EncryptionKey},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsAlgorithmColumn{}, Value: v.Algorithm},
 
-		cqlc.ColumnBinding{Column: &UsersByJwtJwtColumn{}, Value: v.Jwt},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsCreatedAtColumn{}, Value: v.CreatedAt},
 
-		cqlc.ColumnBinding{Column: &UsersByJwtUserEmailColumn{}, Value: v.UserEmail},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsCredentialIdColumn{}, Value: v.CredentialId},
 
-		cqlc.ColumnBinding{Column: &UsersByJwtUserNameColumn{}, Value: v.UserName},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsLastUsedAtColumn{}, Value: v.LastUsedAt},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsNameColumn{}, Value: v.Name},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsPublicKeyColumn{}, Value: v.PublicKey},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsSignCountColumn{}, Value: v.SignCount},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsUserEmailColumn{}, Value: v.UserEmail},
 	}
-	return cqlc.TableBinding{Table: &UsersByJwtDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &WebauthnCredentialsDef{}, Columns: cols}
 }
 
-func (s *UsersByJwtDef) To(v *UsersByJwt) cqlc.TableBinding {
+func (s *WebauthnCredentialsDef) To(v *WebauthnCredentials) cqlc.TableBinding {
 	cols := []cqlc.ColumnBinding{
 
-		cqlc.ColumnBinding{Column: &UsersByJwtEncryptionKeyColumn{}, Value: &v.EncryptionKey},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsAlgorithmColumn{}, Value: &v.Algorithm},
 
-		cqlc.ColumnBinding{Column: &UsersByJwtJwtColumn{}, Value: &v.Jwt},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsCreatedAtColumn{}, Value: &v.CreatedAt},
 
-		cqlc.ColumnBinding{Column: &UsersByJwtUserEmailColumn{}, Value: &v.UserEmail},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsCredentialIdColumn{}, Value: &v.CredentialId},
 
-		cqlc.ColumnBinding{Column: &UsersByJwtUserNameColumn{}, Value: &v.UserName},
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsLastUsedAtColumn{}, Value: &v.LastUsedAt},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsNameColumn{}, Value: &v.Name},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsPublicKeyColumn{}, Value: &v.PublicKey},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsSignCountColumn{}, Value: &v.SignCount},
+
+		cqlc.ColumnBinding{Column: &WebauthnCredentialsUserEmailColumn{}, Value: &v.UserEmail},
 	}
-	return cqlc.TableBinding{Table: &UsersByJwtDef{}, Columns: cols}
+	return cqlc.TableBinding{Table: &WebauthnCredentialsDef{}, Columns: cols}
 }
 
-func (s *UsersByJwtDef) ColumnDefinitions() []cqlc.Column {
+func (s *WebauthnCredentialsDef) ColumnDefinitions() []cqlc.Column {
 	return []cqlc.Column{
 
-		&UsersByJwtEncryptionKeyColumn{},
+		&WebauthnCredentialsAlgorithmColumn{},
 
-		&UsersByJwtJwtColumn{},
+		&WebauthnCredentialsCreatedAtColumn{},
 
-		&UsersByJwtUserEmailColumn{},
+		&WebauthnCredentialsCredentialIdColumn{},
 
-		&UsersByJwtUserNameColumn{},
+		&WebauthnCredentialsLastUsedAtColumn{},
+
+		&WebauthnCredentialsNameColumn{},
+
+		&WebauthnCredentialsPublicKeyColumn{},
+
+		&WebauthnCredentialsSignCountColumn{},
+
+		&WebauthnCredentialsUserEmailColumn{},
 	}
 }
 
-func UsersByJwtTableDef() *UsersByJwtDef {
-	return &UsersByJwtDef{
+func WebauthnCredentialsTableDef() *WebauthnCredentialsDef {
+	return &WebauthnCredentialsDef{
 
-		ENCRYPTION_KEY: &UsersByJwtEncryptionKeyColumn{},
+		ALGORITHM: &WebauthnCredentialsAlgorithmColumn{},
 
-		JWT: &UsersByJwtJwtColumn{},
+		CREATED_AT: &WebauthnCredentialsCreatedAtColumn{},
 
-		USER_EMAIL: &UsersByJwtUserEmailColumn{},
+		CREDENTIAL_ID: &WebauthnCredentialsCredentialIdColumn{},
 
-		USER_NAME: &UsersByJwtUserNameColumn{},
+		LAST_USED_AT: &WebauthnCredentialsLastUsedAtColumn{},
+
+		NAME: &WebauthnCredentialsNameColumn{},
+
+		PUBLIC_KEY: &WebauthnCredentialsPublicKeyColumn{},
+
+		SIGN_COUNT: &WebauthnCredentialsSignCountColumn{},
+
+		USER_EMAIL: &WebauthnCredentialsUserEmailColumn{},
 	}
 }
 
-func (s *UsersByJwtDef) EncryptionKeyColumn() cqlc.StringColumn {
-	return &UsersByJwtEncryptionKeyColumn{}
+func (s *WebauthnCredentialsDef) AlgorithmColumn() cqlc.Int64Column {
+	return &WebauthnCredentialsAlgorithmColumn{}
 }
 
-func (s *UsersByJwtDef) JwtColumn() cqlc.LastPartitionedStringColumn {
-	return &UsersByJwtJwtColumn{}
+func (s *WebauthnCredentialsDef) CreatedAtColumn() cqlc.TimestampColumn {
+	return &WebauthnCredentialsCreatedAtColumn{}
 }
 
-func (s *UsersByJwtDef) UserEmailColumn() cqlc.StringColumn {
-	return &UsersByJwtUserEmailColumn{}
+func (s *WebauthnCredentialsDef) CredentialIdColumn() cqlc.LastClusteredStringColumn {
+	return &WebauthnCredentialsCredentialIdColumn{}
 }
 
-func (s *UsersByJwtDef) UserNameColumn() cqlc.StringColumn {
-	return &UsersByJwtUserNameColumn{}
+func (s *WebauthnCredentialsDef) LastUsedAtColumn() cqlc.TimestampColumn {
+	return &WebauthnCredentialsLastUsedAtColumn{}
+}
+
+func (s *WebauthnCredentialsDef) NameColumn() cqlc.StringColumn {
+	return &WebauthnCredentialsNameColumn{}
+}
+
+func (s *WebauthnCredentialsDef) PublicKeyColumn() cqlc.StringColumn {
+	return &WebauthnCredentialsPublicKeyColumn{}
+}
+
+func (s *WebauthnCredentialsDef) SignCountColumn() cqlc.Int64Column {
+	return &WebauthnCredentialsSignCountColumn{}
+}
+
+func (s *WebauthnCredentialsDef) UserEmailColumn() cqlc.LastPartitionedStringColumn {
+	return &WebauthnCredentialsUserEmailColumn{}
 }
@@ -1,21 +1,32 @@
 package sitrep
 
-import "golang.org/x/crypto/bcrypt"
+import "github.com/fkasper/sitrep-authentication/passwords"
 
-// ValidatePassword validates a password against the one, received from the Database
+// ValidatePassword validates a password against the one, received from the Database.
+// Hashes of all supported algorithms are accepted.
 func (u *UsersByEmail) ValidatePassword(password string) error {
-	if err := bcrypt.CompareHashAndPassword([]byte(u.EncryptedPassword), []byte(password)); err != nil {
+	if err := passwords.Verify(u.EncryptedPassword, password); err != nil {
 		return err
 	}
 	return nil
 }
 
-//HashCryptPassword encrypts the current user password
-func (u *UsersByEmail) HashCryptPassword() error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.EncryptedPassword), bcrypt.DefaultCost)
+// HashCryptPassword encrypts the current user password with hasher
+func (u *UsersByEmail) HashCryptPassword(hasher *passwords.Hasher) error {
+	hashedPassword, err := hasher.Hash(u.EncryptedPassword)
 	if err != nil {
 		return err
 	}
-	u.EncryptedPassword = string(hashedPassword)
+	u.EncryptedPassword = hashedPassword
+	return nil
+}
+
+// RegenerateEncryptionKey replaces the key, the tokens of the user are bound to
+func (u *UsersByEmail) RegenerateEncryptionKey() error {
+	key, err := NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	u.JwtEncryptionKey = key
 	return nil
 }
@@ -1,20 +1,109 @@
 package httpd
 
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/toml"
+)
+
+// DefaultIssuer is the issuer of a service listening on the default bind address
+const DefaultIssuer = "http://localhost:7717"
+
 // Config represents a configuration for a HTTP service.
 type Config struct {
-	Enabled      bool   `toml:"enabled"`
-	BindAddress  string `toml:"bind-address"`
-	AuthEnabled  bool   `toml:"auth-enabled"`
-	LogEnabled   bool   `toml:"log-enabled"`
-	WriteTracing bool   `toml:"write-tracing"`
-	PprofEnabled bool   `toml:"pprof-enabled"`
+	Enabled               bool          `toml:"enabled"`
+	BindAddress           string        `toml:"bind-address"`
+	AuthEnabled           bool          `toml:"auth-enabled"`
+	LogEnabled            bool          `toml:"log-enabled"`
+	WriteTracing          bool          `toml:"write-tracing"`
+	PprofEnabled          bool          `toml:"pprof-enabled"`
+	AccessTokenLifetime   toml.Duration `toml:"access-token-lifetime"`
+	RefreshTokenLifetime  toml.Duration `toml:"refresh-token-lifetime"`
+	ExerciseTokenLifetime toml.Duration `toml:"exercise-token-lifetime"`
+	Issuer                string        `toml:"issuer"`
+	WebAuthnRPID          string        `toml:"webauthn-rp-id"`
+	WebAuthnOrigins       []string      `toml:"webauthn-origins"`
+	PasswordResetURL      string        `toml:"password-reset-url"`
+	EmailConfirmationURL  string        `toml:"email-confirmation-url"`
+	InvitationURL         string        `toml:"invitation-url"`
+	MagicLinkURL          string        `toml:"magic-link-url"`
+	RequireConfirmedEmail bool          `toml:"require-confirmed-email"`
+	Registration          string        `toml:"registration"`
+
+	// ExerciseTokenUserCheck looks up the user of every exercise token, so
+	// banned users are refused before their exercise tokens expire
+	ExerciseTokenUserCheck bool `toml:"exercise-token-user-check"`
+
+	// TrustedProxies are the addresses and networks of reverse proxies, whose
+	// X-Forwarded-For header names the client
+	TrustedProxies []string `toml:"trusted-proxies"`
+
+	LoginFailureWindow      toml.Duration `toml:"login-failure-window"`
+	LoginMaxFailures        int           `toml:"login-max-failures"`
+	LoginMaxFailuresPerAddr int           `toml:"login-max-failures-per-address"`
+	LoginMaxFailuresPerPair int           `toml:"login-max-failures-per-account-address"`
+	LoginLockout            toml.Duration `toml:"login-lockout"`
+	LoginDelay              toml.Duration `toml:"login-delay"`
+
+	LoginHistoryRetention toml.Duration `toml:"login-history-retention"`
+
+	RateLimitWindow   toml.Duration `toml:"rate-limit-window"`
+	RateLimitPerAddr  int           `toml:"rate-limit-per-address"`
+	RateLimitPerEmail int           `toml:"rate-limit-per-email"`
 }
 
 // NewConfig returns a new Config with default settings.
 func NewConfig() Config {
 	return Config{
-		Enabled:     true,
-		BindAddress: ":7717",
-		LogEnabled:  true,
+		Enabled:               true,
+		BindAddress:           ":7717",
+		LogEnabled:            true,
+		AccessTokenLifetime:   toml.Duration(models.DefaultAccessTokenLifetime),
+		RefreshTokenLifetime:  toml.Duration(models.DefaultRefreshTokenLifetime),
+		ExerciseTokenLifetime: toml.Duration(models.DefaultExerciseTokenLifetime),
+		Issuer:                DefaultIssuer,
+		Registration:          models.RegistrationRestricted,
+
+		LoginFailureWindow:      toml.Duration(models.DefaultLoginFailureWindow),
+		LoginMaxFailures:        models.DefaultLoginMaxFailures,
+		LoginMaxFailuresPerAddr: models.DefaultLoginMaxFailuresPerAddr,
+		LoginMaxFailuresPerPair: models.DefaultLoginMaxFailuresPerPair,
+		LoginLockout:            toml.Duration(models.DefaultLoginLockout),
+		LoginDelay:              toml.Duration(models.DefaultLoginDelay),
+
+		LoginHistoryRetention: toml.Duration(models.DefaultLoginHistoryRetention),
+
+		RateLimitWindow:   toml.Duration(models.DefaultRateLimitWindow),
+		RateLimitPerAddr:  models.DefaultRateLimitPerAddr,
+		RateLimitPerEmail: models.DefaultRateLimitPerEmail,
+	}
+}
+
+// trustedProxies parses TrustedProxies. Single addresses are taken as
+// networks of one address.
+func (c Config) trustedProxies() ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+		networks = append(networks, network)
 	}
+	return networks, nil
 }
@@ -2,9 +2,12 @@ package httpd_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/fkasper/sitrep-authentication/models"
 	"github.com/fkasper/sitrep-authentication/services/httpd"
+	itoml "github.com/fkasper/sitrep-authentication/toml"
 )
 
 func TestConfig_Parse(t *testing.T) {
@@ -17,6 +20,11 @@ auth-enabled = true
 log-enabled = true
 write-tracing = true
 pprof-enabled = true
+access-token-lifetime = "5m"
+refresh-token-lifetime = "24h"
+issuer = "https://auth.example.com"
+require-confirmed-email = true
+exercise-token-user-check = true
 `, &c); err != nil {
 		t.Fatal(err)
 	}
@@ -34,6 +42,16 @@ pprof-enabled = true
 		t.Fatalf("unexpected write tracing: %v", c.WriteTracing)
 	} else if c.PprofEnabled != true {
 		t.Fatalf("unexpected pprof enabled: %v", c.PprofEnabled)
+	} else if time.Duration(c.AccessTokenLifetime) != 5*time.Minute {
+		t.Fatalf("unexpected access token lifetime: %s", c.AccessTokenLifetime)
+	} else if time.Duration(c.RefreshTokenLifetime) != 24*time.Hour {
+		t.Fatalf("unexpected refresh token lifetime: %s", c.RefreshTokenLifetime)
+	} else if c.Issuer != "https://auth.example.com" {
+		t.Fatalf("unexpected issuer: %s", c.Issuer)
+	} else if c.RequireConfirmedEmail != true {
+		t.Fatalf("unexpected require confirmed email: %v", c.RequireConfirmedEmail)
+	} else if c.ExerciseTokenUserCheck != true {
+		t.Fatalf("unexpected exercise token user check: %v", c.ExerciseTokenUserCheck)
 	}
 }
 
@@ -44,3 +62,96 @@ func TestConfig_WriteTracing(t *testing.T) {
 		t.Fatalf("write tracing was not set")
 	}
 }
+
+func TestConfig_TokenLifetimes(t *testing.T) {
+	c := httpd.NewConfig()
+	c.AccessTokenLifetime = itoml.Duration(time.Minute)
+	s := httpd.NewService(c)
+	if s.Handler.Options.AccessTokenLifetime != time.Minute {
+		t.Fatalf("access token lifetime was not set")
+	}
+	if s.Handler.Options.RefreshTokenLifetime != models.DefaultRefreshTokenLifetime {
+		t.Fatalf("unexpected refresh token lifetime: %s", s.Handler.Options.RefreshTokenLifetime)
+	}
+}
+
+func TestConfig_WebAuthn(t *testing.T) {
+	c := httpd.NewConfig()
+	c.Issuer = "https://auth.example.com"
+	c.WebAuthnRPID = "example.com"
+	s := httpd.NewService(c)
+	rp := s.Handler.Options.RelyingParty
+	if rp == nil || rp.ID != "example.com" {
+		t.Fatalf("relying party was not set")
+	}
+	if len(rp.Origins) != 1 || rp.Origins[0] != "https://auth.example.com" {
+		t.Fatalf("unexpected origins: %v", rp.Origins)
+	}
+}
+
+func TestConfig_LoginLockout(t *testing.T) {
+	var c httpd.Config
+	if _, err := toml.Decode(`
+login-failure-window = "1h"
+login-max-failures = 3
+login-max-failures-per-address = 0
+login-lockout = "30m"
+login-delay = "2s"
+login-history-retention = "720h"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+	opts := httpd.NewService(c).Handler.Options
+	if opts.LoginFailureWindow != time.Hour {
+		t.Fatalf("unexpected failure window: %s", opts.LoginFailureWindow)
+	} else if opts.LoginMaxFailures != 3 {
+		t.Fatalf("unexpected max failures: %d", opts.LoginMaxFailures)
+	} else if opts.LoginMaxFailuresPerAddr != 0 {
+		t.Fatalf("unexpected max failures per address: %d", opts.LoginMaxFailuresPerAddr)
+	} else if opts.LoginLockout != 30*time.Minute {
+		t.Fatalf("unexpected lockout: %s", opts.LoginLockout)
+	} else if opts.LoginDelay != 2*time.Second {
+		t.Fatalf("unexpected delay: %s", opts.LoginDelay)
+	} else if opts.LoginHistoryRetention != 720*time.Hour {
+		t.Fatalf("unexpected login history retention: %s", opts.LoginHistoryRetention)
+	}
+}
+
+func TestConfig_RateLimits(t *testing.T) {
+	var c httpd.Config
+	if _, err := toml.Decode(`
+rate-limit-window = "10m"
+rate-limit-per-address = 0
+rate-limit-per-email = 3
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+	opts := httpd.NewService(c).Handler.Options
+	if opts.RateLimitWindow != 10*time.Minute {
+		t.Fatalf("unexpected rate limit window: %s", opts.RateLimitWindow)
+	} else if opts.RateLimitPerAddr != 0 {
+		t.Fatalf("unexpected rate limit per address: %d", opts.RateLimitPerAddr)
+	} else if opts.RateLimitPerEmail != 3 {
+		t.Fatalf("unexpected rate limit per email: %d", opts.RateLimitPerEmail)
+	}
+}
+
+func TestConfig_TrustedProxies(t *testing.T) {
+	var c httpd.Config
+	if _, err := toml.Decode(`
+trusted-proxies = ["10.0.0.0/8", "192.0.2.1", "2001:db8::1"]
+login-max-failures-per-account-address = 4
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+	h := httpd.NewService(c).Handler
+	if len(h.TrustedProxies) != 3 {
+		t.Fatalf("unexpected trusted proxies: %v", h.TrustedProxies)
+	} else if h.TrustedProxies[1].String() != "192.0.2.1/32" {
+		t.Fatalf("unexpected trusted proxy: %s", h.TrustedProxies[1])
+	} else if h.TrustedProxies[2].String() != "2001:db8::1/128" {
+		t.Fatalf("unexpected trusted proxy: %s", h.TrustedProxies[2])
+	} else if h.Options.LoginMaxFailuresPerPair != 4 {
+		t.Fatalf("unexpected max failures per account and address: %d", h.Options.LoginMaxFailuresPerPair)
+	}
+}
@@ -0,0 +1,92 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/utils"
+)
+
+func (h *Handler) adminForceReloginService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if !u.IsAdmin {
+		httpError(w, "Only administrators can force users to log in again", false, http.StatusForbidden)
+		return
+	}
+	if err := models.ForceUserRelogin(h.Cassandra, r.URL.Query().Get(":email")); err != nil {
+		if _, ok := err.(*models.UserNotFoundError); ok {
+			httpError(w, err.Error(), false, http.StatusNotFound)
+			return
+		}
+		httpError(w, "Failed to end the sessions of this user", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "revoked"}, false))
+}
+
+func (h *Handler) adminUnlockUserService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if !u.IsAdmin {
+		httpError(w, "Only administrators can unlock users", false, http.StatusForbidden)
+		return
+	}
+	if err := models.UnlockUser(h.Cassandra, h.parseClientInfo(r), u.Email, r.URL.Query().Get(":email")); err != nil {
+		if _, ok := err.(*models.UserNotFoundError); ok {
+			httpError(w, err.Error(), false, http.StatusNotFound)
+			return
+		}
+		httpError(w, "Failed to unlock this user", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "unlocked"}, false))
+}
+
+func (h *Handler) adminUserExpiryService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if !u.IsAdmin {
+		httpError(w, "Only administrators can change the expiry of users", false, http.StatusForbidden)
+		return
+	}
+	var req UserExpiryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, "access_valid_till must be a RFC 3339 timestamp or null", false, http.StatusBadRequest)
+		return
+	}
+	user, err := models.SetUserExpiry(h.Cassandra, h.parseClientInfo(r), u.Email, r.URL.Query().Get(":email"), req.AccessValidTill)
+	if err != nil {
+		if _, ok := err.(*models.UserNotFoundError); ok {
+			httpError(w, err.Error(), false, http.StatusNotFound)
+			return
+		}
+		httpError(w, "Failed to change the expiry of this user", false, http.StatusInternalServerError)
+		return
+	}
+	response := UserExpiryRequest{}
+	if user.IsExpiring {
+		response.AccessValidTill = &user.AccessValidTill
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(response, false))
+}
+
+func (h *Handler) adminAuditEventsService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if !u.IsAdmin {
+		httpError(w, "Only administrators can read the audit log", false, http.StatusForbidden)
+		return
+	}
+	events, err := models.FindAuditEvents(h.Cassandra, r.URL.Query().Get(":email"))
+	if err != nil {
+		httpError(w, "Failed to fetch the audit log", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapAuditEvents(events), false))
+}
+
+// UserExpiryRequest defines an inbound req to extend or clear the expiry of
+// an account. Without access_valid_till, the account never expires.
+type UserExpiryRequest struct {
+	AccessValidTill *time.Time `json:"access_valid_till"`
+}
@@ -0,0 +1,206 @@
+package httpd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/yosssi/ace"
+)
+
+// ResponseTypeCode is the only response type supported by the authorization endpoint
+const ResponseTypeCode = "code"
+
+// authorizeCSRFCookie holds the browser secret, which the CSRF tokens of the
+// login page are derived from
+const authorizeCSRFCookie = "authorize_csrf"
+
+// authenticationAuthorizeService renders the login page of the authorization
+// code flow
+func (h *Handler) authenticationAuthorizeService(w http.ResponseWriter, r *http.Request) {
+	req, state := parseAuthorizationRequest(r)
+	if _, err := models.ValidateAuthorizationRequest(h.Cassandra, req); err != nil {
+		renderAuthorizePage(w, req, state, "", "", "", err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.FormValue("response_type") != ResponseTypeCode {
+		redirectAuthorizationError(w, r, req, state, "unsupported_response_type")
+		return
+	}
+	if !h.Options.OpenIDEnabled() && req.HasScope(models.ScopeOpenID) {
+		redirectAuthorizationError(w, r, req, state, "invalid_scope")
+		return
+	}
+	csrfToken, err := h.authorizeCSRFToken(w, r, req, state)
+	if err != nil {
+		redirectAuthorizationError(w, r, req, state, "server_error")
+		return
+	}
+	renderAuthorizePage(w, req, state, csrfToken, "", "", "", http.StatusOK)
+}
+
+// authenticationAuthorizeSubmitService signs the user in and redirects back
+// to the client with a one-time authorization code
+func (h *Handler) authenticationAuthorizeSubmitService(w http.ResponseWriter, r *http.Request) {
+	req, state := parseAuthorizationRequest(r)
+	// Never redirect before the client and its redirect URI have been
+	// verified, the endpoint would be an open redirector otherwise
+	if _, err := models.ValidateAuthorizationRequest(h.Cassandra, req); err != nil {
+		renderAuthorizePage(w, req, state, "", "", "", err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Another site could otherwise submit its own credentials and sign the
+	// browser in to the account of the attacker
+	csrfToken, err := h.authorizeCSRFToken(w, r, req, state)
+	if err != nil {
+		redirectAuthorizationError(w, r, req, state, "server_error")
+		return
+	}
+	if !hmac.Equal([]byte(csrfToken), []byte(r.PostFormValue("csrf_token"))) {
+		renderAuthorizePage(w, req, state, csrfToken, "", "", "The sign in form has expired, please try again!", http.StatusForbidden)
+		return
+	}
+
+	user := h.authorizeUser(w, r, req, state, csrfToken)
+	if user == nil {
+		return
+	}
+	code, err := models.CreateAuthorizationCode(h.Cassandra, h.Options, user, req)
+	if err != nil {
+		redirectAuthorizationError(w, r, req, state, "server_error")
+		return
+	}
+	redirectAuthorization(w, r, req.RedirectURI, url.Values{"code": {code}}, state)
+}
+
+// authorizeUser signs the user of the login page in. Users with two factor
+// authentication are shown the page again, asking for the code of their
+// authenticator app. The page has been rendered, whenever no user is returned.
+func (h *Handler) authorizeUser(w http.ResponseWriter, r *http.Request, req *models.AuthorizationRequest, state string, csrfToken string) *sitrep.UsersByEmail {
+	if mfaToken := r.PostFormValue("mfa_token"); mfaToken != "" {
+		user, err := models.VerifyMfaChallenge(h.Cassandra, h.Options, h.parseClientInfo(r), mfaToken, r.PostFormValue("otp"))
+		if err != nil {
+			renderAuthorizePage(w, req, state, csrfToken, "", "", err.Error(), http.StatusForbidden)
+			return nil
+		}
+		return user
+	}
+
+	username := r.PostFormValue("username")
+	user, err := models.AuthenticateLogin(h.Cassandra, h.Options, h.parseClientInfo(r), username, r.PostFormValue("password"))
+	if err == nil {
+		err = models.CheckEmailConfirmed(h.Options, user)
+	}
+	if err != nil {
+		renderAuthorizePage(w, req, state, csrfToken, username, "", err.Error(), http.StatusForbidden)
+		return nil
+	}
+	if user.TotpEnabled {
+		mfaToken, err := models.CreateMfaChallenge(h.Cassandra, h.Options, user)
+		if err != nil {
+			renderAuthorizePage(w, req, state, csrfToken, username, "", err.Error(), http.StatusInternalServerError)
+			return nil
+		}
+		renderAuthorizePage(w, req, state, csrfToken, username, mfaToken, "", http.StatusOK)
+		return nil
+	}
+	return user
+}
+
+func parseAuthorizationRequest(r *http.Request) (*models.AuthorizationRequest, string) {
+	return &models.AuthorizationRequest{
+		ClientID:            r.FormValue("client_id"),
+		RedirectURI:         r.FormValue("redirect_uri"),
+		Scope:               r.FormValue("scope"),
+		CodeChallenge:       r.FormValue("code_challenge"),
+		CodeChallengeMethod: r.FormValue("code_challenge_method"),
+		Nonce:               r.FormValue("nonce"),
+	}, r.FormValue("state")
+}
+
+// authorizeCSRFToken returns the CSRF token of the login page for req. It is
+// derived from a secret in a cookie of the browser, which other sites can
+// neither read nor set, and is bound to the authorization request, so it
+// can not be replayed for another client or redirect.
+func (h *Handler) authorizeCSRFToken(w http.ResponseWriter, r *http.Request, req *models.AuthorizationRequest, state string) (string, error) {
+	secret := ""
+	if cookie, err := r.Cookie(authorizeCSRFCookie); err == nil {
+		secret = cookie.Value
+	}
+	if secret == "" {
+		var err error
+		if secret, err = sitrep.NewOpaqueToken(); err != nil {
+			return "", err
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     authorizeCSRFCookie,
+			Value:    secret,
+			Path:     "/apis/authentication/authorize",
+			HttpOnly: true,
+			Secure:   strings.HasPrefix(h.Options.Issuer, "https://"),
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, field := range []string{req.ClientID, req.RedirectURI, req.Scope, req.CodeChallenge, req.CodeChallengeMethod, req.Nonce, state} {
+		mac.Write([]byte(field))
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func renderAuthorizePage(w http.ResponseWriter, req *models.AuthorizationRequest, state string, csrfToken string, username string, mfaToken string, message string, code int) {
+	tpl, err := ace.Load("html/authorize", "", nil)
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "text/html")
+	// The page takes passwords, it must never be framed by another origin
+	w.Header().Add("X-Frame-Options", "DENY")
+	w.WriteHeader(code)
+
+	data := map[string]interface{}{
+		"Error":     message,
+		"Username":  username,
+		"MfaToken":  mfaToken,
+		"CsrfToken": csrfToken,
+		"Params": map[string]string{
+			"response_type":         ResponseTypeCode,
+			"client_id":             req.ClientID,
+			"redirect_uri":          req.RedirectURI,
+			"scope":                 req.Scope,
+			"state":                 state,
+			"code_challenge":        req.CodeChallenge,
+			"code_challenge_method": req.CodeChallengeMethod,
+			"nonce":                 req.Nonce,
+		},
+	}
+	tpl.Execute(w, data)
+}
+
+func redirectAuthorizationError(w http.ResponseWriter, r *http.Request, req *models.AuthorizationRequest, state string, reason string) {
+	redirectAuthorization(w, r, req.RedirectURI, url.Values{"error": {reason}}, state)
+}
+
+func redirectAuthorization(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values, state string) {
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		httpError(w, "redirect_uri is invalid", false, http.StatusBadRequest)
+		return
+	}
+	if state != "" {
+		params.Set("state", state)
+	}
+	query := target.Query()
+	for k, v := range params {
+		query[k] = v
+	}
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
@@ -9,7 +9,9 @@ import (
 )
 
 func (h *Handler) authenticationPasswordChangeService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
-
+	if refuseImpersonation(w, r) {
+		return
+	}
 	req, err := unmarshalPasswordChangeRequest(r)
 	if err != nil {
 		httpError(w, "Password could not be changed", false, http.StatusInternalServerError)
@@ -24,7 +26,11 @@ func (h *Handler) authenticationPasswordChangeService(w http.ResponseWriter, r *
 		httpError(w, "Old password is empty", false, http.StatusExpectationFailed)
 		return
 	}
-	pwChange, err := models.UserChangePassword(h.Cassandra, u, req.OldPassword, req.NewPassword)
+	pwChange, err := models.UserChangePassword(h.Cassandra, h.Options, u, req.OldPassword, req.NewPassword)
+	if invalid, ok := err.(*models.ValidationError); ok {
+		validationError(w, invalid)
+		return
+	}
 	if err != nil {
 		httpError(w, err.Error(), false, http.StatusForbidden)
 		return
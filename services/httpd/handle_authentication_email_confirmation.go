@@ -0,0 +1,66 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func (h *Handler) authenticationEmailConfirmService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalEmailConfirmationRequest(r)
+	if err != nil || req.Token == "" {
+		httpError(w, "token missing", false, http.StatusBadRequest)
+		return
+	}
+	if _, err := models.ConfirmEmail(h.Cassandra, h.Options, req.Token); err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "confirmed"}, false))
+}
+
+func (h *Handler) authenticationEmailConfirmationResendService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalEmailConfirmationRequest(r)
+	if err != nil || req.Email == "" {
+		httpError(w, "email missing", false, http.StatusBadRequest)
+		return
+	}
+	// The email address is counted whether an account exists or not, so
+	// the limit does not tell either
+	if err := models.ThrottleRequest(h.Cassandra, h.Options, models.RateLimitEmailConfirmation, h.parseClientInfo(r), req.Email); err != nil {
+		if err, ok := err.(*models.RateLimitedError); ok {
+			rateLimitedError(w, err)
+			return
+		}
+		httpError(w, "Failed to send the confirmation email", false, http.StatusInternalServerError)
+		return
+	}
+	// Like password resets, the mail is sent in the background, so the
+	// response does not tell whether an account exists
+	go func() {
+		if err := models.ResendConfirmationEmail(h.Cassandra, h.Options, req.Email); err != nil {
+			h.Logger.Printf("confirmation email could not be sent: %s", err)
+		}
+	}()
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(MarshalJSON(map[string]string{"status": "requested"}, false))
+}
+
+func unmarshalEmailConfirmationRequest(r *http.Request) (EmailConfirmationRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req EmailConfirmationRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// EmailConfirmationRequest defines an inbound req to confirm an email address
+type EmailConfirmationRequest struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
@@ -0,0 +1,126 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/utils"
+)
+
+func (h *Handler) authenticationInviteService(w http.ResponseWriter, r *http.Request, claims *models.ExerciseClaims) {
+	if !canManageInvitations(w, claims) {
+		return
+	}
+	var req InvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, "Invitation failed", false, http.StatusBadRequest)
+		return
+	}
+	invitation, err := models.InviteToExercise(h.Cassandra, h.Options, h.parseClientInfo(r), claims.Subject, claims.ExerciseID, req.Email, req.Role)
+	switch err := err.(type) {
+	case nil:
+	case *models.ValidationError:
+		validationError(w, err)
+		return
+	case *models.MailerDisabledError:
+		httpError(w, err.Error(), false, http.StatusServiceUnavailable)
+		return
+	default:
+		httpError(w, "Failed to send the invitation", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(MarshalJSON(utils.MapExerciseInvitation(invitation), false))
+}
+
+func (h *Handler) authenticationGetInvitationsService(w http.ResponseWriter, r *http.Request, claims *models.ExerciseClaims) {
+	if !canManageInvitations(w, claims) {
+		return
+	}
+	invitations, err := models.FindExerciseInvitations(h.Cassandra, claims.ExerciseID)
+	if err != nil {
+		httpError(w, "Failed to fetch invitations", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapExerciseInvitations(invitations), false))
+}
+
+func (h *Handler) authenticationRevokeInvitationService(w http.ResponseWriter, r *http.Request, claims *models.ExerciseClaims) {
+	if !canManageInvitations(w, claims) {
+		return
+	}
+	err := models.RevokeExerciseInvitation(h.Cassandra, h.parseClientInfo(r), claims.Subject, claims.ExerciseID, r.URL.Query().Get(":id"))
+	if _, ok := err.(*models.ExerciseInvitationNotFoundError); ok {
+		httpError(w, err.Error(), false, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		httpError(w, "Failed to revoke the invitation", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "revoked"}, false))
+}
+
+func (h *Handler) authenticationAcceptInvitationService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalRegistrationRequest(r)
+	if err != nil {
+		httpError(w, "Failed to accept the invitation", false, http.StatusBadRequest)
+		return
+	}
+	user, err := models.AcceptExerciseInvitation(h.Cassandra, h.Options, h.parseClientInfo(r), &models.Registration{
+		Email:           req.Email,
+		Password:        req.Password,
+		RealName:        req.Name,
+		UserTitle:       req.Title,
+		UserRank:        req.Rank,
+		UserUnit:        req.Unit,
+		TwitterName:     req.TwitterAlias,
+		ExerciseID:      req.ExerciseID,
+		InvitationToken: req.InvitationToken,
+	})
+	switch err := err.(type) {
+	case nil:
+	case *models.ValidationError:
+		validationError(w, err)
+		return
+	case *models.RateLimitedError:
+		rateLimitedError(w, err)
+		return
+	case *models.ExerciseInvitationInvalidError, *models.UserInvalidError, *models.RegistrationClosedError:
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	default:
+		httpError(w, "Failed to accept the invitation", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapUser(user), false))
+}
+
+// canManageInvitations refuses everybody but the admins of the exercise and
+// of the whole service. Impersonated sessions can not invite anybody.
+func canManageInvitations(w http.ResponseWriter, claims *models.ExerciseClaims) bool {
+	if claims == nil {
+		httpError(w, "User is not authorized in this exercise at all!", false, http.StatusUnauthorized)
+		return false
+	}
+	if claims.Actor != "" {
+		httpError(w, "Impersonated sessions can not do this", false, http.StatusForbidden)
+		return false
+	}
+	if !claims.IsAdmin && !claims.IsSiteAdmin {
+		httpError(w, "Only exercise administrators can manage invitations", false, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// InvitationRequest defines an inbound req to invite somebody into an exercise
+type InvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
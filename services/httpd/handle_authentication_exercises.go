@@ -18,14 +18,13 @@ func (h *Handler) authenticationGetExercisesService(w http.ResponseWriter, r *ht
 	w.Write(MarshalJSON(exercises, false))
 }
 
-func (h *Handler) authenticationGetCurrentExercisePermissions(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier) {
-	exercises, err := models.FindExercisePermissionsForUser(h.Cassandra, u, exercise)
-	if err != nil {
+func (h *Handler) authenticationGetCurrentExercisePermissions(w http.ResponseWriter, r *http.Request, claims *models.ExerciseClaims) {
+	if claims == nil {
 		httpError(w, "User is not authorized in this exercise at all!", false, http.StatusUnauthorized)
 		return
 	}
 	w.Header().Add("content-type", "application/json")
-	w.Write(MarshalJSON(exercises, false))
+	w.Write(MarshalJSON(claims.Permissions(), false))
 }
 
 func (h *Handler) authenticationGetExercisesSettings(w http.ResponseWriter, r *http.Request, exercise *sitrep.ExerciseByIdentifier) {
@@ -35,17 +34,31 @@ func (h *Handler) authenticationGetExercisesSettings(w http.ResponseWriter, r *h
 		return
 	}
 	w.Header().Add("content-type", "application/json")
-	w.Write(MarshalJSON(settings, false))
+	w.Write(MarshalJSON(models.PublicExerciseSettings(settings), false))
 }
 
-func (h *Handler) authenticationUpdateExercisesSettings(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier) {
-	exercises, err := models.FindExercisePermissionsForUser(h.Cassandra, u, exercise)
-	if err != nil {
+func (h *Handler) authenticationExerciseTokenService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier) {
+	principal := requestPrincipal(r)
+	if principal == nil {
 		httpError(w, "User is not authorized in this exercise at all!", false, http.StatusUnauthorized)
 		return
 	}
-	if !exercises.IsAdmin || !u.IsAdmin {
-		httpError(w, "User is authorized to update settings", false, http.StatusUnauthorized)
+	token, err := models.IssueExerciseToken(h.Cassandra, h.Options, principal, exercise)
+	if expired, ok := err.(*models.AccountExpiredError); ok {
+		accountExpiredError(w, expired)
+		return
+	}
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(token, false))
+}
+
+func (h *Handler) authenticationUpdateExercisesSettings(w http.ResponseWriter, r *http.Request, claims *models.ExerciseClaims) {
+	if claims == nil {
+		httpError(w, "User is not authorized in this exercise at all!", false, http.StatusUnauthorized)
 		return
 	}
 	req, err := unmarshalSettingsUpdateRequest(r)
@@ -53,7 +66,11 @@ func (h *Handler) authenticationUpdateExercisesSettings(w http.ResponseWriter, r
 		httpError(w, "Error occured while processing your settings!", false, http.StatusInternalServerError)
 		return
 	}
-	updated, err := models.UpdateExerciseSetting(h.Cassandra, exercise.Id, req.Values)
+	if !claims.CanUpdateSettings(req.Values) {
+		httpError(w, "User is not authorized to update these settings", false, http.StatusUnauthorized)
+		return
+	}
+	updated, err := models.UpdateExerciseSetting(h.Cassandra, claims.ExerciseID, req.Values)
 	if err != nil {
 		httpError(w, "Error occured while saving your settings!", false, http.StatusInternalServerError)
 		return
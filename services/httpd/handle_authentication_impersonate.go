@@ -0,0 +1,51 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+func (h *Handler) authenticationImpersonateService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	// Acting as somebody else is not passed on
+	if impersonator(r) != "" {
+		httpError(w, "Impersonated sessions can not impersonate other users", false, http.StatusForbidden)
+		return
+	}
+	var req ImpersonationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		httpError(w, "email missing", false, http.StatusBadRequest)
+		return
+	}
+	if req.ExerciseID == "" {
+		req.ExerciseID, _ = parseExerciseID(r)
+	}
+	token, err := models.ImpersonateUser(h.Cassandra, h.Options, h.parseClientInfo(r), u, req.Email, req.ExerciseID)
+	switch err := err.(type) {
+	case nil:
+	case *models.UserNotFoundError:
+		httpError(w, err.Error(), false, http.StatusNotFound)
+		return
+	case *models.AccountExpiredError:
+		accountExpiredError(w, err)
+		return
+	case *models.ImpersonationForbiddenError, *models.MfaEnrollmentRequiredError:
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	default:
+		httpError(w, "Failed to impersonate this user", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "no-store")
+	w.Write(MarshalJSON(token, false))
+}
+
+// ImpersonationRequest defines an inbound req to act as another user.
+// Controllers name the exercise, they act within.
+type ImpersonationRequest struct {
+	Email      string `json:"email"`
+	ExerciseID string `json:"exercise_id"`
+}
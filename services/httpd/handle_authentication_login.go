@@ -3,11 +3,36 @@ package httpd
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/webauthn"
 	"github.com/rcrowley/go-metrics"
 )
 
+const (
+	// GrantTypeJwtBearer exchanges a username and password for tokens
+	GrantTypeJwtBearer = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	// GrantTypeRefreshToken exchanges a refresh token for new tokens
+	GrantTypeRefreshToken = "refresh_token"
+
+	// GrantTypeClientCredentials issues tokens to registered service clients
+	GrantTypeClientCredentials = "client_credentials"
+
+	// GrantTypeAuthorizationCode exchanges an authorization code for tokens
+	GrantTypeAuthorizationCode = "authorization_code"
+
+	// GrantTypeMfaOtp completes a sign in with the code of an authenticator app
+	GrantTypeMfaOtp = "urn:sitrep:params:oauth:grant-type:mfa-otp"
+
+	// GrantTypeWebAuthn signs a user in with a passkey or security key
+	GrantTypeWebAuthn = "urn:sitrep:params:oauth:grant-type:webauthn"
+)
+
 func (h *Handler) authenticationLoginService(w http.ResponseWriter, r *http.Request) {
 	counter := metrics.GetOrRegisterCounter(statAuthFail, h.statMap)
 	req, err := unmarshalRequest(r)
@@ -15,18 +40,100 @@ func (h *Handler) authenticationLoginService(w http.ResponseWriter, r *http.Requ
 		httpError(w, "Login failed", false, http.StatusInternalServerError)
 		return
 	}
-	if req.GrantType != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+
+	var jwtResponse *sitrep.JWTResponse
+	switch req.GrantType {
+	case GrantTypeJwtBearer:
+		if req.Username == "" || req.Password == "" {
+			counter.Inc(1)
+			httpError(w, "username or password missing", false, http.StatusForbidden)
+			return
+		}
+		jwtResponse, err = models.UserSignIn(h.Cassandra, h.Options, h.parseClientInfo(r), req.Username, req.Password, req.GrantType)
+	case GrantTypeRefreshToken:
+		if req.RefreshToken == "" {
+			counter.Inc(1)
+			httpError(w, "refresh token missing", false, http.StatusForbidden)
+			return
+		}
+		jwtResponse, err = models.RefreshSignIn(h.Cassandra, h.Options, h.parseClientInfo(r), req.RefreshToken)
+	case GrantTypeClientCredentials:
+		clientID, secret, ok := r.BasicAuth()
+		if !ok {
+			clientID, secret = req.ClientID, req.ClientSecret
+		}
+		if clientID == "" || secret == "" {
+			counter.Inc(1)
+			httpError(w, "client credentials missing", false, http.StatusUnauthorized)
+			return
+		}
+		jwtResponse, err = models.ClientSignIn(h.Cassandra, h.Options, clientID, secret, req.Scope)
+	case GrantTypeAuthorizationCode:
+		clientID, secret, ok := r.BasicAuth()
+		if !ok {
+			clientID, secret = req.ClientID, req.ClientSecret
+		}
+		if clientID == "" || req.Code == "" || req.CodeVerifier == "" {
+			counter.Inc(1)
+			httpError(w, "client_id, code or code_verifier missing", false, http.StatusBadRequest)
+			return
+		}
+		jwtResponse, err = models.ExchangeAuthorizationCode(h.Cassandra, h.Options, h.parseClientInfo(r), clientID, secret, req.Code, req.RedirectURI, req.CodeVerifier)
+	case GrantTypeMfaOtp:
+		if req.MfaToken == "" || req.Otp == "" {
+			counter.Inc(1)
+			httpError(w, "mfa_token or otp missing", false, http.StatusForbidden)
+			return
+		}
+		jwtResponse, err = models.MfaSignIn(h.Cassandra, h.Options, h.parseClientInfo(r), req.MfaToken, req.Otp)
+	case GrantTypeWebAuthn:
+		clientData, err1 := webauthn.DecodeID(req.ClientDataJSON)
+		authData, err2 := webauthn.DecodeID(req.AuthenticatorData)
+		signature, err3 := webauthn.DecodeID(req.Signature)
+		if req.CredentialID == "" || err1 != nil || err2 != nil || err3 != nil || len(clientData) == 0 || len(authData) == 0 || len(signature) == 0 {
+			counter.Inc(1)
+			httpError(w, "credential_id, client_data_json, authenticator_data or signature missing", false, http.StatusForbidden)
+			return
+		}
+		jwtResponse, err = models.WebAuthnSignIn(h.Cassandra, h.Options, h.parseClientInfo(r), req.CredentialID, clientData, authData, signature)
+	default:
 		counter.Inc(1)
-		httpError(w, "grant type must be urn:ietf:params:oauth:grant-type:jwt-bearer to request a password", false, http.StatusInternalServerError)
+		httpError(w, "grant type must be urn:ietf:params:oauth:grant-type:jwt-bearer to request a password, refresh_token to refresh a session, client_credentials for service clients, authorization_code to redeem a code, urn:sitrep:params:oauth:grant-type:mfa-otp to complete a two factor sign in or urn:sitrep:params:oauth:grant-type:webauthn to sign in with a security key", false, http.StatusInternalServerError)
 		return
 	}
+	h.writeTokenResponse(w, jwtResponse, err)
+}
 
-	if req.Username == "" || req.Password == "" {
+// writeTokenResponse answers a sign in with the issued tokens or with why
+// none have been issued
+func (h *Handler) writeTokenResponse(w http.ResponseWriter, jwtResponse *sitrep.JWTResponse, err error) {
+	counter := metrics.GetOrRegisterCounter(statAuthFail, h.statMap)
+	if mfa, ok := err.(*models.MfaRequiredError); ok {
+		w.Header().Add("content-type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write(MarshalJSON(map[string]string{
+			"error":             "mfa_required",
+			"error_description": mfa.Error(),
+			"mfa_token":         mfa.MfaToken,
+		}, false))
+		return
+	}
+	if locked, ok := err.(*models.AccountLockedError); ok {
+		counter.Inc(1)
+		w.Header().Add("content-type", "application/json")
+		w.Header().Add("Retry-After", strconv.Itoa(int(locked.RetryAfter/time.Second)))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write(MarshalJSON(map[string]string{
+			"error":             "account_locked",
+			"error_description": locked.Error(),
+		}, false))
+		return
+	}
+	if expired, ok := err.(*models.AccountExpiredError); ok {
 		counter.Inc(1)
-		httpError(w, "username or password missing", false, http.StatusForbidden)
+		accountExpiredError(w, expired)
 		return
 	}
-	jwtResponse, err := models.UserSignIn(h.Cassandra, req.Username, req.Password, req.GrantType)
 	if err != nil {
 		counter.Inc(1)
 		httpError(w, err.Error(), false, http.StatusForbidden)
@@ -37,8 +144,34 @@ func (h *Handler) authenticationLoginService(w http.ResponseWriter, r *http.Requ
 }
 
 func unmarshalRequest(r *http.Request) (AuthenticationRequest, error) {
-	decoder := json.NewDecoder(r.Body)
 	var req AuthenticationRequest
+	// Browser apps and most OAuth libraries post their token requests as forms
+	if strings.HasPrefix(r.Header.Get("content-type"), "application/x-www-form-urlencoded") {
+		if err := r.ParseForm(); err != nil {
+			return req, err
+		}
+		req = AuthenticationRequest{
+			Username:     r.PostForm.Get("username"),
+			Password:     r.PostForm.Get("password"),
+			RefreshToken: r.PostForm.Get("refresh_token"),
+			ClientID:     r.PostForm.Get("client_id"),
+			ClientSecret: r.PostForm.Get("client_secret"),
+			Scope:        r.PostForm.Get("scope"),
+			GrantType:    r.PostForm.Get("grant_type"),
+			Code:         r.PostForm.Get("code"),
+			RedirectURI:  r.PostForm.Get("redirect_uri"),
+			CodeVerifier: r.PostForm.Get("code_verifier"),
+			MfaToken:     r.PostForm.Get("mfa_token"),
+			Otp:          r.PostForm.Get("otp"),
+
+			CredentialID:      r.PostForm.Get("credential_id"),
+			ClientDataJSON:    r.PostForm.Get("client_data_json"),
+			AuthenticatorData: r.PostForm.Get("authenticator_data"),
+			Signature:         r.PostForm.Get("signature"),
+		}
+		return req, nil
+	}
+	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&req)
 	if err != nil {
 		return req, err
@@ -48,7 +181,22 @@ func unmarshalRequest(r *http.Request) (AuthenticationRequest, error) {
 
 // AuthenticationRequest defines an inbound authentication req
 type AuthenticationRequest struct {
-	Username  string `json:"username"`
-	Password  string `json:"password"`
-	GrantType string `json:"grant_type"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	RefreshToken string `json:"refresh_token"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code"`
+	RedirectURI  string `json:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier"`
+	MfaToken     string `json:"mfa_token"`
+	Otp          string `json:"otp"`
+
+	// Fields of a WebAuthn assertion, binary ones are base64url encoded
+	CredentialID      string `json:"credential_id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
 }
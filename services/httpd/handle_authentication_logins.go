@@ -0,0 +1,53 @@
+package httpd
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/utils"
+	"github.com/gocql/gocql"
+)
+
+func (h *Handler) authenticationGetLoginsService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	h.writeLoginHistory(w, r, u.Email)
+}
+
+func (h *Handler) adminLoginsService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if !u.IsAdmin {
+		httpError(w, "Only administrators can read the login history of other users", false, http.StatusForbidden)
+		return
+	}
+	h.writeLoginHistory(w, r, r.URL.Query().Get(":email"))
+}
+
+// writeLoginHistory writes a page of the login history of the user with
+// email. The limit parameter sets the page size, the id of the last login
+// of a page is passed as before parameter to get the next one.
+func (h *Handler) writeLoginHistory(w http.ResponseWriter, r *http.Request, email string) {
+	q := r.URL.Query()
+	var before gocql.UUID
+	if cursor := q.Get("before"); cursor != "" {
+		var err error
+		if before, err = gocql.ParseUUID(cursor); err != nil {
+			httpError(w, "before is invalid", false, http.StatusBadRequest)
+			return
+		}
+	}
+	var limit int
+	if l := q.Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			httpError(w, "limit is invalid", false, http.StatusBadRequest)
+			return
+		}
+	}
+	logins, err := models.FindLoginHistory(h.Cassandra, email, before, limit)
+	if err != nil {
+		httpError(w, "Failed to fetch the login history", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapLoginHistory(logins), false))
+}
@@ -0,0 +1,36 @@
+package httpd
+
+import (
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+func (h *Handler) authenticationLogoutService(w http.ResponseWriter, r *http.Request, p *models.Principal) {
+	token, err := parseCredentials(r)
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusUnauthorized)
+		return
+	}
+	if err := models.UserSignOut(h.Cassandra, token); err != nil {
+		httpError(w, "Logout failed", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "logged_out"}, false))
+}
+
+func (h *Handler) authenticationLogoutAllService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	// Rotating the key also voids exercise tokens, which are not stored, once
+	// their user is checked (see models.VerifyExerciseToken)
+	if err := models.RotateUserEncryptionKey(h.Cassandra, u); err != nil {
+		httpError(w, "Logout failed", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "logged_out"}, false))
+}
@@ -0,0 +1,74 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func (h *Handler) authenticationMagicLinkRequestService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalMagicLinkRequest(r)
+	if err != nil || req.Email == "" {
+		httpError(w, "email missing", false, http.StatusBadRequest)
+		return
+	}
+	if req.ExerciseID == "" {
+		req.ExerciseID, _ = parseExerciseID(r)
+	}
+	// Whether an exercise allows emailed links is no secret, whether an
+	// account exists is
+	if err := models.CheckMagicLinkEnabled(h.Cassandra, req.ExerciseID); err != nil {
+		if _, ok := err.(*models.MagicLinkDisabledError); ok {
+			httpError(w, err.Error(), false, http.StatusForbidden)
+			return
+		}
+		httpError(w, "Failed to send the sign in link", false, http.StatusInternalServerError)
+		return
+	}
+	// The email address is counted whether an account exists or not, so
+	// the limit does not tell either
+	if err := models.ThrottleRequest(h.Cassandra, h.Options, models.RateLimitMagicLink, h.parseClientInfo(r), req.Email); err != nil {
+		if err, ok := err.(*models.RateLimitedError); ok {
+			rateLimitedError(w, err)
+			return
+		}
+		httpError(w, "Failed to send the sign in link", false, http.StatusInternalServerError)
+		return
+	}
+	go func() {
+		if err := models.RequestMagicLink(h.Cassandra, h.Options, req.Email, req.ExerciseID); err != nil {
+			h.Logger.Printf("sign in link could not be requested: %s", err)
+		}
+	}()
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(MarshalJSON(map[string]string{"status": "requested"}, false))
+}
+
+func (h *Handler) authenticationMagicLinkRedeemService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalMagicLinkRequest(r)
+	if err != nil || req.Token == "" {
+		httpError(w, "token missing", false, http.StatusBadRequest)
+		return
+	}
+	jwtResponse, err := models.MagicLinkSignIn(h.Cassandra, h.Options, h.parseClientInfo(r), req.Token)
+	h.writeTokenResponse(w, jwtResponse, err)
+}
+
+func unmarshalMagicLinkRequest(r *http.Request) (MagicLinkRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req MagicLinkRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// MagicLinkRequest defines an inbound req to sign in with an emailed link
+type MagicLinkRequest struct {
+	Email      string `json:"email"`
+	ExerciseID string `json:"exercise_id"`
+	Token      string `json:"token"`
+}
@@ -0,0 +1,96 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+func (h *Handler) authenticationTotpEnrollService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	enrollment, err := models.EnrollTotp(h.Cassandra, u)
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusConflict)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "no-store")
+	w.Write(MarshalJSON(enrollment, false))
+}
+
+func (h *Handler) authenticationTotpVerifyService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	req, err := unmarshalMfaRequest(r)
+	if err != nil || req.Code == "" {
+		httpError(w, "code missing", false, http.StatusBadRequest)
+		return
+	}
+	codes, err := models.ConfirmTotp(h.Cassandra, u, req.Code, h.parseClientInfo(r))
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "no-store")
+	w.Write(MarshalJSON(map[string]interface{}{
+		"status":         "enabled",
+		"recovery_codes": codes,
+	}, false))
+}
+
+func (h *Handler) authenticationTotpDisableService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	req, err := unmarshalMfaRequest(r)
+	if err != nil || req.Code == "" {
+		httpError(w, "code missing", false, http.StatusBadRequest)
+		return
+	}
+	if err := models.DisableTotp(h.Cassandra, u, req.Code, h.parseClientInfo(r)); err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "disabled"}, false))
+}
+
+func (h *Handler) authenticationRecoveryCodesService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	req, err := unmarshalMfaRequest(r)
+	if err != nil || req.Code == "" {
+		httpError(w, "code missing", false, http.StatusBadRequest)
+		return
+	}
+	codes, err := models.RegenerateRecoveryCodes(h.Cassandra, u, req.Code, h.parseClientInfo(r))
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "no-store")
+	w.Write(MarshalJSON(map[string]interface{}{"recovery_codes": codes}, false))
+}
+
+func unmarshalMfaRequest(r *http.Request) (MfaRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req MfaRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// MfaRequest defines an inbound req carrying the code of an authenticator app
+type MfaRequest struct {
+	Code string `json:"code"`
+}
@@ -0,0 +1,77 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+)
+
+func (h *Handler) authenticationPasswordResetRequestService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalPasswordResetRequest(r)
+	if err != nil || req.Email == "" {
+		httpError(w, "email missing", false, http.StatusBadRequest)
+		return
+	}
+	// The email address is counted whether an account exists or not, so
+	// the limit does not tell either
+	if err := models.ThrottleRequest(h.Cassandra, h.Options, models.RateLimitPasswordReset, h.parseClientInfo(r), req.Email); err != nil {
+		if err, ok := err.(*models.RateLimitedError); ok {
+			rateLimitedError(w, err)
+			return
+		}
+		httpError(w, "Failed to request the password reset", false, http.StatusInternalServerError)
+		return
+	}
+	// The mail is sent in the background, so neither the response nor its
+	// timing tell whether an account exists
+	go func() {
+		if err := models.RequestPasswordReset(h.Cassandra, h.Options, req.Email); err != nil {
+			h.Logger.Printf("password reset could not be requested: %s", err)
+		}
+	}()
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(MarshalJSON(map[string]string{"status": "requested"}, false))
+}
+
+func (h *Handler) authenticationPasswordResetConfirmService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalPasswordResetRequest(r)
+	if err != nil || req.Token == "" {
+		httpError(w, "token missing", false, http.StatusBadRequest)
+		return
+	}
+	if req.NewPassword == "" || req.NewPassword != req.NewPasswordConfirmation {
+		httpError(w, "Passwords do not match", false, http.StatusExpectationFailed)
+		return
+	}
+	err = models.ResetPassword(h.Cassandra, h.Options, h.parseClientInfo(r), req.Token, req.NewPassword)
+	if invalid, ok := err.(*models.ValidationError); ok {
+		validationError(w, invalid)
+		return
+	}
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "changed"}, false))
+}
+
+func unmarshalPasswordResetRequest(r *http.Request) (PasswordResetRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req PasswordResetRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// PasswordResetRequest defines an inbound req to reset a forgotten password
+type PasswordResetRequest struct {
+	Email                   string `json:"email"`
+	Token                   string `json:"token"`
+	NewPassword             string `json:"new_password"`
+	NewPasswordConfirmation string `json:"new_password_confirmation"`
+}
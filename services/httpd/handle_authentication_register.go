@@ -0,0 +1,80 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/utils"
+)
+
+func (h *Handler) authenticationRegisterService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalRegistrationRequest(r)
+	if err != nil {
+		httpError(w, "Registration failed", false, http.StatusBadRequest)
+		return
+	}
+	user, err := models.RegisterUser(h.Cassandra, h.Options, h.parseClientInfo(r), &models.Registration{
+		Email:       req.Email,
+		Password:    req.Password,
+		RealName:    req.Name,
+		UserTitle:   req.Title,
+		UserRank:    req.Rank,
+		UserUnit:    req.Unit,
+		TwitterName: req.TwitterAlias,
+		ExerciseID:  req.ExerciseID,
+		JoinCode:    req.JoinCode,
+
+		InvitationToken: req.InvitationToken,
+	})
+	switch err := err.(type) {
+	case nil:
+	case *models.ValidationError:
+		validationError(w, err)
+		return
+	case *models.RateLimitedError:
+		rateLimitedError(w, err)
+		return
+	case *models.RegistrationClosedError, *models.ExerciseInvitationInvalidError:
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	default:
+		httpError(w, "Registration failed", false, http.StatusInternalServerError)
+		return
+	}
+	go func() {
+		if err := models.SendConfirmationEmail(h.Options, user); err != nil {
+			h.Logger.Printf("confirmation email could not be sent: %s", err)
+		}
+	}()
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(MarshalJSON(utils.MapUser(user), false))
+}
+
+func unmarshalRegistrationRequest(r *http.Request) (RegistrationRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req RegistrationRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// RegistrationRequest defines an inbound req to create an account. Profile
+// fields are named like in the profile returned by the API.
+type RegistrationRequest struct {
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	Name         string `json:"name"`
+	Title        string `json:"title"`
+	Rank         string `json:"rank"`
+	Unit         string `json:"unit"`
+	TwitterAlias string `json:"twitter_alias"`
+	ExerciseID   string `json:"exercise_id"`
+	JoinCode     string `json:"join_code"`
+
+	// InvitationToken replaces the join code for invited users
+	InvitationToken string `json:"invitation_token"`
+}
@@ -0,0 +1,68 @@
+package httpd
+
+import (
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/utils"
+	"github.com/gocql/gocql"
+)
+
+func (h *Handler) authenticationGetSessionsService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	email, ok := sessionOwner(r, u)
+	if !ok {
+		httpError(w, "Only administrators can manage sessions of other users", false, http.StatusForbidden)
+		return
+	}
+	sessions, err := models.FindSessions(h.Cassandra, email)
+	if err != nil {
+		httpError(w, "Failed to fetch sessions", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapSessions(sessions), false))
+}
+
+func (h *Handler) authenticationDeleteSessionService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	email, ok := sessionOwner(r, u)
+	if !ok {
+		httpError(w, "Only administrators can manage sessions of other users", false, http.StatusForbidden)
+		return
+	}
+	id, err := gocql.ParseUUID(r.URL.Query().Get(":id"))
+	if err != nil {
+		httpError(w, models.NewSessionNotFoundError().Error(), false, http.StatusNotFound)
+		return
+	}
+	if err := models.RevokeSession(h.Cassandra, email, id); err != nil {
+		if _, ok := err.(*models.SessionNotFoundError); ok {
+			httpError(w, err.Error(), false, http.StatusNotFound)
+			return
+		}
+		httpError(w, "Failed to end the session", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "revoked"}, false))
+}
+
+// sessionOwner returns whose sessions are managed by a request. Global
+// administrators may pass the email of any user, everybody else can only
+// manage their own sessions. Without authentication, there is no owner.
+func sessionOwner(r *http.Request, u *sitrep.UsersByEmail) (string, bool) {
+	if u == nil {
+		return "", false
+	}
+	email := r.URL.Query().Get("email")
+	if email == "" || email == u.Email {
+		return u.Email, true
+	}
+	return email, u.IsAdmin
+}
@@ -0,0 +1,33 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+func TestSessions_RefuseImpersonation(t *testing.T) {
+	h := &Handler{}
+	user := &sitrep.UsersByEmail{Email: "someguy@somedomain.com"}
+	principal := &models.Principal{
+		Type:    models.PrincipalUser,
+		Subject: user.Email,
+		User:    user,
+		Actor:   &sitrep.UsersByEmail{Email: "admin@somedomain.com", IsAdmin: true},
+	}
+
+	for _, handler := range []func(http.ResponseWriter, *http.Request, *sitrep.UsersByEmail){
+		h.authenticationGetSessionsService,
+		h.authenticationDeleteSessionService,
+	} {
+		r := withPrincipal(httptest.NewRequest("GET", "/apis/authentication/sessions", nil), principal)
+		w := httptest.NewRecorder()
+		handler(w, r, user)
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("Impersonated session could manage sessions: %d", w.Code)
+		}
+	}
+}
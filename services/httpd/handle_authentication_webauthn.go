@@ -0,0 +1,122 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/utils"
+	"github.com/fkasper/sitrep-authentication/webauthn"
+)
+
+func (h *Handler) authenticationWebAuthnRegisterBeginService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	options, err := models.BeginWebAuthnRegistration(h.Cassandra, h.Options, u)
+	if err != nil {
+		httpError(w, "Failed to start the registration", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "no-store")
+	w.Write(MarshalJSON(map[string]interface{}{"publicKey": options}, false))
+}
+
+func (h *Handler) authenticationWebAuthnRegisterFinishService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	req, err := unmarshalWebAuthnRequest(r)
+	if err != nil {
+		httpError(w, "client_data_json or attestation_object missing", false, http.StatusBadRequest)
+		return
+	}
+	clientData, err1 := webauthn.DecodeID(req.ClientDataJSON)
+	attestation, err2 := webauthn.DecodeID(req.AttestationObject)
+	if err1 != nil || err2 != nil || len(clientData) == 0 || len(attestation) == 0 {
+		httpError(w, "client_data_json or attestation_object missing", false, http.StatusBadRequest)
+		return
+	}
+	credential, err := models.FinishWebAuthnRegistration(h.Cassandra, h.Options, u, h.parseClientInfo(r), req.Name, clientData, attestation)
+	if err != nil {
+		httpError(w, err.Error(), false, http.StatusForbidden)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(MarshalJSON(utils.MapWebAuthnCredentials([]sitrep.WebauthnCredentials{*credential})[0], false))
+}
+
+func (h *Handler) authenticationWebAuthnCredentialsService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	credentials, err := models.FindWebAuthnCredentials(h.Cassandra, u.Email)
+	if err != nil {
+		httpError(w, "Failed to fetch the security keys", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapWebAuthnCredentials(credentials), false))
+}
+
+func (h *Handler) authenticationWebAuthnCredentialDeleteService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	err := models.DeleteWebAuthnCredential(h.Cassandra, u, h.parseClientInfo(r), r.URL.Query().Get(":id"))
+	if _, ok := err.(*models.WebAuthnCredentialNotFoundError); ok {
+		httpError(w, err.Error(), false, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		httpError(w, "Failed to remove the security key", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(map[string]string{"status": "removed"}, false))
+}
+
+func (h *Handler) authenticationWebAuthnLoginBeginService(w http.ResponseWriter, r *http.Request) {
+	req, err := unmarshalWebAuthnRequest(r)
+	if err != nil || req.Username == "" {
+		httpError(w, "username missing", false, http.StatusBadRequest)
+		return
+	}
+	// Only the client address is counted, naming a user must not keep the
+	// user from signing in
+	if err := models.ThrottleRequest(h.Cassandra, h.Options, models.RateLimitWebAuthnSignIn, h.parseClientInfo(r), ""); err != nil {
+		if err, ok := err.(*models.RateLimitedError); ok {
+			rateLimitedError(w, err)
+			return
+		}
+		httpError(w, "Failed to start the sign in", false, http.StatusInternalServerError)
+		return
+	}
+	options, err := models.BeginWebAuthnSignIn(h.Cassandra, h.Options, req.Username)
+	if err != nil {
+		httpError(w, "Failed to start the sign in", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "no-store")
+	w.Write(MarshalJSON(map[string]interface{}{"publicKey": options}, false))
+}
+
+func unmarshalWebAuthnRequest(r *http.Request) (WebAuthnRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req WebAuthnRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// WebAuthnRequest defines an inbound req of a WebAuthn ceremony. Binary
+// fields are base64url encoded.
+type WebAuthnRequest struct {
+	Username          string `json:"username"`
+	Name              string `json:"name"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AttestationObject string `json:"attestation_object"`
+}
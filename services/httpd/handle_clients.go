@@ -0,0 +1,87 @@
+package httpd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+func (h *Handler) authenticationIntrospectService(w http.ResponseWriter, r *http.Request, c *sitrep.OauthClients) {
+	token := r.PostFormValue("token")
+	if token == "" {
+		httpError(w, "token missing", false, http.StatusBadRequest)
+		return
+	}
+	introspection, err := models.IntrospectToken(h.Cassandra, h.Options, c, token)
+	if _, ok := err.(*models.InvalidScopeError); ok {
+		httpError(w, "This client is not allowed to introspect tokens", false, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, "Token could not be introspected", false, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(introspection, false))
+}
+
+func (h *Handler) registerClientService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	if refuseImpersonation(w, r) {
+		return
+	}
+	if !u.IsAdmin {
+		httpError(w, "Only administrators can register clients", false, http.StatusForbidden)
+		return
+	}
+	req, err := unmarshalClientRequest(r)
+	if err != nil || req.Name == "" {
+		httpError(w, "client name missing", false, http.StatusBadRequest)
+		return
+	}
+	client := &sitrep.OauthClients{
+		AllowedScopes: strings.Join(req.Scopes, " "),
+		IsPublic:      req.IsPublic,
+		Name:          req.Name,
+		RedirectUris:  strings.Join(req.RedirectURIs, " "),
+	}
+	secret, err := models.RegisterClient(h.Cassandra, client)
+	if err != nil {
+		httpError(w, "Client could not be registered", false, http.StatusInternalServerError)
+		return
+	}
+	res := map[string]interface{}{
+		"client_id":     client.ClientId,
+		"name":          client.Name,
+		"scope":         client.AllowedScopes,
+		"redirect_uris": req.RedirectURIs,
+		"is_public":     client.IsPublic,
+	}
+	// Public clients can not keep a secret, so they do not get one
+	if !client.IsPublic {
+		res["client_secret"] = secret
+	}
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(MarshalJSON(res, false))
+}
+
+func unmarshalClientRequest(r *http.Request) (ClientRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	var req ClientRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// ClientRequest defines an inbound client registration req
+type ClientRequest struct {
+	Name         string   `json:"name"`
+	Scopes       []string `json:"scopes"`
+	RedirectURIs []string `json:"redirect_uris"`
+	IsPublic     bool     `json:"is_public"`
+}
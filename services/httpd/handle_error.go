@@ -3,6 +3,10 @@ package httpd
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
 )
 
 // httpError writes an error to the client in a standard format.
@@ -31,3 +35,30 @@ func resultError(w http.ResponseWriter, result Result, code int) {
 	w.WriteHeader(code)
 	_ = json.NewEncoder(w).Encode(&result)
 }
+
+// validationError writes the invalid fields of a request to the client
+func validationError(w http.ResponseWriter, err *models.ValidationError) {
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(422)
+	w.Write(MarshalJSON(map[string]interface{}{
+		"error":  err.Error(),
+		"fields": err.Fields,
+	}, false))
+}
+
+// accountExpiredError tells the client, that the account of the user has
+// expired, so it does not retry with the same credentials
+func accountExpiredError(w http.ResponseWriter, err *models.AccountExpiredError) {
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	w.Write(MarshalJSON(map[string]string{
+		"error":             "account_expired",
+		"error_description": err.Error(),
+	}, false))
+}
+
+// rateLimitedError tells the client, when it can repeat a request
+func rateLimitedError(w http.ResponseWriter, err *models.RateLimitedError) {
+	w.Header().Add("Retry-After", strconv.Itoa(int(err.RetryAfter/time.Second)))
+	httpError(w, err.Error(), false, http.StatusTooManyRequests)
+}
@@ -0,0 +1,11 @@
+package httpd
+
+import "net/http"
+
+// serveJWKS publishes the public keys tokens are signed with, so other
+// services can verify tokens without calling back into this service.
+func (h *Handler) serveJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "public, max-age=300")
+	w.Write(MarshalJSON(h.Options.Keys.JWKS(), false))
+}
@@ -0,0 +1,63 @@
+package httpd
+
+import (
+	"net/http"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/schema"
+	"github.com/fkasper/sitrep-authentication/utils"
+)
+
+// OpenIDConfiguration is the OpenID Connect discovery document
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JwksURI                           string   `json:"jwks_uri"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// serveOpenIDConfiguration publishes the endpoints and capabilities of this
+// service, so OpenID Connect clients can configure themselves. Without a key
+// ring, ID tokens can not be issued and nothing is published.
+func (h *Handler) serveOpenIDConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !h.Options.OpenIDEnabled() {
+		http.NotFound(w, r)
+		return
+	}
+	issuer := h.Options.Issuer
+	w.Header().Add("content-type", "application/json")
+	w.Header().Add("cache-control", "public, max-age=300")
+	w.Write(MarshalJSON(&OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/apis/authentication/authorize",
+		TokenEndpoint:                     issuer + "/apis/authentication/token",
+		UserInfoEndpoint:                  issuer + "/apis/authentication/userinfo",
+		JwksURI:                           issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:             issuer + "/apis/authentication/introspect",
+		ScopesSupported:                   []string{models.ScopeOpenID, sitrep.DefaultScope},
+		ResponseTypesSupported:            []string{ResponseTypeCode},
+		GrantTypesSupported:               []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken, GrantTypeClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{h.Options.IDTokenSigningAlgorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{sitrep.CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "exp", "iat", "nonce", "email", "email_verified", "name", "rank", "title", "unit"},
+	}, false))
+}
+
+// authenticationUserInfoService returns the OpenID Connect claims of the
+// signed in user
+func (h *Handler) authenticationUserInfoService(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail) {
+	w.Header().Add("content-type", "application/json")
+	w.Write(MarshalJSON(utils.MapUserClaims(u), false))
+}
@@ -4,16 +4,14 @@ import (
 	"net/http"
 
 	"github.com/fkasper/sitrep-authentication/models"
-	"github.com/fkasper/sitrep-authentication/schema"
 )
 
-func (h *Handler) getUsersList(w http.ResponseWriter, r *http.Request, u *sitrep.UsersByEmail, exercise *sitrep.ExerciseByIdentifier) {
-	exercises, err := models.FindExercisePermissionsForUser(h.Cassandra, u, exercise)
-	if err != nil {
+func (h *Handler) getUsersList(w http.ResponseWriter, r *http.Request, claims *models.ExerciseClaims) {
+	if claims == nil {
 		httpError(w, "User is not authorized in this exercise at all!", false, http.StatusUnauthorized)
 		return
 	}
-	if !exercises.IsAdmin || !u.IsAdmin {
+	if !claims.IsAdmin || !claims.IsSiteAdmin {
 		httpError(w, "User is authorized to fetch a list of users", false, http.StatusUnauthorized)
 		return
 	}
@@ -3,12 +3,14 @@ package httpd
 import (
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"os"
 	"strings"
 
 	"github.com/bmizerany/pat"
+	"github.com/fkasper/sitrep-authentication/models"
 	"github.com/fkasper/sitrep-authentication/schema"
 	"github.com/gocql/gocql"
 	"github.com/mattbaird/elastigo/lib"
@@ -62,6 +64,8 @@ type Handler struct {
 	Mongo          *mgo.Database
 	Elasticsearch  *elastigo.Conn
 	Cassandra      *gocql.ClusterConfig
+	Options        *models.Options
+	TrustedProxies []*net.IPNet // Proxies allowed to forward client addresses
 	statMap        metrics.Registry
 	Feature        *Feature
 	//statMap        *expvar.Map
@@ -80,6 +84,7 @@ func NewHandler(requireAuthentication, loggingEnabled, writeTrace bool) *Handler
 		loggingEnabled:        loggingEnabled,
 		WriteTrace:            writeTrace,
 		statMap:               metrics.DefaultRegistry,
+		Options:               models.NewOptions(),
 		Feature: &Feature{
 			ID:                      "nyi",
 			Name:                    "demo-feature",
@@ -94,10 +99,118 @@ func NewHandler(requireAuthentication, loggingEnabled, writeTrace bool) *Handler
 			"authentication_login-route",
 			"POST", "/apis/authentication/login", true, true, h.authenticationLoginService,
 		},
+		route{
+			"authentication_token-route",
+			"POST", "/apis/authentication/token", true, true, h.authenticationLoginService,
+		},
+		route{
+			"authentication_authorize-route",
+			"GET", "/apis/authentication/authorize", true, true, h.authenticationAuthorizeService,
+		},
+		route{
+			"authentication_authorize_submit-route",
+			"POST", "/apis/authentication/authorize", true, true, h.authenticationAuthorizeSubmitService,
+		},
+		route{
+			"authentication_userinfo-route",
+			"GET", "/apis/authentication/userinfo", true, true, h.authenticationUserInfoService,
+		},
+		route{
+			"authentication_userinfo_post-route",
+			"POST", "/apis/authentication/userinfo", true, true, h.authenticationUserInfoService,
+		},
+		route{
+			"authentication_logout-route",
+			"POST", "/apis/authentication/logout", true, true, h.authenticationLogoutService,
+		},
+		route{
+			"authentication_logout_all-route",
+			"POST", "/apis/authentication/logout-all", true, true, h.authenticationLogoutAllService,
+		},
+		route{
+			"authentication_sessions-route",
+			"GET", "/apis/authentication/sessions", true, true, h.authenticationGetSessionsService,
+		},
+		route{
+			"authentication_session_delete-route",
+			"DELETE", "/apis/authentication/sessions/:id", true, true, h.authenticationDeleteSessionService,
+		},
+		route{
+			"authentication_totp_enroll-route",
+			"POST", "/apis/authentication/mfa/totp", true, true, h.authenticationTotpEnrollService,
+		},
+		route{
+			"authentication_totp_verify-route",
+			"POST", "/apis/authentication/mfa/totp/verify", true, true, h.authenticationTotpVerifyService,
+		},
+		route{
+			"authentication_totp_disable-route",
+			"DELETE", "/apis/authentication/mfa/totp", true, true, h.authenticationTotpDisableService,
+		},
+		route{
+			"authentication_recovery_codes-route",
+			"POST", "/apis/authentication/mfa/recovery-codes", true, true, h.authenticationRecoveryCodesService,
+		},
+		route{
+			"authentication_webauthn_register_begin-route",
+			"POST", "/apis/authentication/webauthn/register/begin", true, true, h.authenticationWebAuthnRegisterBeginService,
+		},
+		route{
+			"authentication_webauthn_register_finish-route",
+			"POST", "/apis/authentication/webauthn/register/finish", true, true, h.authenticationWebAuthnRegisterFinishService,
+		},
+		route{
+			"authentication_webauthn_credentials-route",
+			"GET", "/apis/authentication/webauthn/credentials", true, true, h.authenticationWebAuthnCredentialsService,
+		},
+		route{
+			"authentication_webauthn_credential_delete-route",
+			"DELETE", "/apis/authentication/webauthn/credentials/:id", true, true, h.authenticationWebAuthnCredentialDeleteService,
+		},
+		route{
+			"authentication_webauthn_login_begin-route",
+			"POST", "/apis/authentication/webauthn/login/begin", true, true, h.authenticationWebAuthnLoginBeginService,
+		},
+		route{
+			"authentication_impersonate-route",
+			"POST", "/apis/authentication/impersonate", true, true, h.authenticationImpersonateService,
+		},
+		route{
+			"admin_users_force_relogin-route",
+			"POST", "/apis/authentication/users/:email/force-relogin", true, true, h.adminForceReloginService,
+		},
+		route{
+			"admin_users_unlock-route",
+			"POST", "/apis/authentication/users/:email/unlock", true, true, h.adminUnlockUserService,
+		},
+		route{
+			"admin_users_expiry-route",
+			"PUT", "/apis/authentication/users/:email/expiry", true, true, h.adminUserExpiryService,
+		},
+		route{
+			"admin_users_audit_events-route",
+			"GET", "/apis/authentication/users/:email/audit-events", true, true, h.adminAuditEventsService,
+		},
+		route{
+			"admin_users_logins-route",
+			"GET", "/apis/authentication/users/:email/logins", true, true, h.adminLoginsService,
+		},
+		route{
+			"authentication_introspect-route",
+			"POST", "/apis/authentication/introspect", true, true, h.authenticationIntrospectService,
+		},
+		route{
+			"clients-register",
+			"POST", "/apis/authentication/clients", true, true, h.registerClientService,
+		},
 		route{
 			"profiles-self",
 			"GET", "/apis/authentication/me", true, true, h.receiveOwnProfileService,
 		},
+		route{
+			"profiles-logins",
+			"GET", "/apis/authentication/me/logins", true, true, h.authenticationGetLoginsService,
+		},
 		route{
 			"exercises-self",
 			"GET", "/apis/authentication/exercises", true, true, h.authenticationGetExercisesService,
@@ -114,10 +227,58 @@ func NewHandler(requireAuthentication, loggingEnabled, writeTrace bool) *Handler
 			"exercises-current-permissions",
 			"GET", "/apis/authentication/exercise-permissions", true, true, h.authenticationGetCurrentExercisePermissions,
 		},
+		route{
+			"exercises-token",
+			"POST", "/apis/authentication/exercise-token", true, true, h.authenticationExerciseTokenService,
+		},
 		route{
 			"change-my-password",
 			"POST", "/apis/authentication/change-password", true, true, h.authenticationPasswordChangeService,
 		},
+		route{
+			"register",
+			"POST", "/apis/authentication/register", true, true, h.authenticationRegisterService,
+		},
+		route{
+			"exercise-invitations-create",
+			"POST", "/apis/authentication/exercise-invitations", true, true, h.authenticationInviteService,
+		},
+		route{
+			"exercise-invitations-list",
+			"GET", "/apis/authentication/exercise-invitations", true, true, h.authenticationGetInvitationsService,
+		},
+		route{
+			"exercise-invitations-accept",
+			"POST", "/apis/authentication/exercise-invitations/accept", true, true, h.authenticationAcceptInvitationService,
+		},
+		route{
+			"exercise-invitations-revoke",
+			"DELETE", "/apis/authentication/exercise-invitations/:id", true, true, h.authenticationRevokeInvitationService,
+		},
+		route{
+			"magic-link-request",
+			"POST", "/apis/authentication/magic-link", true, true, h.authenticationMagicLinkRequestService,
+		},
+		route{
+			"magic-link-redeem",
+			"POST", "/apis/authentication/magic-link/redeem", true, true, h.authenticationMagicLinkRedeemService,
+		},
+		route{
+			"password-reset-request",
+			"POST", "/apis/authentication/password-reset/request", true, true, h.authenticationPasswordResetRequestService,
+		},
+		route{
+			"password-reset-confirm",
+			"POST", "/apis/authentication/password-reset/confirm", true, true, h.authenticationPasswordResetConfirmService,
+		},
+		route{
+			"email-confirmation-confirm",
+			"POST", "/apis/authentication/email-confirmation/confirm", true, true, h.authenticationEmailConfirmService,
+		},
+		route{
+			"email-confirmation-resend",
+			"POST", "/apis/authentication/email-confirmation/resend", true, true, h.authenticationEmailConfirmationResendService,
+		},
 		route{
 			"exercises-users-list",
 			"GET", "/apis/authentication/user-list", true, true, h.getUsersList,
@@ -126,6 +287,14 @@ func NewHandler(requireAuthentication, loggingEnabled, writeTrace bool) *Handler
 			"authentication_options-route",
 			"OPTIONS", "/apis/authentication/:option", true, false, h.serveOptions,
 		},
+		route{
+			"jwks",
+			"GET", "/.well-known/jwks.json", true, true, h.serveJWKS,
+		},
+		route{
+			"openid-configuration",
+			"GET", "/.well-known/openid-configuration", true, true, h.serveOpenIDConfiguration,
+		},
 		route{
 			"healthcheck",
 			"GET", "/healthcheck", true, true, h.serveHealthcheck,
@@ -188,6 +357,21 @@ func (h *Handler) SetRoutes(routes []route) {
 		if hf, ok := r.handlerFunc.(func(http.ResponseWriter, *http.Request, *sitrep.ExerciseByIdentifier)); ok {
 			handler = exercisifyOnly(hf, h)
 		}
+
+		// If it's a handler func that accepts users and service clients alike, wrap it in authorization
+		if hf, ok := r.handlerFunc.(func(http.ResponseWriter, *http.Request, *models.Principal)); ok {
+			handler = authenticatePrincipal(hf, h, h.requireAuthentication)
+		}
+
+		// If it's a handler func that authorizes from exercise roles, wrap it in exercise authorization
+		if hf, ok := r.handlerFunc.(func(http.ResponseWriter, *http.Request, *models.ExerciseClaims)); ok {
+			handler = authorizeExercise(hf, h, h.requireAuthentication)
+		}
+
+		// If it's a handler func for service clients, wrap it in client authentication
+		if hf, ok := r.handlerFunc.(func(http.ResponseWriter, *http.Request, *sitrep.OauthClients)); ok {
+			handler = authenticateClient(hf, h)
+		}
 		// If it's a handler func that requires authorization, wrap it in authorization
 		// if hf, ok := r.handlerFunc.(func(http.ResponseWriter, *http.Request, *models.User)); ok {
 		// 	handler = authenticate(hf, h, h.requireAuthentication)
@@ -2,9 +2,11 @@ package httpd
 
 import (
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -120,9 +122,65 @@ func recovery(inner http.Handler, name string, weblog *log.Logger) http.Handler
 }
 
 func makeForbidden(w http.ResponseWriter, err error) {
+	if expired, ok := err.(*models.AccountExpiredError); ok {
+		accountExpiredError(w, expired)
+		return
+	}
 	httpError(w, fmt.Sprintf("You are not allowed to access this resource: %s", err.Error()), false, http.StatusForbidden)
 }
 
+// contextKey keys the values middleware passes on to handlers
+type contextKey int
+
+// principalKey keys the principal verified by the middleware
+const principalKey contextKey = iota
+
+// verifyUser verifies the access token of a user like
+// models.VerifyUserRequest, but returns the whole principal
+func verifyUser(h *Handler, accessToken string) (*models.Principal, error) {
+	principal, err := models.VerifyRequest(h.Cassandra, h.Options, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if principal.User == nil {
+		return nil, models.NewUserInvalidError()
+	}
+	return principal, nil
+}
+
+// withPrincipal passes the verified principal on to the handler, which
+// only gets the user in its arguments
+func withPrincipal(r *http.Request, principal *models.Principal) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), principalKey, principal))
+}
+
+// requestPrincipal returns the principal verified by the middleware. It is
+// nil for requests, which have not been authenticated.
+func requestPrincipal(r *http.Request) *models.Principal {
+	principal, _ := r.Context().Value(principalKey).(*models.Principal)
+	return principal
+}
+
+// impersonator returns the email of the user acting as the authenticated
+// one, which is empty unless the session is impersonated
+func impersonator(r *http.Request) string {
+	if principal := requestPrincipal(r); principal != nil && principal.IsImpersonated() {
+		return principal.Actor.Email
+	}
+	return ""
+}
+
+// refuseImpersonation keeps impersonated sessions away from the credentials
+// and the sessions of the impersonated user and from administrative tasks.
+// It reports whether the request was refused.
+func refuseImpersonation(w http.ResponseWriter, r *http.Request) bool {
+	if impersonator(r) == "" {
+		return false
+	}
+	httpError(w, "Impersonated sessions can not do this", false, http.StatusForbidden)
+	return true
+}
+
 func authenticate(inner func(http.ResponseWriter, *http.Request, *sitrep.UsersByEmail), h *Handler, requireAuthentication bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !requireAuthentication {
@@ -137,13 +195,59 @@ func authenticate(inner func(http.ResponseWriter, *http.Request, *sitrep.UsersBy
 			return
 		}
 
-		user, err := models.VerifyUserRequest(h.Cassandra, accessToken)
+		principal, err := verifyUser(h, accessToken)
+		if err != nil {
+			counter.Inc(1)
+			makeForbidden(w, err)
+			return
+		}
+		inner(w, withPrincipal(r, principal), principal.User)
+	})
+}
+
+func authenticatePrincipal(inner func(http.ResponseWriter, *http.Request, *models.Principal), h *Handler, requireAuthentication bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requireAuthentication {
+			inner(w, r, nil)
+			return
+		}
+		counter := metrics.GetOrRegisterCounter(statAuthFail, h.statMap)
+		accessToken, err := parseCredentials(r)
+		if err != nil {
+			counter.Inc(1)
+			makeForbidden(w, err)
+			return
+		}
+
+		principal, err := models.VerifyRequest(h.Cassandra, h.Options, accessToken)
 		if err != nil {
 			counter.Inc(1)
 			makeForbidden(w, err)
 			return
 		}
-		inner(w, r, user)
+		inner(w, withPrincipal(r, principal), principal)
+	})
+}
+
+func authenticateClient(inner func(http.ResponseWriter, *http.Request, *sitrep.OauthClients), h *Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter := metrics.GetOrRegisterCounter(statAuthFail, h.statMap)
+		clientID, secret, err := parseClientCredentials(r)
+		if err != nil {
+			counter.Inc(1)
+			w.Header().Set("WWW-Authenticate", `Basic realm="authentication"`)
+			httpError(w, err.Error(), false, http.StatusUnauthorized)
+			return
+		}
+
+		client, err := models.AuthenticateClient(h.Cassandra, clientID, secret)
+		if err != nil {
+			counter.Inc(1)
+			w.Header().Set("WWW-Authenticate", `Basic realm="authentication"`)
+			httpError(w, err.Error(), false, http.StatusUnauthorized)
+			return
+		}
+		inner(w, r, client)
 	})
 }
 
@@ -201,13 +305,50 @@ func exercisify(inner func(http.ResponseWriter, *http.Request, *sitrep.UsersByEm
 			return
 		}
 
-		user, err := models.VerifyUserRequest(h.Cassandra, accessToken)
+		principal, err := verifyUser(h, accessToken)
 		if err != nil {
 			counter.Inc(1)
 			makeForbidden(w, err)
 			return
 		}
-		inner(w, r, user, exercise)
+		// Impersonated sessions of controllers are limited to their exercise
+		if err := principal.CheckExercise(exercise.Id); err != nil {
+			makeForbidden(w, err)
+			return
+		}
+		if err := models.CheckExerciseMfa(h.Cassandra, principal.User, exercise); err != nil {
+			makeForbidden(w, err)
+			return
+		}
+		inner(w, withPrincipal(r, principal), principal.User, exercise)
+	})
+}
+
+// authorizeExercise authorizes requests from the roles of the caller in the
+// requested exercise. The roles of exercise tokens are taken from their
+// claims instead of the database.
+func authorizeExercise(inner func(http.ResponseWriter, *http.Request, *models.ExerciseClaims), h *Handler, requireAuthentication bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requireAuthentication {
+			inner(w, r, nil)
+			return
+		}
+		counter := metrics.GetOrRegisterCounter(statAuthFail, h.statMap)
+		accessToken, err := parseCredentials(r)
+		if err != nil {
+			counter.Inc(1)
+			makeForbidden(w, err)
+			return
+		}
+		// Exercise tokens name their exercise, so it is optional for them
+		exerciseID, _ := parseExerciseID(r)
+		claims, err := models.AuthorizeExerciseRequest(h.Cassandra, h.Options, accessToken, exerciseID)
+		if err != nil {
+			counter.Inc(1)
+			makeForbidden(w, err)
+			return
+		}
+		inner(w, r, claims)
 	})
 }
 
@@ -254,6 +395,60 @@ func parseCredentials(r *http.Request) (string, error) {
 	return "", fmt.Errorf("unable to parse Bearer Auth credentials")
 }
 
+// parseClientCredentials returns the credentials of a service client, which
+// are either sent with HTTP basic auth or as client_id and client_secret
+// form values.
+func parseClientCredentials(r *http.Request) (string, string, error) {
+	if id, secret, ok := r.BasicAuth(); ok {
+		return id, secret, nil
+	}
+	if id, secret := r.PostFormValue("client_id"), r.PostFormValue("client_secret"); id != "" && secret != "" {
+		return id, secret, nil
+	}
+	return "", "", fmt.Errorf("unable to parse client credentials")
+}
+
+// parseClientInfo returns the device a request has been sent from. Clients
+// can put anything into X-Forwarded-For, so it is only read after a trusted
+// proxy. Walking back from the proxy closest to this service, the first
+// address, which is no trusted proxy itself, is the client.
+func (h *Handler) parseClientInfo(r *http.Request) *models.ClientInfo {
+	remoteAddr := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+	if h.isTrustedProxy(remoteAddr) {
+		forwarded := strings.Split(strings.Join(r.Header["X-Forwarded-For"], ","), ",")
+		for i := len(forwarded) - 1; i >= 0; i-- {
+			addr := strings.TrimSpace(forwarded[i])
+			if addr == "" {
+				continue
+			}
+			remoteAddr = addr
+			if !h.isTrustedProxy(addr) {
+				break
+			}
+		}
+	}
+	return &models.ClientInfo{
+		UserAgent:  r.UserAgent(),
+		RemoteAddr: remoteAddr,
+	}
+}
+
+func (h *Handler) isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range h.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // parseExerciseID returns the exercise currently supplied, can be:
 // COOKIE: ex_id (string)
 // GET: ex_id (string)
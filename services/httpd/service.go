@@ -9,6 +9,10 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/models"
+	"github.com/fkasper/sitrep-authentication/webauthn"
 )
 
 // statistics gathered by the httpd package.
@@ -58,6 +62,58 @@ func NewService(c Config) *Service {
 		Logger: log.New(os.Stderr, "[httpd] ", log.LstdFlags),
 	}
 	s.Handler.Logger = s.Logger
+	if c.AccessTokenLifetime > 0 {
+		s.Handler.Options.AccessTokenLifetime = time.Duration(c.AccessTokenLifetime)
+	}
+	if c.RefreshTokenLifetime > 0 {
+		s.Handler.Options.RefreshTokenLifetime = time.Duration(c.RefreshTokenLifetime)
+	}
+	if c.ExerciseTokenLifetime > 0 {
+		s.Handler.Options.ExerciseTokenLifetime = time.Duration(c.ExerciseTokenLifetime)
+	}
+	s.Handler.Options.ExerciseTokenUserCheck = c.ExerciseTokenUserCheck
+	s.Handler.Options.Issuer = strings.TrimSuffix(c.Issuer, "/")
+	s.Handler.Options.PasswordResetURL = c.PasswordResetURL
+	s.Handler.Options.EmailConfirmationURL = c.EmailConfirmationURL
+	s.Handler.Options.InvitationURL = c.InvitationURL
+	s.Handler.Options.MagicLinkURL = c.MagicLinkURL
+	s.Handler.Options.RequireConfirmedEmail = c.RequireConfirmedEmail
+	s.Handler.Options.Registration = c.Registration
+	if c.LoginFailureWindow > 0 {
+		s.Handler.Options.LoginFailureWindow = time.Duration(c.LoginFailureWindow)
+	}
+	// A limit, lockout or delay of 0 switches that part of the protection off
+	s.Handler.Options.LoginMaxFailures = c.LoginMaxFailures
+	s.Handler.Options.LoginMaxFailuresPerAddr = c.LoginMaxFailuresPerAddr
+	s.Handler.Options.LoginMaxFailuresPerPair = c.LoginMaxFailuresPerPair
+	s.Handler.Options.LoginLockout = time.Duration(c.LoginLockout)
+	s.Handler.Options.LoginDelay = time.Duration(c.LoginDelay)
+	// A retention of 0 keeps the login history forever
+	s.Handler.Options.LoginHistoryRetention = time.Duration(c.LoginHistoryRetention)
+	// A window or limit of 0 switches rate limiting off
+	s.Handler.Options.RateLimitWindow = time.Duration(c.RateLimitWindow)
+	s.Handler.Options.RateLimitPerAddr = c.RateLimitPerAddr
+	s.Handler.Options.RateLimitPerEmail = c.RateLimitPerEmail
+	// Without trusted proxies, X-Forwarded-For is ignored and every client is
+	// the peer address of its connection
+	proxies, err := c.trustedProxies()
+	if err != nil {
+		s.Logger.Printf("X-Forwarded-For is ignored: %s", err)
+	}
+	s.Handler.TrustedProxies = proxies
+	// Passkeys are bound to the domain of the web app, which may differ from
+	// the issuer. Without settings, the host of the issuer is used.
+	if c.WebAuthnRPID != "" {
+		origins := c.WebAuthnOrigins
+		if len(origins) == 0 {
+			origins = []string{s.Handler.Options.Issuer}
+		}
+		s.Handler.Options.RelyingParty = &webauthn.RelyingParty{
+			ID:      c.WebAuthnRPID,
+			Name:    models.RelyingPartyName,
+			Origins: origins,
+		}
+	}
 	return s
 }
 
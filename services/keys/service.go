@@ -0,0 +1,86 @@
+package keys
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/signing"
+)
+
+// Service reloads the signing keys from the keys directory, so keys that
+// have been rotated on disk are picked up without a restart.
+type Service struct {
+	Config *signing.Config
+	Ring   *signing.KeyRing
+	Logger *log.Logger
+
+	wg      sync.WaitGroup
+	err     chan error
+	closing chan struct{}
+}
+
+// NewService returns a new instance of Service.
+func NewService(c *signing.Config, ring *signing.KeyRing) *Service {
+	return &Service{
+		Config:  c,
+		Ring:    ring,
+		Logger:  log.New(os.Stderr, "[keys] ", log.LstdFlags),
+		err:     make(chan error),
+		closing: make(chan struct{}),
+	}
+}
+
+// Open starts reloading the keys periodically
+func (s *Service) Open() error {
+	s.Logger.Printf("Reloading signing keys from %s every %s", s.Config.KeysDir, time.Duration(s.Config.ReloadInterval))
+	s.wg.Add(1)
+	go s.run()
+	return nil
+}
+
+// Close stops reloading the keys
+func (s *Service) Close() error {
+	close(s.closing)
+	s.wg.Wait()
+	return nil
+}
+
+// SetLogger sets the internal logger to the logger passed in.
+func (s *Service) SetLogger(l *log.Logger) {
+	s.Logger = l
+}
+
+// Err returns a channel for fatal errors that occur while reloading.
+func (s *Service) Err() <-chan error { return s.err }
+
+func (s *Service) run() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(time.Duration(s.Config.ReloadInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.reload()
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// reload reads the keys directory again. A broken directory, or one
+// without an active key, keeps the previously loaded keys in place.
+func (s *Service) reload() {
+	keys, err := signing.LoadDir(s.Config.KeysDir)
+	if err != nil {
+		s.Logger.Printf("failed to reload signing keys: %s", err)
+	} else {
+		s.Ring.Replace(keys)
+	}
+	// The keys in place may have retired meanwhile, no token can be issued
+	// until a new key is rotated in
+	if s.Ring.Active() == nil {
+		s.Logger.Printf("tokens can not be issued: %s", signing.ErrNoActiveKey)
+	}
+}
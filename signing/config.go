@@ -0,0 +1,41 @@
+package signing
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/toml"
+)
+
+const (
+	// DefaultAlgorithm is the algorithm tokens are signed with
+	DefaultAlgorithm = "RS256"
+
+	// DefaultRotationOverlap is how long a replaced key keeps verifying
+	// tokens. It has to outlast the lifetime of access tokens.
+	DefaultRotationOverlap = 24 * time.Hour
+
+	// DefaultReloadInterval is how often the keys directory is read again
+	DefaultReloadInterval = time.Minute
+)
+
+// Config represents the token signing configuration. Keys are either read
+// from a single key file or from a keys directory maintained by
+// "authentication keys rotate". Without either, tokens keep being signed
+// with the secret of each user.
+type Config struct {
+	Algorithm       string        `toml:"algorithm"`
+	KeyFile         string        `toml:"key-file"`
+	KeyID           string        `toml:"key-id"`
+	KeysDir         string        `toml:"keys-dir"`
+	RotationOverlap toml.Duration `toml:"rotation-overlap"`
+	ReloadInterval  toml.Duration `toml:"reload-interval"`
+}
+
+// NewConfig builds a new configuration with default values.
+func NewConfig() *Config {
+	return &Config{
+		Algorithm:       DefaultAlgorithm,
+		RotationOverlap: toml.Duration(DefaultRotationOverlap),
+		ReloadInterval:  toml.Duration(DefaultReloadInterval),
+	}
+}
@@ -0,0 +1,199 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Key signs and verifies tokens with a single algorithm. A key signs new
+// tokens from ActivatesAt on and verifies tokens until RetiresAt. Zero
+// times leave the window open on that side.
+type Key struct {
+	ID          string
+	Method      jwt.SigningMethod
+	ActivatesAt time.Time
+	RetiresAt   time.Time
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewKey parses a PEM encoded private key for alg, which is either RS256 or
+// ES256. If id is empty, the thumbprint of the key is used as its id.
+func NewKey(id string, alg string, pemBytes []byte) (*Key, error) {
+	k := &Key{ID: id}
+	switch alg {
+	case "RS256":
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		k.Method = jwt.SigningMethodRS256
+		k.signKey, k.verifyKey = private, &private.PublicKey
+	case "ES256":
+		private, err := jwt.ParseECPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		if private.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("ES256 requires a P-256 key")
+		}
+		k.Method = jwt.SigningMethodES256
+		k.signKey, k.verifyKey = private, &private.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+	if k.ID == "" {
+		k.ID = k.Thumbprint()
+	}
+	return k, nil
+}
+
+// LoadKey reads a PEM encoded private key from path
+func LoadKey(id string, alg string, path string) (*Key, error) {
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(id, alg, pemBytes)
+}
+
+// GenerateKey creates a new PEM encoded private key for alg
+func GenerateKey(alg string) ([]byte, error) {
+	switch alg {
+	case "RS256":
+		private, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(private),
+		}), nil
+	case "ES256":
+		private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		der, err := x509.MarshalECPrivateKey(private)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+	}
+	return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+}
+
+// NewHMACKey returns a HS512 key for a shared secret. HMAC keys have no id
+// and are never published.
+func NewHMACKey(secret string) *Key {
+	return &Key{
+		Method:    jwt.SigningMethodHS512,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+}
+
+// Sign signs claims and returns the encoded token
+func (k *Key) Sign(claims map[string]interface{}) (string, error) {
+	token := jwt.New(k.Method)
+	token.Claims = claims
+	if k.ID != "" {
+		token.Header["kid"] = k.ID
+	}
+	return token.SignedString(k.signKey)
+}
+
+// IsRetired reports whether k has stopped verifying tokens at t
+func (k *Key) IsRetired(t time.Time) bool {
+	return !k.RetiresAt.IsZero() && !t.Before(k.RetiresAt)
+}
+
+// IsActive reports whether k may sign tokens at t
+func (k *Key) IsActive(t time.Time) bool {
+	return !t.Before(k.ActivatesAt) && !k.IsRetired(t)
+}
+
+// VerifyKey returns the key tokens signed by k are verified with
+func (k *Key) VerifyKey() interface{} {
+	return k.verifyKey
+}
+
+// JWK returns the public part of k. HMAC keys can not be published.
+func (k *Key) JWK() (*JWK, bool) {
+	switch public := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: k.Method.Alg(),
+			Kid: k.ID,
+			N:   encodeSegment(public.N.Bytes()),
+			E:   encodeSegment(bigEndian(public.E)),
+		}, true
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		return &JWK{
+			Kty: "EC",
+			Use: "sig",
+			Alg: k.Method.Alg(),
+			Kid: k.ID,
+			Crv: public.Curve.Params().Name,
+			X:   encodeSegment(padded(public.X.Bytes(), size)),
+			Y:   encodeSegment(padded(public.Y.Bytes(), size)),
+		}, true
+	}
+	return nil, false
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of k, or an empty string for
+// HMAC keys
+func (k *Key) Thumbprint() string {
+	jwk, ok := k.JWK()
+	if !ok {
+		return ""
+	}
+	// The members are required in lexicographic order, which is what
+	// encoding/json produces for maps.
+	members := map[string]string{"kty": jwk.Kty}
+	switch jwk.Kty {
+	case "RSA":
+		members["e"], members["n"] = jwk.E, jwk.N
+	case "EC":
+		members["crv"], members["x"], members["y"] = jwk.Crv, jwk.X, jwk.Y
+	}
+	b, _ := json.Marshal(members)
+	sum := sha256.Sum256(b)
+	return encodeSegment(sum[:])
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func bigEndian(n int) []byte {
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return b
+}
+
+func padded(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
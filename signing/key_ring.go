@@ -0,0 +1,107 @@
+package signing
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoActiveKey is returned, when a key ring is configured, but none of its
+// keys may sign tokens right now
+var ErrNoActiveKey = errors.New("no signing key is active, run `authentication keys rotate` to create one")
+
+// KeyRing holds the keys tokens are signed and verified with. The active
+// key is the most recently activated key that has not been retired yet,
+// every other key verifies tokens until it retires.
+type KeyRing struct {
+	mu   sync.RWMutex
+	keys []*Key
+}
+
+// NewKeyRing returns a KeyRing holding keys
+func NewKeyRing(keys ...*Key) *KeyRing {
+	return &KeyRing{keys: keys}
+}
+
+// LoadKeyRing builds the KeyRing described by c. It returns nil, if no key
+// has been configured.
+func LoadKeyRing(c *Config) (*KeyRing, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.KeysDir != "" {
+		keys, err := LoadDir(c.KeysDir)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeyRing(keys...), nil
+	}
+	if c.KeyFile != "" {
+		key, err := LoadKey(c.KeyID, c.Algorithm, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeyRing(key), nil
+	}
+	return nil, nil
+}
+
+// Replace swaps the keys of the ring, e.g. after they have been rotated
+func (r *KeyRing) Replace(keys []*Key) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = keys
+}
+
+// Active returns the key new tokens are signed with, or nil if no key is
+// active right now
+func (r *KeyRing) Active() *Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	var active *Key
+	for _, k := range r.keys {
+		if !k.IsActive(now) {
+			continue
+		}
+		if active == nil || k.ActivatesAt.After(active.ActivatesAt) {
+			active = k
+		}
+	}
+	return active
+}
+
+// Lookup returns the key with the given id, as long as it has not retired
+func (r *KeyRing) Lookup(id string) (*Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	for _, k := range r.keys {
+		if k.ID == id && !k.IsRetired(now) {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+// JWKS returns the public keys of the ring that have not retired. Keys
+// are published before they activate, so verifiers already know them by
+// the time they sign the first token.
+func (r *KeyRing) JWKS() *JWKS {
+	set := &JWKS{Keys: make([]*JWK, 0)}
+	if r == nil {
+		return set
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	for _, k := range r.keys {
+		if k.IsRetired(now) {
+			continue
+		}
+		if jwk, ok := k.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
@@ -0,0 +1,104 @@
+package signing_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/signing"
+)
+
+func mustKey(t *testing.T, id string, activatesAt, retiresAt time.Time) *signing.Key {
+	key, err := signing.NewKey(id, "ES256", ecPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.ActivatesAt, key.RetiresAt = activatesAt, retiresAt
+	return key
+}
+
+func TestKeyRing_Windows(t *testing.T) {
+	now := time.Now()
+	retired := mustKey(t, "retired", now.Add(-2*time.Hour), now.Add(-time.Hour))
+	old := mustKey(t, "old", now.Add(-time.Hour), now.Add(time.Hour))
+	current := mustKey(t, "current", now.Add(-time.Minute), time.Time{})
+	pending := mustKey(t, "pending", now.Add(time.Hour), time.Time{})
+	ring := signing.NewKeyRing(retired, old, current, pending)
+
+	if active := ring.Active(); active == nil || active.ID != "current" {
+		t.Fatalf("unexpected active key: %v", active)
+	}
+	if _, ok := ring.Lookup("retired"); ok {
+		t.Fatal("a retired key still verifies tokens")
+	}
+	for _, id := range []string{"old", "current", "pending"} {
+		if _, ok := ring.Lookup(id); !ok {
+			t.Fatalf("key %s does not verify tokens", id)
+		}
+	}
+	if n := len(ring.JWKS().Keys); n != 3 {
+		t.Fatalf("unexpected number of published keys: %d", n)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	first, err := signing.Rotate(dir, "ES256", now.Add(-time.Minute), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := signing.Rotate(dir, "RS256", now, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := signing.LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := signing.NewKeyRing(keys...)
+	if active := ring.Active(); active == nil || active.ID != second.ID {
+		t.Fatalf("the rotated key is not active: %v", active)
+	}
+	old, ok := ring.Lookup(first.ID)
+	if !ok {
+		t.Fatal("the replaced key stopped verifying tokens during the overlap")
+	}
+	if !old.RetiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected retirement of the replaced key: %s", old.RetiresAt)
+	}
+}
+
+func TestLoadDir_Empty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := signing.LoadDir(dir); err == nil {
+		t.Fatal("expected an error for a directory without keys")
+	}
+}
+
+func TestLoadDir_NoActiveKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := signing.Rotate(dir, "ES256", time.Now().Add(time.Hour), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signing.LoadDir(dir); err == nil {
+		t.Fatal("expected an error for a directory without an active key")
+	}
+}
@@ -0,0 +1,91 @@
+package signing_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/fkasper/sitrep-authentication/signing"
+)
+
+func rsaPEM(t *testing.T) []byte {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+}
+
+func ecPEM(t *testing.T) []byte {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestKey_SignAndVerify(t *testing.T) {
+	for alg, pemBytes := range map[string][]byte{"RS256": rsaPEM(t), "ES256": ecPEM(t)} {
+		key, err := signing.NewKey("", alg, pemBytes)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", alg, err)
+		}
+		if key.ID == "" {
+			t.Fatalf("%s: key id was not derived from the thumbprint", alg)
+		}
+		signed, err := key.Sign(map[string]interface{}{"sub": "someguy@somedomain.com"})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", alg, err)
+		}
+		token, err := jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+			if token.Header["kid"] != key.ID {
+				t.Fatalf("%s: unexpected kid: %v", alg, token.Header["kid"])
+			}
+			return key.VerifyKey(), nil
+		})
+		if err != nil || !token.Valid {
+			t.Fatalf("%s: token could not be verified: %v", alg, err)
+		}
+	}
+}
+
+func TestKey_UnsupportedAlgorithm(t *testing.T) {
+	if _, err := signing.NewKey("", "HS256", rsaPEM(t)); err == nil {
+		t.Fatal("expected an error for an unsupported algorithm")
+	}
+	if _, err := signing.NewKey("", "ES256", rsaPEM(t)); err == nil {
+		t.Fatal("expected an error for a key of the wrong type")
+	}
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	key, err := signing.NewKey("key-1", "ES256", ecPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := signing.NewKeyRing(key).JWKS()
+	if len(set.Keys) != 1 {
+		t.Fatalf("unexpected number of keys: %d", len(set.Keys))
+	}
+	jwk := set.Keys[0]
+	if jwk.Kid != "key-1" || jwk.Kty != "EC" || jwk.Crv != "P-256" || jwk.Alg != "ES256" {
+		t.Fatalf("unexpected jwk: %+v", jwk)
+	}
+	if len(jwk.X) != 43 || len(jwk.Y) != 43 {
+		t.Fatalf("coordinates are not padded: %+v", jwk)
+	}
+
+	var ring *signing.KeyRing
+	if set := ring.JWKS(); set.Keys == nil || len(set.Keys) != 0 {
+		t.Fatalf("a missing key ring must publish an empty set")
+	}
+}
@@ -0,0 +1,134 @@
+package signing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFile is the name of the file describing the keys of a keys
+// directory
+const ManifestFile = "keys.json"
+
+// manifest lists the keys of a keys directory together with their windows
+type manifest struct {
+	Keys []*manifestEntry `json:"keys"`
+}
+
+type manifestEntry struct {
+	ID          string    `json:"id"`
+	Algorithm   string    `json:"algorithm"`
+	File        string    `json:"file"`
+	ActivatesAt time.Time `json:"activates_at"`
+	RetiresAt   time.Time `json:"retires_at"`
+}
+
+func readManifest(dir string) (*manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return &manifest{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %s", ManifestFile, err)
+	}
+	return &m, nil
+}
+
+func (m *manifest) write(dir string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a server reloading the directory
+	// never sees a partially written manifest.
+	path := filepath.Join(dir, ManifestFile)
+	if err := ioutil.WriteFile(path+".tmp", b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// LoadDir reads every key listed in the manifest of dir
+func LoadDir(dir string) ([]*Key, error) {
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Keys) == 0 {
+		return nil, fmt.Errorf("no signing keys in %s, run `authentication keys rotate` to create one", dir)
+	}
+	keys := make([]*Key, 0, len(m.Keys))
+	for _, entry := range m.Keys {
+		key, err := LoadKey(entry.ID, entry.Algorithm, filepath.Join(dir, entry.File))
+		if err != nil {
+			return nil, fmt.Errorf("load key %s: %s", entry.ID, err)
+		}
+		key.ActivatesAt = entry.ActivatesAt
+		key.RetiresAt = entry.RetiresAt
+		keys = append(keys, key)
+	}
+	// Verifiers only trust the published keys, tokens must never be signed
+	// with anything else
+	if NewKeyRing(keys...).Active() == nil {
+		return nil, fmt.Errorf("%s: %s", dir, ErrNoActiveKey)
+	}
+	return keys, nil
+}
+
+// Rotate adds a new key for alg to dir, which starts signing tokens at
+// activatesAt. Every key that has not retired yet keeps verifying tokens
+// for overlap after the new key activates. Keys that have retired already
+// are removed from dir.
+func Rotate(dir string, alg string, activatesAt time.Time, overlap time.Duration) (*Key, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	pemBytes, err := GenerateKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	key, err := NewKey("", alg, pemBytes)
+	if err != nil {
+		return nil, err
+	}
+	key.ActivatesAt = activatesAt
+
+	file := key.ID + ".pem"
+	if err := ioutil.WriteFile(filepath.Join(dir, file), pemBytes, 0600); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	retiresAt := activatesAt.Add(overlap)
+	keep := make([]*manifestEntry, 0, len(m.Keys)+1)
+	for _, entry := range m.Keys {
+		if !entry.RetiresAt.IsZero() && !now.Before(entry.RetiresAt) {
+			os.Remove(filepath.Join(dir, entry.File))
+			continue
+		}
+		if entry.RetiresAt.IsZero() || entry.RetiresAt.After(retiresAt) {
+			entry.RetiresAt = retiresAt
+		}
+		keep = append(keep, entry)
+	}
+	m.Keys = append(keep, &manifestEntry{
+		ID:          key.ID,
+		Algorithm:   alg,
+		File:        file,
+		ActivatesAt: activatesAt,
+	})
+	if err := m.write(dir); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
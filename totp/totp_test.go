@@ -0,0 +1,62 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/totp"
+)
+
+// The SHA1 secret of the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFCVectors(t *testing.T) {
+	for unix, expected := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := totp.Code(rfcSecret, totp.Step(time.Unix(unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != expected {
+			t.Fatalf("%d: expected %s, got %s", unix, expected, code)
+		}
+	}
+}
+
+func TestValidate_Skew(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	code, _ := totp.Code(rfcSecret, totp.Step(now))
+	if _, ok := totp.Validate(rfcSecret, code, now.Add(totp.Period*time.Second)); !ok {
+		t.Fatalf("code of the previous period was refused")
+	}
+	if _, ok := totp.Validate(rfcSecret, code, now.Add(3*totp.Period*time.Second)); ok {
+		t.Fatalf("outdated code was accepted")
+	}
+	if _, ok := totp.Validate(rfcSecret, "12345", now); ok {
+		t.Fatalf("short code was accepted")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	code, err := totp.Code(strings.ToLower(secret), totp.Step(now))
+	if err != nil {
+		t.Fatalf("generated secret could not be decoded: %s", err)
+	}
+	if step, ok := totp.Validate(secret, code, now); !ok || step != totp.Step(now) {
+		t.Fatalf("code of a generated secret was refused")
+	}
+	if uri := totp.URI("SITREP", "someguy@somedomain.com", secret); !strings.HasPrefix(uri, "otpauth://totp/SITREP:someguy%40somedomain.com?") {
+		t.Fatalf("unexpected uri: %s", uri)
+	}
+}
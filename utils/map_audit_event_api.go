@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+// APIAuditEvent represents a security relevant event of a user
+type APIAuditEvent struct {
+	Event      string    `json:"event"`
+	Details    string    `json:"details,omitempty"`
+	UserAgent  string    `json:"user_agent"`
+	RemoteAddr string    `json:"ip"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// MapAuditEvents polishes audit log records for an API.
+func MapAuditEvents(events []sitrep.AuditEvents) []*APIAuditEvent {
+	mapped := make([]*APIAuditEvent, 0, len(events))
+	for _, event := range events {
+		mapped = append(mapped, &APIAuditEvent{
+			Event:      event.Event,
+			Details:    event.Details,
+			UserAgent:  event.UserAgent,
+			RemoteAddr: event.RemoteAddr,
+			OccurredAt: event.EventId.Time(),
+		})
+	}
+	return mapped
+}
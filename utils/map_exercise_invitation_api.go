@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+// APIExerciseInvitation represents a pending invitation into an exercise
+type APIExerciseInvitation struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MapExerciseInvitation polishes a pending invitation for an API. The token
+// itself is never stored, its hash identifies the invitation.
+func MapExerciseInvitation(invitation *sitrep.ExerciseInvitations) *APIExerciseInvitation {
+	return &APIExerciseInvitation{
+		ID:        invitation.TokenHash,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy,
+		CreatedAt: invitation.CreatedAt,
+		ExpiresAt: invitation.ExpiresAt,
+	}
+}
+
+// MapExerciseInvitations polishes a list of pending invitations for an API
+func MapExerciseInvitations(invitations []sitrep.ExerciseInvitations) []*APIExerciseInvitation {
+	mapped := make([]*APIExerciseInvitation, 0, len(invitations))
+	for i := range invitations {
+		mapped = append(mapped, MapExerciseInvitation(&invitations[i]))
+	}
+	return mapped
+}
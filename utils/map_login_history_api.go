@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+// APILogin represents a sign in attempt of a user
+type APILogin struct {
+	ID            string    `json:"id"`
+	Outcome       string    `json:"outcome"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	UserAgent     string    `json:"user_agent"`
+	RemoteAddr    string    `json:"ip"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// MapLoginHistory polishes login history records for an API.
+func MapLoginHistory(logins []sitrep.LoginHistory) []*APILogin {
+	mapped := make([]*APILogin, 0, len(logins))
+	for _, login := range logins {
+		mapped = append(mapped, &APILogin{
+			ID:            login.LoginId.String(),
+			Outcome:       login.Outcome,
+			FailureReason: login.FailureReason,
+			UserAgent:     login.UserAgent,
+			RemoteAddr:    login.RemoteAddr,
+			OccurredAt:    login.LoginId.Time(),
+		})
+	}
+	return mapped
+}
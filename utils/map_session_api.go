@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+// APISession represents a device a user is logged in with
+type APISession struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	RemoteAddr string    `json:"ip"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// MapSessions polishes session records for an API.
+func MapSessions(sessions []sitrep.RefreshTokenFamilies) []*APISession {
+	mapped := make([]*APISession, 0, len(sessions))
+	for _, session := range sessions {
+		mapped = append(mapped, &APISession{
+			ID:         session.FamilyId.String(),
+			UserAgent:  session.UserAgent,
+			RemoteAddr: session.RemoteAddr,
+			IssuedAt:   session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+		})
+	}
+	return mapped
+}
@@ -9,9 +9,11 @@ import (
 // APIUser represents safe to use user fields
 type APIUser struct {
 	UserEmail       string    `json:"email"`
+	Name            string    `json:"name"`
 	IsAdmin         bool      `json:"is_admin"`
 	Rank            string    `json:"rank"`
 	Title           string    `json:"title"`
+	Unit            string    `json:"unit"`
 	SelfDescription string    `json:"self_description"`
 	TwitterAlias    string    `json:"twitter_alias"`
 	IsAnalyzed      bool      `json:"has_tracking"`
@@ -26,9 +28,11 @@ func MapUser(user *sitrep.UsersByEmail) *APIUser {
 	}
 	return &APIUser{
 		UserEmail:       user.Email,
+		Name:            user.RealName,
 		IsAdmin:         user.IsAdmin,
 		Rank:            user.UserRank,
 		Title:           user.UserTitle,
+		Unit:            user.UserUnit,
 		SelfDescription: user.UserSelfDescription,
 		TwitterAlias:    user.TwitterName,
 		IsAnalyzed:      user.IsAnalyzed,
@@ -36,3 +40,21 @@ func MapUser(user *sitrep.UsersByEmail) *APIUser {
 		LastLoggedIn:    user.LastLoggedIn,
 	}
 }
+
+// MapUserClaims maps a user to the OpenID Connect claims of its profile.
+// Claims that are not standardized keep the names of the profile API.
+func MapUserClaims(user *sitrep.UsersByEmail) map[string]interface{} {
+	profile := MapUser(user)
+	if profile == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"sub":            profile.UserEmail,
+		"email":          profile.UserEmail,
+		"email_verified": user.IsConfirmed,
+		"name":           profile.Name,
+		"rank":           profile.Rank,
+		"title":          profile.Title,
+		"unit":           profile.Unit,
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/fkasper/sitrep-authentication/schema"
+)
+
+// APIWebAuthnCredential represents a passkey or security key of a user
+type APIWebAuthnCredential struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// MapWebAuthnCredentials polishes registered credentials for an API. Public
+// keys are left out, they are of no use to clients.
+func MapWebAuthnCredentials(credentials []sitrep.WebauthnCredentials) []*APIWebAuthnCredential {
+	mapped := make([]*APIWebAuthnCredential, 0, len(credentials))
+	for _, credential := range credentials {
+		mapped = append(mapped, &APIWebAuthnCredential{
+			ID:         credential.CredentialId,
+			Name:       credential.Name,
+			CreatedAt:  credential.CreatedAt,
+			LastUsedAt: credential.LastUsedAt,
+		})
+	}
+	return mapped
+}
@@ -0,0 +1,106 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// errMalformed is returned for CBOR data that could not be decoded
+var errMalformed = errors.New("webauthn: malformed CBOR data")
+
+// maxNesting limits how deep arrays and maps may be nested. COSE keys and
+// attestation objects never nest deeper than a few levels.
+const maxNesting = 8
+
+// decodeCBOR decodes the first CBOR item of b and returns it together with
+// the remaining bytes. Only the subset used by authenticators is supported:
+// integers, byte and text strings, arrays, maps and simple values. Integers
+// are returned as int64, maps as map[interface{}]interface{}.
+func decodeCBOR(b []byte) (interface{}, []byte, error) {
+	return decodeCBORItem(b, 0)
+}
+
+func decodeCBORItem(b []byte, depth int) (interface{}, []byte, error) {
+	if len(b) == 0 || depth > maxNesting {
+		return nil, nil, errMalformed
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	b = b[1:]
+
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, b, nil
+		case 21:
+			return true, b, nil
+		case 22, 23:
+			return nil, b, nil
+		}
+		return nil, nil, errMalformed
+	}
+
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info == 24 && len(b) >= 1:
+		n, b = uint64(b[0]), b[1:]
+	case info == 25 && len(b) >= 2:
+		n, b = uint64(binary.BigEndian.Uint16(b)), b[2:]
+	case info == 26 && len(b) >= 4:
+		n, b = uint64(binary.BigEndian.Uint32(b)), b[4:]
+	case info == 27 && len(b) >= 8:
+		n, b = binary.BigEndian.Uint64(b), b[8:]
+	default:
+		// Indefinite lengths are never used by authenticators
+		return nil, nil, errMalformed
+	}
+
+	// Lengths are sent by the client. Every string byte, array item and map
+	// entry takes at least one byte, so longer lengths can not be valid and
+	// would only allocate memory.
+	if major >= 2 && n > uint64(len(b)) {
+		return nil, nil, errMalformed
+	}
+
+	switch major {
+	case 0:
+		return int64(n), b, nil
+	case 1:
+		return -1 - int64(n), b, nil
+	case 2, 3:
+		if major == 2 {
+			return b[:n], b[n:], nil
+		}
+		return string(b[:n]), b[n:], nil
+	case 4:
+		items := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			item, rest, err := decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			items, b = append(items, item), rest
+		}
+		return items, b, nil
+	case 5:
+		m := make(map[interface{}]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			key, rest, err := decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			if _, ok := key.([]byte); ok {
+				return nil, nil, errMalformed
+			}
+			value, rest, err := decodeCBORItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key], b = value, rest
+		}
+		return m, b, nil
+	}
+	// Tags are not used by authenticators either
+	return nil, nil, errMalformed
+}
@@ -0,0 +1,105 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers of the supported credential types
+const (
+	AlgES256 = -7
+	AlgRS256 = -257
+)
+
+// COSE key parameters, see RFC 8152
+const (
+	coseKty    = 1
+	coseAlg    = 3
+	coseCrv    = -1
+	coseX      = -2
+	coseY      = -3
+	coseN      = -1
+	coseE      = -2
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+	coseP256   = 1
+)
+
+// parsePublicKey decodes a COSE encoded public key
+func parsePublicKey(coseKey []byte) (crypto.PublicKey, int64, error) {
+	item, _, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	m, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, errMalformed
+	}
+	kty, _ := m[int64(coseKty)].(int64)
+	alg, _ := m[int64(coseAlg)].(int64)
+
+	switch {
+	case kty == coseKtyEC2 && alg == AlgES256:
+		crv, _ := m[int64(coseCrv)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		y, _ := m[int64(coseY)].([]byte)
+		if crv != coseP256 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("webauthn: unsupported EC2 key")
+		}
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, 0, errors.New("webauthn: EC2 key is not on its curve")
+		}
+		return key, alg, nil
+	case kty == coseKtyRSA && alg == AlgRS256:
+		n, _ := m[int64(coseN)].([]byte)
+		e, _ := m[int64(coseE)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("webauthn: unsupported RSA key")
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, alg, nil
+	}
+	return nil, 0, fmt.Errorf("webauthn: unsupported key type %d with algorithm %d", kty, alg)
+}
+
+// verifySignature checks an assertion signature over data
+func verifySignature(coseKey []byte, data []byte, signature []byte) error {
+	key, _, err := parsePublicKey(coseKey)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(data)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		var sig struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) > 0 {
+			return errors.New("webauthn: malformed signature")
+		}
+		if !ecdsa.Verify(key, digest[:], sig.R, sig.S) {
+			return errors.New("webauthn: invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("webauthn: invalid signature")
+		}
+		return nil
+	}
+	return errors.New("webauthn: unsupported key")
+}
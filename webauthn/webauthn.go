@@ -0,0 +1,301 @@
+// Package webauthn implements the server side of the WebAuthn registration
+// and assertion ceremonies for passkeys and security keys. Attestation
+// statements are not verified, credentials are trusted on first use.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	// CeremonyTimeout is how long a user has to answer a ceremony
+	CeremonyTimeout = 5 * time.Minute
+
+	typeCreate = "webauthn.create"
+	typeGet    = "webauthn.get"
+
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+// RelyingParty identifies this service towards authenticators. ID is the
+// domain credentials are scoped to, Origins lists the web origins allowed
+// to run ceremonies.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// Credential is a public key credential registered by an authenticator
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	Algorithm int64
+	SignCount uint32
+}
+
+// UserEntity describes the user a credential is created for
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialDescriptor references an existing credential
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// CredentialParameter lists an acceptable credential algorithm
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// AuthenticatorSelection asks for capabilities of the authenticator
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// RelyingPartyEntity is how the relying party is presented to the user
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreationOptions are passed to navigator.credentials.create() after the
+// binary fields have been decoded from base64url
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are passed to navigator.credentials.get() after the binary
+// fields have been decoded from base64url
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// ClientData is what the browser signs alongside the authenticator data
+type ClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// NewChallenge generates a random ceremony challenge
+func NewChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return EncodeID(b), nil
+}
+
+// EncodeID encodes binary ids the way they are exchanged with browsers
+func EncodeID(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeID decodes a binary id sent by a browser
+func DecodeID(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// NewCreationOptions starts a registration ceremony for user. Credentials
+// in exclude are not registered again.
+func (rp *RelyingParty) NewCreationOptions(challenge string, user UserEntity, exclude [][]byte) *CreationOptions {
+	return &CreationOptions{
+		Challenge: challenge,
+		RP:        RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:      user,
+		PubKeyCredParams: []CredentialParameter{
+			{Type: "public-key", Alg: AlgES256},
+			{Type: "public-key", Alg: AlgRS256},
+		},
+		Timeout:            int64(CeremonyTimeout / time.Millisecond),
+		ExcludeCredentials: descriptors(exclude),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}
+}
+
+// NewRequestOptions starts an assertion ceremony for the given credentials
+func (rp *RelyingParty) NewRequestOptions(challenge string, allow [][]byte) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          int64(CeremonyTimeout / time.Millisecond),
+		RPID:             rp.ID,
+		AllowCredentials: descriptors(allow),
+		UserVerification: "required",
+	}
+}
+
+func descriptors(ids [][]byte) []CredentialDescriptor {
+	d := make([]CredentialDescriptor, 0, len(ids))
+	for _, id := range ids {
+		d = append(d, CredentialDescriptor{Type: "public-key", ID: EncodeID(id)})
+	}
+	return d
+}
+
+// ParseClientData decodes the client data of a ceremony. The challenge it
+// contains identifies the ceremony, but is only trusted once verified.
+func ParseClientData(clientDataJSON []byte) (*ClientData, error) {
+	var c ClientData
+	if err := json.Unmarshal(clientDataJSON, &c); err != nil {
+		return nil, errors.New("webauthn: malformed client data")
+	}
+	return &c, nil
+}
+
+// VerifyRegistration completes a registration ceremony and returns the new
+// credential
+func (rp *RelyingParty) VerifyRegistration(challenge string, clientDataJSON []byte, attestationObject []byte) (*Credential, error) {
+	if err := rp.verifyClientData(typeCreate, challenge, clientDataJSON); err != nil {
+		return nil, err
+	}
+	item, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, err
+	}
+	attestation, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, errMalformed
+	}
+	authData, _ := attestation["authData"].([]byte)
+	data, err := rp.parseAuthenticatorData(authData, true)
+	if err != nil {
+		return nil, err
+	}
+	return data.credential, nil
+}
+
+// VerifyAssertion completes an assertion ceremony with credential. It returns
+// the new signature counter of the credential, which has to be stored.
+func (rp *RelyingParty) VerifyAssertion(challenge string, credential *Credential, clientDataJSON []byte, authenticatorData []byte, signature []byte) (uint32, error) {
+	if err := rp.verifyClientData(typeGet, challenge, clientDataJSON); err != nil {
+		return 0, err
+	}
+	data, err := rp.parseAuthenticatorData(authenticatorData, false)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+	if err := verifySignature(credential.PublicKey, signed, signature); err != nil {
+		return 0, err
+	}
+	// A counter that does not move forward hints at a cloned authenticator.
+	// Authenticators without a counter always report zero.
+	if (data.signCount != 0 || credential.SignCount != 0) && data.signCount <= credential.SignCount {
+		return 0, errors.New("webauthn: signature counter did not increase")
+	}
+	return data.signCount, nil
+}
+
+func (rp *RelyingParty) verifyClientData(ceremony string, challenge string, clientDataJSON []byte) error {
+	c, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+	if c.Type != ceremony {
+		return errors.New("webauthn: unexpected ceremony")
+	}
+	if challenge == "" || c.Challenge != challenge {
+		return errors.New("webauthn: challenge mismatch")
+	}
+	for _, origin := range rp.Origins {
+		if c.Origin == origin {
+			return nil
+		}
+	}
+	return errors.New("webauthn: origin is not allowed")
+}
+
+type authenticatorData struct {
+	flags      byte
+	signCount  uint32
+	credential *Credential
+}
+
+// parseAuthenticatorData decodes authenticator data and checks, that it was
+// created for this relying party with a verified user. Only registrations
+// attest a credential. Assertions are parsed before their signature is
+// checked, so attested data in them is refused instead of decoded.
+func (rp *RelyingParty) parseAuthenticatorData(b []byte, attested bool) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, errors.New("webauthn: authenticator data is too short")
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(b[:32], rpIDHash[:]) {
+		return nil, errors.New("webauthn: credential belongs to another relying party")
+	}
+	data := &authenticatorData{
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+	if data.flags&flagUserPresent == 0 || data.flags&flagUserVerified == 0 {
+		return nil, errors.New("webauthn: user was not verified")
+	}
+	if !attested {
+		if data.flags&flagAttestedData != 0 {
+			return nil, errors.New("webauthn: unexpected attested credential")
+		}
+		return data, nil
+	}
+	if data.flags&flagAttestedData == 0 {
+		return nil, errors.New("webauthn: no credential was attested")
+	}
+
+	rest := b[37:]
+	if len(rest) < 18 {
+		return nil, errMalformed
+	}
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < idLength {
+		return nil, errMalformed
+	}
+	id := rest[:idLength]
+	_, extensions, err := decodeCBOR(rest[idLength:])
+	if err != nil {
+		return nil, err
+	}
+	publicKey := rest[idLength : len(rest)-len(extensions)]
+	_, alg, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	data.credential = &Credential{
+		ID:        append([]byte{}, id...),
+		PublicKey: append([]byte{}, publicKey...),
+		Algorithm: alg,
+		SignCount: data.signCount,
+	}
+	return data, nil
+}
@@ -0,0 +1,132 @@
+package webauthn_test
+
+import (
+	"testing"
+
+	"github.com/fkasper/sitrep-authentication/webauthn"
+	"github.com/fkasper/sitrep-authentication/webauthn/webauthntest"
+)
+
+var rp = &webauthn.RelyingParty{
+	ID:      "sitrep-vatcinc.com",
+	Name:    "SITREP",
+	Origins: []string{"https://sitrep-vatcinc.com"},
+}
+
+func register(t *testing.T, a *webauthntest.Authenticator) *webauthn.Credential {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientData, attestation, err := a.Register(challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential, err := rp.VerifyRegistration(challenge, clientData, attestation)
+	if err != nil {
+		t.Fatalf("registration failed: %s", err)
+	}
+	return credential
+}
+
+func TestCeremonies(t *testing.T) {
+	a, err := webauthntest.NewAuthenticator(rp.ID, rp.Origins[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential := register(t, a)
+	if webauthn.EncodeID(credential.ID) != webauthn.EncodeID(a.CredentialID()) {
+		t.Fatalf("unexpected credential id")
+	}
+	if credential.Algorithm != webauthn.AlgES256 {
+		t.Fatalf("unexpected algorithm: %d", credential.Algorithm)
+	}
+
+	challenge, _ := webauthn.NewChallenge()
+	clientData, authData, signature, err := a.Assert(challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := rp.VerifyAssertion(challenge, credential, clientData, authData, signature)
+	if err != nil {
+		t.Fatalf("assertion failed: %s", err)
+	}
+	if counter != 1 {
+		t.Fatalf("unexpected signature counter: %d", counter)
+	}
+
+	credential.SignCount = counter
+	if _, err := rp.VerifyAssertion(challenge, credential, clientData, authData, signature); err == nil {
+		t.Fatalf("replayed assertion was accepted")
+	}
+	other, _ := webauthn.NewChallenge()
+	if _, err := rp.VerifyAssertion(other, credential, clientData, authData, signature); err == nil {
+		t.Fatalf("assertion for another challenge was accepted")
+	}
+}
+
+func TestCeremonies_WrongOrigin(t *testing.T) {
+	a, err := webauthntest.NewAuthenticator(rp.ID, "https://phishing.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge, _ := webauthn.NewChallenge()
+	clientData, attestation, _ := a.Register(challenge)
+	if _, err := rp.VerifyRegistration(challenge, clientData, attestation); err == nil {
+		t.Fatalf("registration from a foreign origin was accepted")
+	}
+}
+
+func TestCeremonies_WrongRelyingParty(t *testing.T) {
+	a, err := webauthntest.NewAuthenticator("example.com", rp.Origins[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge, _ := webauthn.NewChallenge()
+	clientData, attestation, _ := a.Register(challenge)
+	if _, err := rp.VerifyRegistration(challenge, clientData, attestation); err == nil {
+		t.Fatalf("credential of another relying party was accepted")
+	}
+}
+
+func TestCeremonies_ForgedSignature(t *testing.T) {
+	a, _ := webauthntest.NewAuthenticator(rp.ID, rp.Origins[0])
+	credential := register(t, a)
+	b, _ := webauthntest.NewAuthenticator(rp.ID, rp.Origins[0])
+
+	challenge, _ := webauthn.NewChallenge()
+	clientData, authData, signature, _ := b.Assert(challenge)
+	if _, err := rp.VerifyAssertion(challenge, credential, clientData, authData, signature); err == nil {
+		t.Fatalf("assertion of another key was accepted")
+	}
+}
+
+func TestCeremonies_MalformedCBOR(t *testing.T) {
+	a, _ := webauthntest.NewAuthenticator(rp.ID, rp.Origins[0])
+	challenge, _ := webauthn.NewChallenge()
+	clientData, _, _ := a.Register(challenge)
+	for _, attestation := range [][]byte{
+		{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0xbb, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff},
+		{0x5a, 0x7f, 0xff, 0xff, 0xff, 0x00},
+		{0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0x00},
+	} {
+		if _, err := rp.VerifyRegistration(challenge, clientData, attestation); err == nil {
+			t.Fatalf("malformed attestation %x was accepted", attestation)
+		}
+	}
+}
+
+func TestCeremonies_AttestedDataInAssertion(t *testing.T) {
+	a, _ := webauthntest.NewAuthenticator(rp.ID, rp.Origins[0])
+	credential := register(t, a)
+
+	challenge, _ := webauthn.NewChallenge()
+	clientData, authData, signature, _ := a.Assert(challenge)
+	authData[32] |= 0x40
+	authData = append(authData, make([]byte, 18)...)
+	authData = append(authData, 0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)
+	if _, err := rp.VerifyAssertion(challenge, credential, clientData, authData, signature); err == nil {
+		t.Fatalf("assertion with attested data was accepted")
+	}
+}
@@ -0,0 +1,160 @@
+// Package webauthntest provides a software authenticator, which runs the
+// WebAuthn ceremonies without any hardware.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+)
+
+// Authenticator holds a single ES256 credential. Origin is the web origin
+// the simulated browser reports.
+type Authenticator struct {
+	RPID      string
+	Origin    string
+	SignCount uint32
+
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+}
+
+// NewAuthenticator creates an authenticator with a new credential
+func NewAuthenticator(rpID string, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Authenticator{RPID: rpID, Origin: origin, key: key, credentialID: id}, nil
+}
+
+// CredentialID returns the id of the credential
+func (a *Authenticator) CredentialID() []byte {
+	return a.credentialID
+}
+
+// Register answers a registration ceremony with a "none" attestation
+func (a *Authenticator) Register(challenge string) ([]byte, []byte, error) {
+	clientData, err := a.clientData("webauthn.create", challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+	authData := a.authenticatorData(0x45)
+	authData = append(authData, make([]byte, 16)...)
+	idLength := make([]byte, 2)
+	binary.BigEndian.PutUint16(idLength, uint16(len(a.credentialID)))
+	authData = append(authData, idLength...)
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, a.publicKey()...)
+
+	attestation := cborMap(
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborMap(),
+		cborText("authData"), cborBytes(authData),
+	)
+	return clientData, attestation, nil
+}
+
+// Assert answers an assertion ceremony. The signature counter is increased
+// with every assertion.
+func (a *Authenticator) Assert(challenge string) ([]byte, []byte, []byte, error) {
+	clientData, err := a.clientData("webauthn.get", challenge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	a.SignCount++
+	authData := a.authenticatorData(0x05)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	r, s, err := ecdsa.Sign(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return clientData, authData, signature, nil
+}
+
+func (a *Authenticator) clientData(ceremony string, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    a.Origin,
+	})
+}
+
+func (a *Authenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	counter := make([]byte, 4)
+	binary.BigEndian.PutUint32(counter, a.SignCount)
+	return append(data, counter...)
+}
+
+// publicKey encodes the public key of the credential as COSE key
+func (a *Authenticator) publicKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	xb, yb := a.key.X.Bytes(), a.key.Y.Bytes()
+	copy(x[32-len(xb):], xb)
+	copy(y[32-len(yb):], yb)
+	return cborMap(
+		cborInt(1), cborInt(2),
+		cborInt(3), cborInt(-7),
+		cborInt(-1), cborInt(1),
+		cborInt(-2), cborBytes(x),
+		cborInt(-3), cborBytes(y),
+	)
+}
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 1<<8:
+		return []byte{major<<5 | 24, byte(n)}
+	case n < 1<<16:
+		b := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	}
+	b := []byte{major<<5 | 26, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(b[1:], uint32(n))
+	return b
+}
+
+func cborInt(n int64) []byte {
+	if n < 0 {
+		return cborHead(1, uint64(-1-n))
+	}
+	return cborHead(0, uint64(n))
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, uint64(len(b))), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, uint64(len(s))), s...)
+}
+
+// cborMap encodes alternating keys and values
+func cborMap(items ...[]byte) []byte {
+	b := cborHead(5, uint64(len(items)/2))
+	for _, item := range items {
+		b = append(b, item...)
+	}
+	return b
+}